@@ -0,0 +1,150 @@
+package datediff
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AcademicTerm is the term of the academic year. Start and end dates are
+// the first and the last days of the term, the time of the day is ignored.
+type AcademicTerm struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// AcademicYear is the school year that consists of terms, i.e autumn, spring
+// and summer terms. Breaks between terms are not counted as school time.
+type AcademicYear struct {
+	Name  string
+	Terms []AcademicTerm
+}
+
+// AcademicCalendar defines academic years and their terms. It's used to
+// calculate enrollment durations in school years, terms, weeks and days.
+type AcademicCalendar struct {
+	years []academicYear
+}
+
+// academicYear keeps day numbers of the first and the day after the last
+// days of terms.
+type academicYear struct {
+	terms [][2]int64
+}
+
+// NewAcademicCalendar creates academic calendar of the years. Years and
+// their terms must be listed in chronological order.
+//
+// NewAcademicCalendar returns error in the following cases:
+//
+//	academic year has no terms
+//	term ends before it starts
+//	term starts before the previous term ends
+func NewAcademicCalendar(years ...AcademicYear) (AcademicCalendar, error) {
+	var c AcademicCalendar
+	var prevEnd int64
+	var prevName string
+	for _, y := range years {
+		if len(y.Terms) == 0 {
+			return AcademicCalendar{}, fmt.Errorf("academic year %q has no terms", y.Name)
+		}
+		var ay academicYear
+		for _, term := range y.Terms {
+			start, end := newCivilDate(term.Start).days, newCivilDate(term.End).days+1
+			if end <= start {
+				return AcademicCalendar{}, fmt.Errorf("term %q ends before it starts", term.Name)
+			}
+			if len(c.years) > 0 || len(ay.terms) > 0 {
+				if start < prevEnd {
+					return AcademicCalendar{}, fmt.Errorf("term %q starts before term %q ends", term.Name, prevName)
+				}
+			}
+			ay.terms = append(ay.terms, [2]int64{start, end})
+			prevEnd, prevName = end, term.Name
+		}
+		c.years = append(c.years, ay)
+	}
+	return c, nil
+}
+
+// AcademicDiff describes enrollment duration in school years, terms, weeks
+// and days of school time. Full terms that are not a full school year are
+// counted as terms, and days of partially attended terms are counted as
+// weeks and days.
+type AcademicDiff struct {
+	Years int
+	Terms int
+	Weeks int
+	Days  int
+}
+
+// String returns enrollment duration in "2 school years, 1 term, 3 weeks"
+// format. Time units with 0 value are omitted, the duration of no school
+// time is "0 days".
+func (d AcademicDiff) String() string {
+	var parts []string
+	for _, u := range []struct {
+		n          int
+		one, other string
+	}{
+		{n: d.Years, one: "school year", other: "school years"},
+		{n: d.Terms, one: "term", other: "terms"},
+		{n: d.Weeks, one: "week", other: "weeks"},
+		{n: d.Days, one: "day", other: "days"},
+	} {
+		switch {
+		case u.n == 1:
+			parts = append(parts, "1 "+u.one)
+		case u.n != 0:
+			parts = append(parts, fmt.Sprintf("%d %s", u.n, u.other))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 days"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Diff calculates enrollment duration between dates. The end date is not
+// included, and it's converted to the location of the start date. The time
+// of the day is ignored.
+//
+// Diff returns error when start date is after end date.
+func (c AcademicCalendar) Diff(start, end time.Time) (AcademicDiff, error) {
+	if start.After(end) {
+		return AcademicDiff{}, ErrStartAfterEnd
+	}
+	from, to := newCivilDate(start).days, newCivilDate(end.In(start.Location())).days
+
+	var diff AcademicDiff
+	var days int64
+	for _, y := range c.years {
+		var full int
+		var partial int64
+		for _, term := range y.terms {
+			s, e := term[0], term[1]
+			if s < from {
+				s = from
+			}
+			if e > to {
+				e = to
+			}
+			switch {
+			case s >= e:
+			case s == term[0] && e == term[1]:
+				full++
+			default:
+				partial += e - s
+			}
+		}
+		if full == len(y.terms) {
+			diff.Years++
+			continue
+		}
+		diff.Terms += full
+		days += partial
+	}
+	diff.Weeks, diff.Days = int(days/daysInWeek), int(days%daysInWeek)
+	return diff, nil
+}
@@ -0,0 +1,92 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestAcademicCalendarDiff(t *testing.T) {
+	c, err := datediff.NewAcademicCalendar(
+		datediff.AcademicYear{Name: "2023/24", Terms: []datediff.AcademicTerm{
+			{Name: "autumn", Start: date(2023, time.September, 4), End: date(2023, time.December, 15)},
+			{Name: "spring", Start: date(2024, time.January, 8), End: date(2024, time.March, 28)},
+			{Name: "summer", Start: date(2024, time.April, 15), End: date(2024, time.July, 19)},
+		}},
+		datediff.AcademicYear{Name: "2024/25", Terms: []datediff.AcademicTerm{
+			{Name: "autumn", Start: date(2024, time.September, 2), End: date(2024, time.December, 20)},
+			{Name: "spring", Start: date(2025, time.January, 6), End: date(2025, time.April, 4)},
+			{Name: "summer", Start: date(2025, time.April, 22), End: date(2025, time.July, 18)},
+		}},
+	)
+	if err != nil {
+		t.Fatalf("NewAcademicCalendar() failed: %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		expected string
+	}{
+		{desc: "full years", start: date(2023, time.September, 4), end: date(2025, time.July, 19), expected: "2 school years"},
+		{desc: "partial term", start: date(2023, time.September, 4), end: date(2025, time.January, 27), expected: "1 school year, 1 term, 3 weeks"},
+		{desc: "days", start: date(2023, time.October, 2), end: date(2023, time.October, 12), expected: "1 week, 3 days"},
+		{desc: "break", start: date(2023, time.December, 20), end: date(2024, time.January, 5), expected: "0 days"},
+		{desc: "full terms", start: date(2023, time.December, 1), end: date(2024, time.July, 20), expected: "2 terms, 2 weeks, 1 day"},
+	}
+	for _, tC := range testCases {
+		got, err := c.Diff(tC.start, tC.end)
+		if err != nil {
+			t.Errorf("Diff() %s failed: %v", tC.desc, err)
+		} else if got.String() != tC.expected {
+			t.Errorf("Diff() %s = %q, want %q", tC.desc, got, tC.expected)
+		}
+	}
+
+	if _, err := c.Diff(date(2024, time.January, 2), date(2024, time.January, 1)); err != datediff.ErrStartAfterEnd {
+		t.Errorf("Diff() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+}
+
+func TestNewAcademicCalendarFails(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		years    []datediff.AcademicYear
+		expected string
+	}{
+		{
+			desc:     "year without terms",
+			years:    []datediff.AcademicYear{{Name: "2023/24"}},
+			expected: `academic year "2023/24" has no terms`,
+		},
+		{
+			desc: "term ends before it starts",
+			years: []datediff.AcademicYear{{Name: "2023/24", Terms: []datediff.AcademicTerm{
+				{Name: "autumn", Start: date(2023, time.December, 15), End: date(2023, time.September, 4)},
+			}}},
+			expected: `term "autumn" ends before it starts`,
+		},
+		{
+			desc: "overlapping terms",
+			years: []datediff.AcademicYear{
+				{Name: "2023/24", Terms: []datediff.AcademicTerm{
+					{Name: "autumn", Start: date(2023, time.September, 4), End: date(2023, time.December, 15)},
+				}},
+				{Name: "2024/25", Terms: []datediff.AcademicTerm{
+					{Name: "autumn 2024", Start: date(2023, time.December, 15), End: date(2024, time.December, 20)},
+				}},
+			},
+			expected: `term "autumn 2024" starts before term "autumn" ends`,
+		},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.NewAcademicCalendar(tC.years...)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("NewAcademicCalendar() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package datediff
+
+import "time"
+
+// ageFormat is the format of age.
+const ageFormat = "%Y %M %D"
+
+// Age returns age of the person born on the birth date as of today, i.e
+// "37 years 2 months 5 days". See AgeAt for details.
+func Age(birthDate time.Time, opts ...Option) (Diff, error) {
+	return AgeAt(birthDate, time.Now(), opts...)
+}
+
+// AgeAt returns age of the person born on the birth date as of the date. Age
+// is calculated in years, months and days with "%Y %M %D" format. The date is
+// converted to the location of the birth date, and both dates are truncated
+// to the start of the day, so times of the day do not affect the age.
+// Persons born on February 29 become older on March 1 in common years, use
+// WithLeapDayPolicy(LeapDayFeb28) to change it.
+func AgeAt(birthDate, at time.Time, opts ...Option) (Diff, error) {
+	start, end := daysOf(birthDate, at)
+	return NewDiff(start, end, ageFormat, opts...)
+}
+
+// daysOf returns starts of the days of the dates in the location of the
+// first date, so anniversaries do not depend on times of the day.
+func daysOf(first, second time.Time) (time.Time, time.Time) {
+	loc := first.Location()
+	return startOfDayIn(first, loc), startOfDayIn(second, loc)
+}
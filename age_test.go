@@ -0,0 +1,74 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestAgeAt(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	feb28 := []datediff.Option{datediff.WithLeapDayPolicy(datediff.LeapDayFeb28)}
+
+	testCases := []struct {
+		birthDate time.Time
+		at        time.Time
+		opts      []datediff.Option
+		expected  [3]int // years, months, days
+	}{
+		{birthDate: date(1980, time.April, 17), at: date(2017, time.June, 22), expected: [3]int{37, 2, 5}},
+		{birthDate: date(1980, time.April, 17), at: time.Date(2017, time.April, 17, 23, 59, 0, 0, time.UTC), expected: [3]int{37, 0, 0}},
+		{birthDate: date(1980, time.April, 17), at: date(1980, time.April, 17), expected: [3]int{}},
+		{birthDate: time.Date(2000, time.May, 10, 14, 30, 0, 0, time.UTC), at: time.Date(2010, time.May, 10, 9, 0, 0, 0, time.UTC), expected: [3]int{10, 0, 0}},
+		{birthDate: time.Date(2000, time.May, 10, 14, 30, 0, 0, time.UTC), at: time.Date(2000, time.May, 10, 9, 0, 0, 0, time.UTC), expected: [3]int{}},
+		{birthDate: time.Date(2000, time.May, 10, 14, 30, 0, 0, time.UTC), at: date(2000, time.June, 9), expected: [3]int{0, 0, 30}},
+		{birthDate: date(2000, time.February, 29), at: date(2001, time.February, 28), expected: [3]int{0, 11, 30}},
+		{birthDate: date(2000, time.February, 29), at: date(2001, time.March, 1), expected: [3]int{1, 0, 0}},
+		{birthDate: date(2000, time.February, 29), at: date(2001, time.February, 28), opts: feb28, expected: [3]int{1, 0, 0}},
+		{birthDate: date(2000, time.February, 29), at: date(2001, time.March, 1), opts: feb28, expected: [3]int{1, 0, 0}},
+		{birthDate: date(2000, time.February, 29), at: date(2004, time.February, 28), opts: feb28, expected: [3]int{3, 11, 27}},
+		{birthDate: date(2000, time.February, 29), at: date(2004, time.February, 29), opts: feb28, expected: [3]int{4, 0, 0}},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.AgeAt(tC.birthDate, tC.at, tC.opts...)
+		if err != nil {
+			t.Errorf("AgeAt(%s, %s) failed: %v", tC.birthDate.Format(dateFmt), tC.at.Format(dateFmt), err)
+		} else if actual := [3]int{got.Years, got.Months, got.Days}; actual != tC.expected {
+			t.Errorf("AgeAt(%s, %s) = %v, want %v", tC.birthDate.Format(dateFmt), tC.at.Format(dateFmt), actual, tC.expected)
+		}
+	}
+}
+
+func TestAgeAtLocation(t *testing.T) {
+	east := time.FixedZone("UTC+10", 10*60*60)
+	birthDate := time.Date(1980, time.April, 17, 0, 0, 0, 0, east)
+	// it's already April 17 in the location of the birth date
+	at := time.Date(2017, time.April, 16, 20, 0, 0, 0, time.UTC)
+
+	got, err := datediff.AgeAt(birthDate, at)
+	if err != nil {
+		t.Fatalf("AgeAt() failed: %v", err)
+	}
+	if expected := "37 years"; got.String() != expected {
+		t.Errorf("AgeAt() = %s, want %s", got.String(), expected)
+	}
+}
+
+func TestAge(t *testing.T) {
+	now := time.Now()
+	birthDate := time.Date(now.Year()-30, now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	got, err := datediff.Age(birthDate)
+	if err != nil {
+		t.Fatalf("Age() failed: %v", err)
+	}
+	if got.Years != 30 {
+		t.Errorf("Age() = %#v, want 30 years", got)
+	}
+
+	if _, err := datediff.Age(time.Now().AddDate(1, 0, 0)); err == nil {
+		t.Errorf("Age() want to fail due to start date is after end date")
+	}
+}
@@ -0,0 +1,61 @@
+package datediff
+
+import "time"
+
+// NextAnniversary returns the first anniversary of the start date that is
+// after the date and its number, i.e the 5th work anniversary. Anniversaries
+// are calculated with the same calendar rules as dates difference in years,
+// anniversaries of February 29 happen on March 1 in common years unless
+// WithLeapDayPolicy(LeapDayFeb28) is provided. Other options are ignored.
+func NextAnniversary(start, after time.Time, opts ...Option) (time.Time, int) {
+	o := newOptions(opts)
+	n := after.Year() - start.Year() - 1
+	if n < 1 {
+		n = 1
+	}
+	for !o.anniversary(start, n).After(after) {
+		n++
+	}
+	return o.anniversary(start, n), n
+}
+
+// PreviousAnniversary returns the last anniversary of the start date that is
+// on or before the date and its number. It returns zero time and 0 when there
+// were no anniversaries yet. Options are the same as for NextAnniversary.
+func PreviousAnniversary(start, before time.Time, opts ...Option) (time.Time, int) {
+	_, n := NextAnniversary(start, before, opts...)
+	if n--; n == 0 {
+		return time.Time{}, 0
+	}
+	return newOptions(opts).anniversary(start, n), n
+}
+
+// UntilAnniversary returns dates difference from the date to the next
+// anniversary of the start date according to the provided format, and the
+// number of the anniversary. It allows to show "37 days until the 5th work
+// anniversary":
+//
+//	diff, n, _ := UntilAnniversary(hired, time.Now(), "%D")
+//	fmt.Printf("%s until the work anniversary #%d", diff, n)
+//
+// Options are applied to both the anniversary and dates difference
+// calculation.
+func UntilAnniversary(start, from time.Time, rawFormat string, opts ...Option) (Diff, int, error) {
+	next, n := NextAnniversary(start, from, opts...)
+	diff, err := NewDiff(from, next, rawFormat, opts...)
+	if err != nil {
+		return Diff{}, 0, err
+	}
+	return diff, n, nil
+}
+
+// anniversary returns the n-th anniversary of the start date according to
+// the leap day policy.
+func (o options) anniversary(start time.Time, n int) time.Time {
+	t := start.AddDate(n, 0, 0)
+	if o.leapDay == LeapDayFeb28 && start.Month() == time.February && start.Day() == 29 &&
+		t.Month() == time.March && t.Day() == 1 {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
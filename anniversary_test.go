@@ -0,0 +1,83 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestNextAnniversary(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	feb28 := []datediff.Option{datediff.WithLeapDayPolicy(datediff.LeapDayFeb28)}
+
+	testCases := []struct {
+		start    time.Time
+		after    time.Time
+		opts     []datediff.Option
+		next     time.Time
+		nextN    int
+		previous time.Time
+		prevN    int
+	}{
+		{
+			start: date(2018, time.April, 17), after: date(2022, time.March, 11),
+			next: date(2022, time.April, 17), nextN: 4, previous: date(2021, time.April, 17), prevN: 3,
+		},
+		{
+			start: date(2018, time.April, 17), after: date(2022, time.April, 17),
+			next: date(2023, time.April, 17), nextN: 5, previous: date(2022, time.April, 17), prevN: 4,
+		},
+		{
+			start: date(2018, time.April, 17), after: date(2018, time.May, 1),
+			next: date(2019, time.April, 17), nextN: 1,
+		},
+		{
+			start: date(2018, time.April, 17), after: date(2017, time.May, 1),
+			next: date(2019, time.April, 17), nextN: 1,
+		},
+		{
+			start: date(2000, time.February, 29), after: date(2001, time.February, 28),
+			next: date(2001, time.March, 1), nextN: 1,
+		},
+		{
+			start: date(2000, time.February, 29), after: date(2001, time.February, 27), opts: feb28,
+			next: date(2001, time.February, 28), nextN: 1,
+		},
+		{
+			start: date(2000, time.February, 29), after: date(2003, time.March, 1), opts: feb28,
+			next: date(2004, time.February, 29), nextN: 4, previous: date(2003, time.February, 28), prevN: 3,
+		},
+	}
+	for _, tC := range testCases {
+		next, n := datediff.NextAnniversary(tC.start, tC.after, tC.opts...)
+		if !next.Equal(tC.next) || n != tC.nextN {
+			t.Errorf("NextAnniversary(%s, %s) = %s, %d, want %s, %d", tC.start.Format(dateFmt), tC.after.Format(dateFmt),
+				next.Format(dateFmt), n, tC.next.Format(dateFmt), tC.nextN)
+		}
+		previous, n := datediff.PreviousAnniversary(tC.start, tC.after, tC.opts...)
+		if !previous.Equal(tC.previous) || n != tC.prevN {
+			t.Errorf("PreviousAnniversary(%s, %s) = %s, %d, want %s, %d", tC.start.Format(dateFmt), tC.after.Format(dateFmt),
+				previous.Format(dateFmt), n, tC.previous.Format(dateFmt), tC.prevN)
+		}
+	}
+}
+
+func TestUntilAnniversary(t *testing.T) {
+	start := time.Date(2018, time.April, 17, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2023, time.March, 11, 0, 0, 0, 0, time.UTC)
+
+	diff, n, err := datediff.UntilAnniversary(start, from, "%D")
+	if err != nil {
+		t.Fatalf("UntilAnniversary() failed: %v", err)
+	}
+	if got, want := diff.String(), "37 days"; got != want || n != 5 {
+		t.Errorf("UntilAnniversary() = %s, %d, want %s, 5", got, n, want)
+	}
+
+	if _, _, err := datediff.UntilAnniversary(start, from, "%Z"); err == nil {
+		t.Errorf("UntilAnniversary() want to fail due to invalid format")
+	}
+}
@@ -0,0 +1,175 @@
+package datediff
+
+import "time"
+
+// AddTo returns the date advanced by the dates difference. Time units are
+// added one by one starting from years, as they are calculated by NewDiff.
+// Days overflowing the month are normalized as time.AddDate does, not clamped
+// to the end of the month: January 31 plus 1 month is March 2 or 3.
+//
+// Adding dates difference calculated from start date to the start date
+// returns the end date only when days are in the mode, or the day after it
+// for the inclusive end date. Otherwise the remaining days are dropped, i.e
+// January 31 - March 15 in months is 1 month, and AddTo returns March 2 or 3.
+func (d Diff) AddTo(t time.Time) time.Time {
+	return addDiff(t, d, 1)
+}
+
+// SubtractFrom returns the date rolled back by the dates difference. Time
+// units are subtracted one by one starting from years. Since month overflow
+// is normalized, subtraction is not always inverse of addition, i.e
+// January 31 plus 1 month is March 2 (in a leap year), and March 2 minus
+// 1 month is February 2. Subtracting dates difference calculated from start
+// date to end date from the end date can return a date after the start date.
+func (d Diff) SubtractFrom(t time.Time) time.Time {
+	return addDiff(t, d, -1)
+}
+
+// Add returns the sum of dates differences. Time units values are summed
+// without normalization, use Normalize to convert overflowed units. The mode
+// of the sum combines modes of both dates differences, the format is kept
+// when it matches the combined mode. The sum is not calculated from dates, so
+// its start and end dates are unknown.
+func (d Diff) Add(other Diff) Diff {
+	sum := Diff{
+		Years:  d.Years + other.Years,
+		Months: d.Months + other.Months,
+		Weeks:  d.Weeks + other.Weeks,
+		Days:   d.Days + other.Days,
+		mode:   d.mode | other.mode,
+	}
+	sum.rawFormat = d.formatOf(sum.mode)
+	return sum
+}
+
+// Sub returns the difference of dates differences, i.e remaining time of the
+// target dates difference after the elapsed one. Time units values are
+// subtracted without normalization, so the result can have negative values.
+// Mode and format of the result are defined as Add does.
+func (d Diff) Sub(other Diff) Diff {
+	return d.Add(other.negated())
+}
+
+// Mul returns dates difference multiplied by n, i.e 3 times the probation
+// period. Overflowed time units are normalized as Normalize does. Mode and
+// format of the dates difference are kept, start and end dates are unknown.
+func (d Diff) Mul(n int) Diff {
+	return Diff{
+		Years:     d.Years * n,
+		Months:    d.Months * n,
+		Weeks:     d.Weeks * n,
+		Days:      d.Days * n,
+		rawFormat: d.rawFormat,
+		mode:      d.mode,
+	}.Normalize()
+}
+
+// Normalize converts overflowed time units according to the mode of dates
+// difference. Months overflow is converted to years, and days overflow is
+// converted to weeks, when the mode has these units. Years are converted to
+// months and weeks are converted to days, when the mode has the shorter unit
+// only. Days are never converted to months, since months have different
+// lengths. Dates difference with zero mode is normalized as one that has all
+// time units.
+func (d Diff) Normalize() Diff {
+	mode := d.mode
+	if mode == 0 {
+		mode = ModeAll
+	}
+
+	n := d
+	switch {
+	case mode&ModeYears != 0 && mode&ModeMonths != 0:
+		n.Years += n.Months / monthsInYear
+		n.Months %= monthsInYear
+	case mode&ModeMonths != 0:
+		n.Months += n.Years * monthsInYear
+		n.Years = 0
+	}
+	switch {
+	case mode&ModeWeeks != 0 && mode&ModeDays != 0:
+		n.Weeks += n.Days / daysInWeek
+		n.Days %= daysInWeek
+	case mode&ModeDays != 0:
+		n.Days += n.Weeks * daysInWeek
+		n.Weeks = 0
+	}
+	return n
+}
+
+// negated returns dates difference with negated time units values.
+func (d Diff) negated() Diff {
+	d.Years, d.Months, d.Weeks, d.Days = -d.Years, -d.Months, -d.Weeks, -d.Days
+	return d
+}
+
+// formatOf returns the format of dates difference when it matches the mode.
+func (d Diff) formatOf(mode DiffMode) string {
+	if d.rawFormat == "" {
+		return ""
+	}
+	if m, err := unmarshal(d.rawFormat); err != nil || m != mode {
+		return ""
+	}
+	return d.rawFormat
+}
+
+// Compare compares dates differences. It returns -1 when the dates
+// difference is shorter than the other, 1 when it's longer, and 0 when they
+// are equal. When both dates differences were calculated from dates, their
+// exact durations are compared. Otherwise time units values are compared one
+// by one starting from years, i.e 1 year is longer than 13 months.
+func (d Diff) Compare(other Diff) int {
+	if d.hasDates() && other.hasDates() {
+		a, b := d.rangeEnd().Sub(d.start), other.rangeEnd().Sub(other.start)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	for _, u := range diffUnits {
+		a, b := d.value(u), other.value(u)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Less returns true when the dates difference is shorter than the other, as
+// defined by Compare. It can be used to sort dates differences:
+//
+//	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Less(diffs[j]) })
+func (d Diff) Less(other Diff) bool {
+	return d.Compare(other) < 0
+}
+
+// hasDates returns true when dates difference was calculated from dates.
+func (d Diff) hasDates() bool {
+	return !d.start.IsZero() || !d.end.IsZero()
+}
+
+// addDiff adds dates difference multiplied by the sign to the date. Time
+// units are added one by one starting from the longest one, as they are
+// calculated by newDiff.
+func addDiff(t time.Time, d Diff, sign int) time.Time {
+	t = t.AddDate(sign*d.Years, 0, 0)
+	t = t.AddDate(0, sign*d.Months, 0)
+	t = t.AddDate(0, 0, sign*d.Weeks*daysInWeek)
+	return t.AddDate(0, 0, sign*d.Days)
+}
+
+// addCalendarDiff adds dates difference multiplied by the sign to the date
+// using the calendar arithmetic.
+func addCalendarDiff(c Calendar, t time.Time, d Diff, sign int) time.Time {
+	t = c.AddYears(t, sign*d.Years)
+	t = c.AddMonths(t, sign*d.Months)
+	t = c.AddDays(t, sign*d.Weeks*daysInWeek)
+	return c.AddDays(t, sign*d.Days)
+}
@@ -0,0 +1,279 @@
+package datediff_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestAddTo(t *testing.T) {
+	testCases, err := loadDatediffRecordsForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tC := range testCases {
+		// days are required to reach the end date exactly
+		mode := tC.mode | datediff.ModeDays
+		desc := fmt.Sprintf("NewDiffWithMode(%s, %s, %d)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), mode)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiffWithMode(tC.start, tC.end, mode)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			if got := diff.AddTo(tC.start); !got.Equal(tC.end) {
+				t.Errorf("AddTo(%s) = %s, want %s", tC.start.Format(dateFmt), got.Format(dateFmt), tC.end.Format(dateFmt))
+			}
+		})
+	}
+}
+
+func TestAddToMonthOverflow(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		diff     datediff.Diff
+		expected time.Time
+	}{
+		{
+			date:     time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC),
+			diff:     datediff.Diff{Months: 1},
+			expected: time.Date(2000, time.March, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC),
+			diff:     datediff.Diff{Years: 1},
+			expected: time.Date(2001, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2000, time.April, 17, 10, 30, 0, 0, time.UTC),
+			diff:     datediff.Diff{Years: 1, Months: 2, Weeks: 1, Days: 3},
+			expected: time.Date(2001, time.June, 27, 10, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.AddTo(tC.date); !got.Equal(tC.expected) {
+			t.Errorf("AddTo(%s) = %s, want %s", tC.date, got, tC.expected)
+		}
+	}
+}
+
+func TestAddToWithoutDays(t *testing.T) {
+	start := time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		end      time.Time
+		expected time.Time
+	}{
+		{
+			end:      time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC),
+			expected: start,
+		},
+		{
+			// 1 month overflows February and is not clamped to February 29
+			end:      time.Date(2000, time.March, 15, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2000, time.March, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tC := range testCases {
+		diff, err := datediff.NewDiffWithMode(start, tC.end, datediff.ModeMonths)
+		if err != nil {
+			t.Fatalf("NewDiffWithMode(%s, %s) failed: %v", start.Format(dateFmt), tC.end.Format(dateFmt), err)
+		}
+		if got := diff.AddTo(start); !got.Equal(tC.expected) {
+			t.Errorf("AddTo(%s) of %s = %s, want %s", start.Format(dateFmt), diff, got.Format(dateFmt), tC.expected.Format(dateFmt))
+		}
+	}
+}
+
+func TestSubtractFrom(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		diff     datediff.Diff
+		expected time.Time
+	}{
+		{
+			date:     time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC),
+			diff:     datediff.Diff{Years: 3, Months: 1, Days: 1},
+			expected: time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2000, time.March, 2, 0, 0, 0, 0, time.UTC),
+			diff:     datediff.Diff{Months: 1},
+			expected: time.Date(2000, time.February, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2001, time.March, 31, 0, 0, 0, 0, time.UTC),
+			diff:     datediff.Diff{Months: 1},
+			expected: time.Date(2001, time.March, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2000, time.January, 10, 0, 0, 0, 0, time.UTC),
+			diff:     datediff.Diff{Weeks: 1, Days: 3},
+			expected: time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.SubtractFrom(tC.date); !got.Equal(tC.expected) {
+			t.Errorf("SubtractFrom(%s) = %s, want %s", tC.date.Format(dateFmt), got.Format(dateFmt), tC.expected.Format(dateFmt))
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb, err := datediff.NewDiffWithMode(start, start.AddDate(0, 0, 59), datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	days, err := datediff.NewDiffWithMode(start, start.AddDate(0, 0, 60), datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+
+	testCases := []struct {
+		a, b     datediff.Diff
+		expected int
+	}{
+		{a: datediff.Diff{Years: 1}, b: datediff.Diff{Months: 13}, expected: 1},
+		{a: datediff.Diff{Months: 2, Days: 1}, b: datediff.Diff{Months: 2, Days: 3}, expected: -1},
+		{a: datediff.Diff{Weeks: 1}, b: datediff.Diff{Weeks: 1}, expected: 0},
+		// 1 month 28 days is compared with 60 days by duration
+		{a: feb, b: days, expected: -1},
+		{a: days, b: feb, expected: 1},
+		{a: days, b: days, expected: 0},
+		// dates difference without dates is compared by time units
+		{a: feb, b: datediff.Diff{Months: 2}, expected: -1},
+	}
+	for _, tC := range testCases {
+		if got := tC.a.Compare(tC.b); got != tC.expected {
+			t.Errorf("Compare(%#v, %#v) = %d, want %d", tC.a, tC.b, got, tC.expected)
+		}
+		if got, expected := tC.a.Less(tC.b), tC.expected < 0; got != expected {
+			t.Errorf("Less(%#v, %#v) = %t, want %t", tC.a, tC.b, got, expected)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	first := mustNewDiff(t, start, time.Date(2003, time.November, 18, 0, 0, 0, 0, time.UTC), "%Y and %M")
+	second := mustNewDiff(t, start, time.Date(2001, time.September, 1, 0, 0, 0, 0, time.UTC), "%Y and %M")
+
+	got := first.Add(second)
+	expected := datediff.Diff{Years: 4, Months: 11}
+	if !got.Equal(expected) {
+		t.Errorf("Add() = %#v, want %#v", got, expected)
+	}
+	if expectedStr := "4 years and 11 months"; got.String() != expectedStr {
+		t.Errorf("Add() String() = %s, want %s", got.String(), expectedStr)
+	}
+	if !got.Start().IsZero() || !got.End().IsZero() {
+		t.Errorf("Add() Start(), End() = %s, %s, want zero time", got.Start(), got.End())
+	}
+
+	got = first.Add(second).Add(second).Normalize()
+	expected = datediff.Diff{Years: 6, Months: 3}
+	if !got.Equal(expected) {
+		t.Errorf("Add() Normalize() = %#v, want %#v", got, expected)
+	}
+
+	days, err := datediff.NewDiffWithMode(start, start.AddDate(0, 0, 10), datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	got = first.Add(days)
+	if got.Mode() != datediff.ModeYears|datediff.ModeMonths|datediff.ModeDays {
+		t.Errorf("Add() Mode() = %s, want years|months|days", got.Mode())
+	}
+	if got.RawFormat() != "" {
+		t.Errorf("Add() RawFormat() = %s, want empty format", got.RawFormat())
+	}
+	if expectedStr := "3 years 7 months 10 days"; got.String() != expectedStr {
+		t.Errorf("Add() String() = %s, want %s", got.String(), expectedStr)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	// withMode returns dates difference with time units values of d and
+	// the mode
+	withMode := func(mode datediff.DiffMode, d datediff.Diff) datediff.Diff {
+		start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+		zero, err := datediff.NewDiffWithMode(start, start, mode)
+		if err != nil {
+			t.Fatalf("NewDiffWithMode() failed: %v", err)
+		}
+		return zero.Add(d)
+	}
+
+	testCases := []struct {
+		diff     datediff.Diff
+		expected datediff.Diff
+	}{
+		{diff: datediff.Diff{Years: 1, Months: 14, Weeks: 1, Days: 9}, expected: datediff.Diff{Years: 2, Months: 2, Weeks: 2, Days: 2}},
+		{diff: datediff.Diff{Months: -13}, expected: datediff.Diff{Years: -1, Months: -1}},
+		{
+			diff:     withMode(datediff.ModeMonths|datediff.ModeDays, datediff.Diff{Years: 1, Months: 2, Weeks: 1, Days: 3}),
+			expected: datediff.Diff{Months: 14, Days: 10},
+		},
+		{
+			diff:     withMode(datediff.ModeYears|datediff.ModeWeeks, datediff.Diff{Months: 25, Days: 20}),
+			expected: datediff.Diff{Months: 25, Days: 20},
+		},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.Normalize(); !got.Equal(tC.expected) {
+			t.Errorf("Normalize(%#v) = %#v, want %#v", tC.diff, got, tC.expected)
+		}
+	}
+}
+
+func TestSub(t *testing.T) {
+	probation := datediff.Diff{Months: 6}
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	elapsed, err := datediff.NewDiffWithMode(start, time.Date(2000, time.June, 20, 0, 0, 0, 0, time.UTC), datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+
+	got := probation.Sub(elapsed)
+	expected := datediff.Diff{Months: 4, Days: -3}
+	if !got.Equal(expected) {
+		t.Errorf("Sub() = %#v, want %#v", got, expected)
+	}
+	if got.Mode() != datediff.ModeMonths|datediff.ModeDays {
+		t.Errorf("Sub() Mode() = %s, want months|days", got.Mode())
+	}
+
+	got = elapsed.Sub(probation)
+	expected = datediff.Diff{Months: -4, Days: 3}
+	if !got.Equal(expected) {
+		t.Errorf("Sub() = %#v, want %#v", got, expected)
+	}
+}
+
+func TestMul(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	probation := mustNewDiff(t, start, time.Date(2000, time.August, 27, 0, 0, 0, 0, time.UTC), "%M and %W")
+
+	testCases := []struct {
+		n        int
+		expected string
+	}{
+		{n: 1, expected: "4 months and 1 week"},
+		{n: 3, expected: "12 months and 3 weeks"},
+		{n: 7, expected: "28 months and 7 weeks"},
+	}
+	for _, tC := range testCases {
+		got := probation.Mul(tC.n)
+		if got.String() != tC.expected {
+			t.Errorf("Mul(%d) String() = %s, want %s", tC.n, got.String(), tC.expected)
+		}
+	}
+
+	got := datediff.Diff{Months: 5, Days: 4}.Mul(-3)
+	expected := datediff.Diff{Years: -1, Months: -3, Weeks: -1, Days: -5}
+	if !got.Equal(expected) {
+		t.Errorf("Mul(-3) = %#v, want %#v", got, expected)
+	}
+}
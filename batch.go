@@ -0,0 +1,60 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+// DiffsFrom calculates dates differences between the start date and each of
+// the end dates according to the provided mode, i.e ages of a cohort as of
+// the report date. It's equivalent to calling NewDiffWithMode for every end
+// date, but the mode is validated and the result is allocated once.
+//
+// DiffsFrom returns error in the following cases:
+//
+//	undefined dates difference mode
+//	start date is after any of the end dates
+func DiffsFrom(start time.Time, ends []time.Time, mode DiffMode) ([]Diff, error) {
+	dates := func(i int) (time.Time, time.Time) { return start, ends[i] }
+	diffs, err := appendDiffs(make([]Diff, 0, len(ends)), len(ends), dates, mode, "end date")
+	if err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+// DiffBatch calculates dates differences of start and end dates pairs
+// according to the provided mode, i.e ages of records in ETL pipelines.
+// Dates differences are appended to dst, that can be reused between batches
+// to avoid allocations. The result is the same as of NewDiffWithMode for
+// every pair, but the mode is validated once.
+//
+// DiffBatch returns error in the following cases:
+//
+//	numbers of start and end dates are different
+//	undefined dates difference mode
+//	start date is after end date of any pair
+func DiffBatch(starts, ends []time.Time, mode DiffMode, dst []Diff) ([]Diff, error) {
+	if len(starts) != len(ends) {
+		return dst, fmt.Errorf("batch has %d start dates and %d end dates", len(starts), len(ends))
+	}
+	dates := func(i int) (time.Time, time.Time) { return starts[i], ends[i] }
+	return appendDiffs(dst, len(starts), dates, mode, "dates pair")
+}
+
+// appendDiffs validates the mode once and appends to dst dates differences
+// of n dates pairs. The failed pair is referred in the error by the item name
+// and index.
+func appendDiffs(dst []Diff, n int, dates func(i int) (time.Time, time.Time), mode DiffMode, item string) ([]Diff, error) {
+	if mode == 0 || !mode.valid() {
+		return dst, ErrUndefinedMode
+	}
+	for i := 0; i < n; i++ {
+		start, end := dates(i)
+		if start.After(end) {
+			return dst, fmt.Errorf("%s #%d: %w", item, i, ErrStartAfterEnd)
+		}
+		dst = append(dst, newDiff(start, end, mode))
+	}
+	return dst, nil
+}
@@ -0,0 +1,192 @@
+package datediff_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestDiffsFrom(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	ends := []time.Time{
+		start,
+		time.Date(2003, time.March, 16, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+	}
+	mode := datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays
+
+	diffs, err := datediff.DiffsFrom(start, ends, mode)
+	if err != nil {
+		t.Fatalf("DiffsFrom() failed: %v", err)
+	}
+	if len(diffs) != len(ends) {
+		t.Fatalf("DiffsFrom() returned %d diffs, want %d", len(diffs), len(ends))
+	}
+	for i, end := range ends {
+		want, _ := datediff.NewDiffWithMode(start, end, mode)
+		if !diffs[i].Equal(want) || diffs[i].Mode() != mode {
+			t.Errorf("DiffsFrom()[%d] = %v, want %v", i, diffs[i], want)
+		}
+	}
+}
+
+func TestDiffsFromFails(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		ends     []time.Time
+		mode     datediff.DiffMode
+		expected string
+	}{
+		{
+			desc:     "undefined mode",
+			ends:     []time.Time{start},
+			expected: "undefined dates difference mode",
+		},
+		{
+			desc:     "invalid mode",
+			ends:     []time.Time{start},
+			mode:     1,
+			expected: "undefined dates difference mode",
+		},
+		{
+			desc:     "start date is after end date",
+			ends:     []time.Time{start, start.AddDate(0, 0, -1)},
+			mode:     datediff.ModeDays,
+			expected: "end date #1: start date is after end date",
+		},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.DiffsFrom(start, tC.ends, tC.mode)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("DiffsFrom() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
+
+func BenchmarkDiffsFrom(b *testing.B) {
+	start := time.Date(1950, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ends := make([]time.Time, 100)
+	for i := range ends {
+		ends[i] = start.AddDate(i%70, i%12, i%28)
+	}
+	mode := datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := datediff.DiffsFrom(start, ends, mode); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDiffBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	locs := []*time.Location{time.UTC, time.FixedZone("UTC+5:45", (5*60+45)*60)}
+
+	var starts, ends []time.Time
+	for i := 0; i < 2000; i++ {
+		loc := locs[i%len(locs)]
+		// days at the end of months are the edge cases of months overflow
+		start := time.Date(1900+r.Intn(200), time.Month(1+r.Intn(12)), 25+r.Intn(7), r.Intn(24), r.Intn(60), 0, 0, loc)
+		end := start.Add(time.Duration(r.Int63n(int64(40 * 365 * datediff.Day)))).In(locs[r.Intn(len(locs))])
+		starts = append(starts, start)
+		ends = append(ends, end)
+	}
+	// end date on the same day as start date, before and after its time of the day
+	start := time.Date(2020, time.January, 31, 12, 0, 0, 0, time.UTC)
+	starts = append(starts, start, start, start)
+	ends = append(ends, start.AddDate(0, 1, 0).Add(-time.Hour), start.AddDate(1, 0, 0), start.AddDate(0, 0, 1).Add(-time.Minute))
+
+	for m := 1; m < 16; m++ {
+		mode := datediff.DiffMode(m << 4)
+		got, err := datediff.DiffBatch(starts, ends, mode, nil)
+		if err != nil {
+			t.Fatalf("DiffBatch(%s) failed: %v", mode, err)
+		}
+		if len(got) != len(starts) {
+			t.Fatalf("DiffBatch(%s) returned %d diffs, want %d", mode, len(got), len(starts))
+		}
+		for i := range starts {
+			want, _ := datediff.NewDiffWithMode(starts[i], ends[i], mode)
+			if !got[i].Equal(want) || got[i].Mode() != mode {
+				t.Errorf("DiffBatch(%s)[%d] of %s - %s = %v, want %v", mode, i, starts[i], ends[i], got[i], want)
+			}
+		}
+	}
+}
+
+func TestDiffBatchFails(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		desc     string
+		starts   []time.Time
+		ends     []time.Time
+		mode     datediff.DiffMode
+		expected string
+	}{
+		{
+			desc:     "different number of dates",
+			starts:   []time.Time{now, now},
+			ends:     []time.Time{now},
+			mode:     datediff.ModeDays,
+			expected: "batch has 2 start dates and 1 end dates",
+		},
+		{
+			desc:     "undefined mode",
+			starts:   []time.Time{now},
+			ends:     []time.Time{now},
+			expected: "undefined dates difference mode",
+		},
+		{
+			desc:     "start date is after end date",
+			starts:   []time.Time{now, now.Add(time.Hour)},
+			ends:     []time.Time{now, now},
+			mode:     datediff.ModeDays,
+			expected: "dates pair #1: start date is after end date",
+		},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.DiffBatch(tC.starts, tC.ends, tC.mode, nil)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("DiffBatch() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
+
+func BenchmarkDiffBatch(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	starts := make([]time.Time, 10000)
+	ends := make([]time.Time, len(starts))
+	for i := range starts {
+		starts[i] = time.Date(1950+r.Intn(50), time.Month(1+r.Intn(12)), 1+r.Intn(28), 0, 0, 0, 0, time.UTC)
+		ends[i] = starts[i].AddDate(r.Intn(50), r.Intn(12), r.Intn(31))
+	}
+	mode := datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays
+	dst := make([]datediff.Diff, 0, len(starts))
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dst, _ = datediff.DiffBatch(starts, ends, mode, dst[:0])
+		}
+		b.ReportMetric(float64(b.N*len(starts))/b.Elapsed().Seconds(), "pairs/s")
+	})
+	b.Run("single", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dst = dst[:0]
+			for j := range starts {
+				d, _ := datediff.NewDiffWithMode(starts[j], ends[j], mode)
+				dst = append(dst, d)
+			}
+		}
+		b.ReportMetric(float64(b.N*len(starts))/b.Elapsed().Seconds(), "pairs/s")
+	})
+}
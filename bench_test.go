@@ -0,0 +1,149 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// benchSpans are lengths of dates ranges used by benchmarks.
+var benchSpans = []struct {
+	name   string
+	years  int
+	months int
+	days   int
+}{
+	{name: "week", days: 7},
+	{name: "year", years: 1, months: 2, days: 3},
+	{name: "decade", years: 10, months: 2, days: 3},
+	{name: "century", years: 100, months: 2, days: 3},
+}
+
+// benchModes are modes used by benchmarks.
+var benchModes = []struct {
+	name string
+	mode datediff.DiffMode
+}{
+	{name: "Y", mode: datediff.ModeYears},
+	{name: "M", mode: datediff.ModeMonths},
+	{name: "W", mode: datediff.ModeWeeks},
+	{name: "D", mode: datediff.ModeDays},
+	{name: "YMD", mode: datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays},
+	{name: "YMWD", mode: datediff.ModeAll},
+}
+
+var benchDiff datediff.Diff
+
+func BenchmarkNewDiffWithMode(b *testing.B) {
+	start := time.Date(1920, time.April, 17, 10, 30, 0, 0, time.UTC)
+	for _, span := range benchSpans {
+		end := start.AddDate(span.years, span.months, span.days)
+		for _, m := range benchModes {
+			b.Run(span.name+"/"+m.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					benchDiff, _ = datediff.NewDiffWithMode(start, end, m.mode)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkNewDiff(b *testing.B) {
+	start := time.Date(1950, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC)
+
+	for _, format := range []string{"%Y %M %D", "%M", "%W", "%D"} {
+		b.Run(format, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := datediff.NewDiff(start, end, format); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNewDiffWithFormat(b *testing.B) {
+	start := time.Date(1950, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC)
+	const rawFormat = "Years: %Y, months: %M, days: %D"
+	f := datediff.MustCompileFormat(rawFormat)
+
+	b.Run("raw", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchDiff, _ = datediff.NewDiff(start, end, rawFormat)
+		}
+	})
+	b.Run("compiled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchDiff, _ = datediff.NewDiffWithFormat(start, end, f)
+		}
+	})
+}
+
+func BenchmarkString(b *testing.B) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(2, 3, 5)
+	withFormat, _ := datediff.NewDiff(start, end, "%Y, %M and %D")
+	withMode, _ := datediff.NewDiffWithMode(start, end, datediff.ModeAll)
+
+	for _, bm := range []struct {
+		name string
+		diff datediff.Diff
+	}{
+		{name: "format", diff: withFormat},
+		{name: "mode", diff: withMode},
+		{name: "zeros", diff: withFormat.WithZeros()},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = bm.diff.String()
+			}
+		})
+		b.Run(bm.name+"/append", func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 64)
+			for i := 0; i < b.N; i++ {
+				buf = bm.diff.AppendString(buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkFormatter(b *testing.B) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	diff, _ := datediff.NewDiffWithMode(start, start.AddDate(2, 3, 5), datediff.ModeAll)
+	ru, err := datediff.LookupLocale("ru")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, bm := range []struct {
+		name      string
+		formatter datediff.Formatter
+	}{
+		{name: "en", formatter: datediff.Formatter{}},
+		{name: "ru", formatter: datediff.Formatter{Locale: ru}},
+		{name: "compact", formatter: datediff.Formatter{Compact: true}},
+		{name: "casing", formatter: datediff.Formatter{Casing: datediff.CasingUpper}},
+		{name: "numbers", formatter: datediff.Formatter{Numbers: datediff.NumberingArabicIndic}},
+	} {
+		b.Run(bm.name+"/Format", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := bm.formatter.Format(diff, "%Y %M %D"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(bm.name+"/Ago", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = bm.formatter.Ago(diff)
+			}
+		})
+	}
+}
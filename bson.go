@@ -0,0 +1,236 @@
+package datediff
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// These are BSON element types used in dates difference document.
+const (
+	bsonDouble   byte = 0x01
+	bsonString   byte = 0x02
+	bsonEmbedded byte = 0x03
+	bsonBool     byte = 0x08
+	bsonInt32    byte = 0x10
+	bsonInt64    byte = 0x12
+)
+
+var errBSONLength = errors.New("invalid BSON document length")
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface of the MongoDB
+// driver v2, which uses plain bytes for value types, so no driver dependency
+// is required. Dates difference is stored as an embedded document with the
+// JSON object schema, the mode is stored as names of time units:
+//
+//	{years: 2, months: 3, weeks: 0, days: 4, format: "%Y %M %D", mode: "years|months|days",
+//	 start: "2000-04-17T00:00:00Z", end: "2002-07-21T00:00:00Z"}
+//
+// Values are stored as int32, or int64 when they do not fit into int32.
+// Format and mode are omitted when they are empty. Start and end dates are
+// stored as strings in RFC 3339 format to keep the zone offset, they are
+// omitted when dates difference was not calculated from dates. Boolean
+// inclusiveEnd is added when the end date is included.
+func (d Diff) MarshalBSONValue() (byte, []byte, error) {
+	mode, err := d.mode.MarshalText()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	b := make([]byte, 4, 64+len(d.rawFormat))
+	for _, v := range []struct {
+		name string
+		n    int
+	}{
+		{name: "years", n: d.Years},
+		{name: "months", n: d.Months},
+		{name: "weeks", n: d.Weeks},
+		{name: "days", n: d.Days},
+	} {
+		if v.n >= math.MinInt32 && v.n <= math.MaxInt32 {
+			b = appendBSONName(b, bsonInt32, v.name)
+			b = appendUint32(b, uint32(int32(v.n)))
+		} else {
+			b = appendBSONName(b, bsonInt64, v.name)
+			b = appendUint64(b, uint64(int64(v.n)))
+		}
+	}
+	if d.rawFormat != "" {
+		b = appendBSONString(b, "format", d.rawFormat)
+	}
+	if len(mode) > 0 {
+		b = appendBSONString(b, "mode", string(mode))
+	}
+	if !d.start.IsZero() {
+		b = appendBSONString(b, "start", d.start.Format(time.RFC3339Nano))
+	}
+	if !d.end.IsZero() {
+		b = appendBSONString(b, "end", d.end.Format(time.RFC3339Nano))
+	}
+	if d.inclusive {
+		b = appendBSONName(b, bsonBool, "inclusiveEnd")
+		b = append(b, 1)
+	}
+	b = append(b, 0)
+	binary.LittleEndian.PutUint32(b, uint32(len(b)))
+	return bsonEmbedded, b, nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface of the
+// MongoDB driver v2. It expects the embedded document produced by
+// MarshalBSONValue. Values can be stored as int32, int64 or integral double,
+// the mode can be stored as names of time units or a number. Fields with
+// unknown names are ignored, but fields of types other than numbers, strings
+// and booleans are rejected.
+func (d *Diff) UnmarshalBSONValue(t byte, data []byte) error {
+	if t != bsonEmbedded {
+		return fmt.Errorf("unsupported BSON value type 0x%02x", t)
+	}
+	if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data) || data[len(data)-1] != 0 {
+		return errBSONLength
+	}
+
+	var o diffObject
+	data = data[4 : len(data)-1]
+	for len(data) > 0 {
+		t := data[0]
+		end := strings.IndexByte(string(data[1:]), 0)
+		if end < 0 {
+			return errBSONLength
+		}
+		name := string(data[1 : 1+end])
+		data = data[2+end:]
+
+		var (
+			n   int64
+			s   string
+			ok  bool
+			err error
+		)
+		switch t {
+		case bsonInt32:
+			if len(data) < 4 {
+				return errBSONLength
+			}
+			n = int64(int32(binary.LittleEndian.Uint32(data)))
+			data = data[4:]
+		case bsonInt64:
+			if len(data) < 8 {
+				return errBSONLength
+			}
+			n = int64(binary.LittleEndian.Uint64(data))
+			data = data[8:]
+		case bsonDouble:
+			if len(data) < 8 {
+				return errBSONLength
+			}
+			f := math.Float64frombits(binary.LittleEndian.Uint64(data))
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("BSON field %s has non-integral value %v", name, f)
+			}
+			n = int64(f)
+			data = data[8:]
+		case bsonString:
+			s, data, err = readBSONString(data)
+			if err != nil {
+				return err
+			}
+		case bsonBool:
+			if len(data) < 1 {
+				return errBSONLength
+			}
+			if data[0] > 1 {
+				return fmt.Errorf("BSON field %s has invalid boolean value %d", name, data[0])
+			}
+			ok = data[0] == 1
+			data = data[1:]
+		default:
+			return fmt.Errorf("BSON field %s has unsupported type 0x%02x", name, t)
+		}
+		if int64(int(n)) != n {
+			return fmt.Errorf("BSON field %s value %d overflows int", name, n)
+		}
+
+		switch name {
+		case "years":
+			o.Years = int(n)
+		case "months":
+			o.Months = int(n)
+		case "weeks":
+			o.Weeks = int(n)
+		case "days":
+			o.Days = int(n)
+		case "format":
+			o.Format = s
+		case "mode":
+			if t != bsonString {
+				if n < 0 || n > math.MaxUint8 {
+					return fmt.Errorf("invalid dates difference mode %d", n)
+				}
+				o.Mode = DiffMode(n)
+			} else if err := o.Mode.UnmarshalText([]byte(s)); err != nil {
+				return err
+			}
+		case "start", "end":
+			date, err := time.Parse(time.RFC3339Nano, s)
+			if t != bsonString || err != nil {
+				return fmt.Errorf("BSON field %s has invalid date %q", name, s)
+			}
+			if name == "start" {
+				o.Start = &date
+			} else {
+				o.End = &date
+			}
+		case "inclusiveEnd":
+			o.InclusiveEnd = ok
+		}
+	}
+
+	diff, err := o.diff()
+	if err != nil {
+		return err
+	}
+	*d = diff
+	return nil
+}
+
+func appendBSONName(b []byte, t byte, name string) []byte {
+	b = append(b, t)
+	b = append(b, name...)
+	return append(b, 0)
+}
+
+func appendBSONString(b []byte, name, s string) []byte {
+	b = appendBSONName(b, bsonString, name)
+	b = appendUint32(b, uint32(len(s)+1))
+	b = append(b, s...)
+	return append(b, 0)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// readBSONString reads BSON string value and returns the rest of data.
+func readBSONString(data []byte) (string, []byte, error) {
+	if len(data) < 4 {
+		return "", nil, errBSONLength
+	}
+	l := int(binary.LittleEndian.Uint32(data))
+	data = data[4:]
+	if l < 1 || l > len(data) || data[l-1] != 0 {
+		return "", nil, errBSONLength
+	}
+	return string(data[:l-1]), data[l:], nil
+}
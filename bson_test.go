@@ -0,0 +1,147 @@
+package datediff_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestBSON(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	withMode, err := datediff.NewDiffWithMode(start, end, datediff.ModeWeeks|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	diffs := []datediff.Diff{
+		mustNewDiff(t, start, end, "%Y and %M"),
+		withMode,
+		{Years: -2, Days: 1 << 40},
+	}
+	for _, diff := range diffs {
+		typ, data, err := diff.MarshalBSONValue()
+		if err != nil {
+			t.Fatalf("MarshalBSONValue() failed: %v", err)
+		}
+		var got datediff.Diff
+		if err := got.UnmarshalBSONValue(typ, data); err != nil {
+			t.Fatalf("UnmarshalBSONValue(%v) failed: %v", data, err)
+		}
+		if !got.Equal(diff) {
+			t.Errorf("UnmarshalBSONValue(%v) = %#v, want %#v", data, got, diff)
+		}
+		if got.String() != diff.String() {
+			t.Errorf("UnmarshalBSONValue(%v) String() = %s, want %s", data, got.String(), diff.String())
+		}
+	}
+}
+
+func TestMarshalBSONValue(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	diff := mustNewDiff(t, start, end, "%Y")
+	expected := []byte{
+		142, 0, 0, 0,
+		0x10, 'y', 'e', 'a', 'r', 's', 0, 3, 0, 0, 0,
+		0x10, 'm', 'o', 'n', 't', 'h', 's', 0, 0, 0, 0, 0,
+		0x10, 'w', 'e', 'e', 'k', 's', 0, 0, 0, 0, 0,
+		0x10, 'd', 'a', 'y', 's', 0, 0, 0, 0, 0,
+		0x02, 'f', 'o', 'r', 'm', 'a', 't', 0, 3, 0, 0, 0, '%', 'Y', 0,
+		0x02, 'm', 'o', 'd', 'e', 0, 6, 0, 0, 0, 'y', 'e', 'a', 'r', 's', 0,
+		0x02, 's', 't', 'a', 'r', 't', 0, 21, 0, 0, 0,
+		'2', '0', '0', '0', '-', '0', '4', '-', '1', '7', 'T', '0', '0', ':', '0', '0', ':', '0', '0', 'Z', 0,
+		0x02, 'e', 'n', 'd', 0, 21, 0, 0, 0,
+		'2', '0', '0', '3', '-', '0', '5', '-', '1', '8', 'T', '0', '0', ':', '0', '0', ':', '0', '0', 'Z', 0,
+		0,
+	}
+	typ, got, err := diff.MarshalBSONValue()
+	if err != nil {
+		t.Fatalf("MarshalBSONValue() failed: %v", err)
+	}
+	if typ != 0x03 {
+		t.Errorf("MarshalBSONValue() type = 0x%02x, want 0x03", typ)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("MarshalBSONValue() = %v, want %v", got, expected)
+	}
+}
+
+func TestUnmarshalBSONValue(t *testing.T) {
+	// {years: 2.0, days: int64(4), mode: int32(144), note: "ignored"}
+	data := bsonDocument(
+		0x01, 'y', 'e', 'a', 'r', 's', 0, 0, 0, 0, 0, 0, 0, 0, 0x40,
+		0x12, 'd', 'a', 'y', 's', 0, 4, 0, 0, 0, 0, 0, 0, 0,
+		0x10, 'm', 'o', 'd', 'e', 0, 144, 0, 0, 0,
+		0x02, 'n', 'o', 't', 'e', 0, 2, 0, 0, 0, 'x', 0,
+	)
+	var got datediff.Diff
+	if err := got.UnmarshalBSONValue(0x03, data); err != nil {
+		t.Fatalf("UnmarshalBSONValue() failed: %v", err)
+	}
+	expected := "2 years 4 days"
+	if got.String() != expected {
+		t.Errorf("UnmarshalBSONValue() String() = %s, want %s", got.String(), expected)
+	}
+}
+
+// bsonDocument returns BSON document of the elements.
+func bsonDocument(elements ...byte) []byte {
+	n := len(elements) + 5
+	doc := []byte{byte(n), byte(n >> 8), byte(n >> 16), byte(n >> 24)}
+	doc = append(doc, elements...)
+	return append(doc, 0)
+}
+
+func TestUnmarshalBSONValueFails(t *testing.T) {
+	testCases := []struct {
+		typ      byte
+		data     []byte
+		expected string
+	}{
+		{typ: 0x02, data: []byte{2, 0, 0, 0, 'x', 0}, expected: "unsupported BSON value type 0x02"},
+		{data: nil, expected: "invalid BSON document length"},
+		{data: []byte{6, 0, 0, 0, 0}, expected: "invalid BSON document length"},
+		{data: bsonDocument(0x10, 'y', 0, 1, 0), expected: "invalid BSON document length"},
+		{data: bsonDocument(0x10, 'y'), expected: "invalid BSON document length"},
+		{data: bsonDocument(0x02, 'f', 0, 5, 0, 0, 0, '%', 'Y', 0), expected: "invalid BSON document length"},
+		{data: bsonDocument(0x03, 'y', 0, 5, 0, 0, 0, 0), expected: "BSON field y has unsupported type 0x03"},
+		{data: bsonDocument(0x08, 'i', 0, 2), expected: "BSON field i has invalid boolean value 2"},
+		{data: bsonDocument(0x08, 'i', 0), expected: "invalid BSON document length"},
+		{
+			data:     bsonDocument(0x02, 'e', 'n', 'd', 0, 6, 0, 0, 0, '2', '0', '0', '0', '-', 0),
+			expected: `BSON field end has invalid date "2000-"`,
+		},
+		{
+			data:     bsonDocument(0x01, 'd', 'a', 'y', 's', 0, 0, 0, 0, 0, 0, 0, 0xf8, 0x3f),
+			expected: "BSON field days has non-integral value 1.5",
+		},
+		{
+			data:     bsonDocument(0x02, 'm', 'o', 'd', 'e', 0, 6, 0, 0, 0, 'h', 'o', 'u', 'r', 's', 0),
+			expected: `mode "hours" has unknown time unit "hours"`,
+		},
+		{
+			data:     bsonDocument(0x10, 'm', 'o', 'd', 'e', 0, 0, 1, 0, 0),
+			expected: "invalid dates difference mode 256",
+		},
+		{
+			data:     bsonDocument(0x02, 'f', 'o', 'r', 'm', 'a', 't', 0, 3, 0, 0, 0, '%', 'X', 0),
+			expected: `format "%X" has unknown verb X`,
+		},
+	}
+	for _, tC := range testCases {
+		if tC.typ == 0 {
+			tC.typ = 0x03
+		}
+		var got datediff.Diff
+		err := got.UnmarshalBSONValue(tC.typ, tC.data)
+		if err == nil {
+			t.Errorf("UnmarshalBSONValue(0x%02x, %v) = %#v, want to fail due to %s", tC.typ, tC.data, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("UnmarshalBSONValue(0x%02x, %v) failed: %v, want to fail due to %s", tC.typ, tC.data, err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package datediff
+
+import "time"
+
+// EachMonth returns the iterator over calendar months within the range of
+// dates. Periods are aligned to the first day of months in the location of
+// the start date, so the first and the last periods can be partial, i.e the
+// range from January 15 to March 10 yields January 15 - February 1,
+// February 1 - March 1, and March 1 - March 10. Dates differences of periods
+// are calculated in months and days. The iterator yields nothing when the
+// start date is not before the end date.
+//
+// The iterator has the signature of iter.Seq[Period], in Go 1.23 and later it
+// can be used with range-over-func.
+func EachMonth(start, end time.Time) func(yield func(Period) bool) {
+	return eachPeriod(start, end, ModeMonths|ModeDays, func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	})
+}
+
+// EachWeek returns the iterator over calendar weeks within the range of
+// dates. Weeks start on Monday unless WithWeekStart option is provided, other
+// options are ignored. Periods are aligned as EachMonth does. Dates
+// differences of periods are calculated in weeks and days.
+func EachWeek(start, end time.Time, opts ...Option) func(yield func(Period) bool) {
+	weekStart := newOptions(opts).weekStart
+	return eachPeriod(start, end, ModeWeeks|ModeDays, func(t time.Time) time.Time {
+		days := (int(weekStart-t.Weekday())+daysInWeek-1)%daysInWeek + 1
+		return time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, t.Location())
+	})
+}
+
+// CalendarWeeks returns the number of calendar weeks boundaries between
+// dates, i.e there is 1 calendar week from Sunday to the following Monday
+// when weeks start on Monday, and there are none when weeks start on Sunday.
+// Options that adjust the dates and WithWeekStart are applied.
+//
+// CalendarWeeks returns error when start date is after end date.
+func CalendarWeeks(start, end time.Time, opts ...Option) (int, error) {
+	if start.After(end) {
+		return 0, ErrStartAfterEnd
+	}
+	o := newOptions(opts)
+	start, end = o.dates(start, end)
+	s := newCivilDate(start)
+	e := newCivilDate(end.In(start.Location()))
+	return int((e.days-weekOffset(e.t, o.weekStart))-(s.days-weekOffset(s.t, o.weekStart))) / daysInWeek, nil
+}
+
+// weekOffset returns the number of days since the start of the week.
+func weekOffset(t time.Time, weekStart time.Weekday) int64 {
+	return int64((t.Weekday() - weekStart + daysInWeek) % daysInWeek)
+}
+
+// EachDay returns the iterator over calendar days within the range of dates.
+// Periods are aligned to midnight as EachMonth does. Dates differences of
+// periods are calculated in days.
+func EachDay(start, end time.Time) func(yield func(Period) bool) {
+	return eachPeriod(start, end, ModeDays, func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	})
+}
+
+// eachPeriod returns the iterator over periods within the range of dates.
+// The next function returns the start of the period that follows the date.
+func eachPeriod(start, end time.Time, mode DiffMode, next func(time.Time) time.Time) func(yield func(Period) bool) {
+	return func(yield func(Period) bool) {
+		for from := start; from.Before(end); {
+			to := next(from)
+			if to.After(end) {
+				to = end
+			}
+			if !yield(Period{Start: from, End: to, Diff: newDiff(from, to, mode)}) {
+				return
+			}
+			from = to
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// eachWeek iterates over weeks that start on Monday.
+func eachWeek(start, end time.Time) func(yield func(datediff.Period) bool) {
+	return datediff.EachWeek(start, end)
+}
+
+func TestEachPeriod(t *testing.T) {
+	const timeFmt = "2006-01-02T15"
+
+	testCases := []struct {
+		desc     string
+		each     func(start, end time.Time) func(yield func(datediff.Period) bool)
+		start    string
+		end      string
+		expected []string // start, end dates and dates difference of periods
+	}{
+		{
+			desc:  "months",
+			each:  datediff.EachMonth,
+			start: "2020-01-15T00",
+			end:   "2020-03-10T00",
+			expected: []string{
+				"2020-01-15T00", "2020-02-01T00", "17 days",
+				"2020-02-01T00", "2020-03-01T00", "1 month",
+				"2020-03-01T00", "2020-03-10T00", "9 days",
+			},
+		},
+		{
+			desc:  "months over year end",
+			each:  datediff.EachMonth,
+			start: "2020-12-01T00",
+			end:   "2021-02-01T00",
+			expected: []string{
+				"2020-12-01T00", "2021-01-01T00", "1 month",
+				"2021-01-01T00", "2021-02-01T00", "1 month",
+			},
+		},
+		{
+			desc:  "weeks",
+			each:  eachWeek,
+			start: "2021-06-02T00", // Wednesday
+			end:   "2021-06-21T12",
+			expected: []string{
+				"2021-06-02T00", "2021-06-07T00", "5 days",
+				"2021-06-07T00", "2021-06-14T00", "1 week",
+				"2021-06-14T00", "2021-06-21T00", "1 week",
+				"2021-06-21T00", "2021-06-21T12", "",
+			},
+		},
+		{
+			desc:  "week starting on Monday",
+			each:  eachWeek,
+			start: "2021-06-07T00",
+			end:   "2021-06-14T00",
+			expected: []string{
+				"2021-06-07T00", "2021-06-14T00", "1 week",
+			},
+		},
+		{
+			desc: "weeks starting on Sunday",
+			each: func(start, end time.Time) func(yield func(datediff.Period) bool) {
+				return datediff.EachWeek(start, end, datediff.WithWeekStart(time.Sunday))
+			},
+			start: "2021-06-02T00", // Wednesday
+			end:   "2021-06-15T00",
+			expected: []string{
+				"2021-06-02T00", "2021-06-06T00", "4 days",
+				"2021-06-06T00", "2021-06-13T00", "1 week",
+				"2021-06-13T00", "2021-06-15T00", "2 days",
+			},
+		},
+		{
+			desc:  "days",
+			each:  datediff.EachDay,
+			start: "2021-06-02T18",
+			end:   "2021-06-04T00",
+			expected: []string{
+				"2021-06-02T18", "2021-06-03T00", "",
+				"2021-06-03T00", "2021-06-04T00", "1 day",
+			},
+		},
+		{
+			desc:  "empty range",
+			each:  datediff.EachDay,
+			start: "2021-06-02T00",
+			end:   "2021-06-02T00",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			start, _ := time.Parse(timeFmt, tC.start)
+			end, _ := time.Parse(timeFmt, tC.end)
+
+			var got []string
+			tC.each(start, end)(func(p datediff.Period) bool {
+				got = append(got, p.Start.Format(timeFmt), p.End.Format(timeFmt), p.Diff.String())
+				return true
+			})
+			if len(got) != len(tC.expected) {
+				t.Fatalf("each() = %q, want %q", got, tC.expected)
+			}
+			for i := range got {
+				if got[i] != tC.expected[i] {
+					t.Errorf("each() = %q, want %q", got, tC.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarWeeks(t *testing.T) {
+	// Sunday
+	sunday := time.Date(2021, time.June, 6, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		opts     []datediff.Option
+		expected int
+	}{
+		{desc: "same day", start: sunday, end: sunday, expected: 0},
+		{desc: "Sunday to Monday", start: sunday, end: sunday.AddDate(0, 0, 1), expected: 1},
+		{desc: "Sunday to Monday with Sunday week start", start: sunday, end: sunday.AddDate(0, 0, 1), opts: []datediff.Option{datediff.WithWeekStart(time.Sunday)}, expected: 0},
+		{desc: "Saturday to Sunday with Sunday week start", start: sunday.AddDate(0, 0, -1), end: sunday, opts: []datediff.Option{datediff.WithWeekStart(time.Sunday)}, expected: 1},
+		{desc: "Friday to Saturday with Saturday week start", start: sunday.AddDate(0, 0, -2), end: sunday.AddDate(0, 0, -1), opts: []datediff.Option{datediff.WithWeekStart(time.Saturday)}, expected: 1},
+		{desc: "Monday to Sunday", start: sunday.AddDate(0, 0, 1), end: sunday.AddDate(0, 0, 7), expected: 0},
+		{desc: "three weeks", start: sunday, end: sunday.AddDate(0, 0, 15), expected: 3},
+		{desc: "inclusive end", start: sunday.AddDate(0, 0, 1), end: sunday.AddDate(0, 0, 7), opts: []datediff.Option{datediff.WithInclusiveEnd()}, expected: 1},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.CalendarWeeks(tC.start, tC.end, tC.opts...)
+		if err != nil {
+			t.Errorf("CalendarWeeks() %s failed: %v", tC.desc, err)
+		} else if got != tC.expected {
+			t.Errorf("CalendarWeeks() %s = %d, want %d", tC.desc, got, tC.expected)
+		}
+	}
+
+	if _, err := datediff.CalendarWeeks(sunday.AddDate(0, 0, 1), sunday); err != datediff.ErrStartAfterEnd {
+		t.Errorf("CalendarWeeks() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+}
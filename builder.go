@@ -0,0 +1,91 @@
+package datediff
+
+import "time"
+
+// DiffBuilder builds dates difference step by step, it's an alternative to
+// format verbs and mode flags:
+//
+//	diff, err := datediff.Between(start, end).
+//		Units(datediff.ModeYears, datediff.ModeMonths).
+//		Locale("de").
+//		Rounding(datediff.RoundingCeil).
+//		Build()
+//
+// Errors of the steps are returned by Build.
+type DiffBuilder struct {
+	start     time.Time
+	end       time.Time
+	mode      DiffMode
+	rawFormat string
+	opts      []Option
+	err       error
+}
+
+// Between starts building dates difference between the dates.
+func Between(start, end time.Time) *DiffBuilder {
+	return &DiffBuilder{start: start, end: end}
+}
+
+// Units adds time units of dates difference.
+func (b *DiffBuilder) Units(units ...DiffMode) *DiffBuilder {
+	for _, u := range units {
+		b.mode |= u
+	}
+	return b
+}
+
+// Format sets the format of dates difference, time units are defined by the
+// format verbs. Format takes precedence over Units.
+func (b *DiffBuilder) Format(rawFormat string) *DiffBuilder {
+	b.rawFormat = rawFormat
+	return b
+}
+
+// Locale sets the locale of dates difference by name, see LookupLocale.
+func (b *DiffBuilder) Locale(name string) *DiffBuilder {
+	l, err := LookupLocale(name)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	return b.With(WithLocale(l))
+}
+
+// Rounding sets rounding of the smallest time unit.
+func (b *DiffBuilder) Rounding(r Rounding) *DiffBuilder {
+	return b.With(WithRounding(r))
+}
+
+// InclusiveEnd includes the end date in dates difference.
+func (b *DiffBuilder) InclusiveEnd() *DiffBuilder {
+	return b.With(WithInclusiveEnd())
+}
+
+// In sets the location dates difference is calculated in.
+func (b *DiffBuilder) In(loc *time.Location) *DiffBuilder {
+	return b.With(WithLocation(loc))
+}
+
+// With adds options of dates difference calculation.
+func (b *DiffBuilder) With(opts ...Option) *DiffBuilder {
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+// Build calculates dates difference. It returns the first error of the
+// building steps, or error of NewDiff or NewDiffWithMode. It returns error
+// when neither time units nor format are provided.
+func (b *DiffBuilder) Build() (Diff, error) {
+	if b.err != nil {
+		return Diff{}, b.err
+	}
+	if b.rawFormat != "" {
+		return NewDiff(b.start, b.end, b.rawFormat, b.opts...)
+	}
+	if b.mode == 0 {
+		return Diff{}, ErrUndefinedMode
+	}
+	return NewDiffWithMode(b.start, b.end, b.mode, b.opts...)
+}
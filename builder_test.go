@@ -0,0 +1,84 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestDiffBuilder(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		builder  *datediff.DiffBuilder
+		expected string
+	}{
+		{
+			desc:     "units",
+			builder:  datediff.Between(start, end).Units(datediff.ModeYears, datediff.ModeDays),
+			expected: "3 years 31 days",
+		},
+		{
+			desc:     "format",
+			builder:  datediff.Between(start, end).Units(datediff.ModeDays).Format("%Y and %M"),
+			expected: "3 years and 1 month",
+		},
+		{
+			desc: "locale and rounding",
+			builder: datediff.Between(start, end).
+				Units(datediff.ModeYears, datediff.ModeMonths).
+				Locale("de").
+				Rounding(datediff.RoundingCeil),
+			expected: "3 Jahre 2 Monate",
+		},
+		{
+			desc:     "inclusive end",
+			builder:  datediff.Between(start, start.AddDate(0, 0, 6)).Units(datediff.ModeWeeks).InclusiveEnd(),
+			expected: "1 week",
+		},
+		{
+			desc: "location",
+			builder: datediff.Between(start.Add(-time.Hour), end.Add(-time.Hour)).
+				Units(datediff.ModeDays).
+				In(time.FixedZone("UTC+2", 2*60*60)),
+			expected: "1126 days",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := tC.builder.Build()
+			if err != nil {
+				t.Fatalf("Build() failed: %v", err)
+			}
+			if got.String() != tC.expected {
+				t.Errorf("Build() String() = %s, want %s", got.String(), tC.expected)
+			}
+		})
+	}
+}
+
+func TestDiffBuilderFails(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		builder  *datediff.DiffBuilder
+		expected string
+	}{
+		{builder: datediff.Between(start, end), expected: "undefined dates difference mode"},
+		{builder: datediff.Between(end, start).Units(datediff.ModeDays), expected: "start date is after end date"},
+		{builder: datediff.Between(start, end).Format("%X"), expected: `format "%X" has unknown verb X`},
+		{builder: datediff.Between(start, end).Units(datediff.ModeDays).Locale("xx"), expected: `unknown locale "xx"`},
+	}
+	for _, tC := range testCases {
+		got, err := tC.builder.Build()
+		if err == nil {
+			t.Errorf("Build() = %#v, want to fail due to %s", got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("Build() failed: %v, want to fail due to %s", err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package businesscal calculates dates differences in business days, i.e
+// days of SLAs or payment terms that exclude weekends and public holidays.
+//
+// Business days difference is a regular datediff.Diff calculated in days, so
+// it's formatted with the same format verbs:
+//
+//	d, _ := businesscal.NewBusinessDiff(start, end, businesscal.Standard)
+//	s, _ := d.Format("%D")
+//	fmt.Println(s) // 12 days
+package businesscal
+
+import (
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+const daysInWeek = 7
+
+// Standard is the calendar with Saturday and Sunday weekend.
+var Standard = NewCalendar(time.Saturday, time.Sunday)
+
+// Calendar defines business days of the week and public holidays. The zero
+// value of Calendar has no weekend days and holidays, so all days are
+// business days.
+type Calendar struct {
+	weekend  [daysInWeek]bool
+	holidays []HolidayProvider
+}
+
+// NewCalendar creates calendar with the weekend days, i.e Friday and Saturday
+// in Middle East countries.
+func NewCalendar(weekend ...time.Weekday) Calendar {
+	var c Calendar
+	for _, d := range weekend {
+		c.weekend[d%daysInWeek] = true
+	}
+	return c
+}
+
+// IsWeekend returns true when the day of the week is the weekend day.
+func (c Calendar) IsWeekend(d time.Weekday) bool {
+	return c.weekend[d%daysInWeek]
+}
+
+// WithHolidays returns a copy of the calendar that excludes holidays of the
+// providers from business days in addition to holidays of the calendar, i.e
+// businesscal.Standard.WithHolidays(businesscal.USFederal).
+func (c Calendar) WithHolidays(providers ...HolidayProvider) Calendar {
+	holidays := make([]HolidayProvider, 0, len(c.holidays)+len(providers))
+	c.holidays = append(append(holidays, c.holidays...), providers...)
+	return c
+}
+
+// IsHoliday returns true when the date is the holiday of any of holiday
+// providers of the calendar.
+func (c Calendar) IsHoliday(t time.Time) bool {
+	for _, p := range c.holidays {
+		if p.IsHoliday(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBusinessDay returns true when the date is neither the weekend day nor
+// the holiday.
+func (c Calendar) IsBusinessDay(t time.Time) bool {
+	return !c.IsWeekend(t.Weekday()) && !c.IsHoliday(t)
+}
+
+// AddBusinessDays returns the date advanced by n business days, i.e the
+// settlement date of the trade with T+2 settlement cycle. Dates that are not
+// business days are skipped. The date is moved back when n is negative. The
+// date is returned as is when the calendar has no business days of the week.
+func (c Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		n, step = -n, -1
+	}
+	if c.workdays() == 0 {
+		return t
+	}
+	for n > 0 {
+		if t = t.AddDate(0, 0, step); c.IsBusinessDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// NewBusinessDiff calculates business days between dates. Weekend days and
+// holidays of the calendar are excluded. Business days are
+// counted among full days between dates, the start date is counted and the
+// end date is not, i.e there are 5 business days from Monday to the next
+// Monday in the Standard calendar. Dates difference has days mode, its start
+// and end dates are the provided dates.
+//
+// NewBusinessDiff returns error when start date is after end date.
+func NewBusinessDiff(start, end time.Time, cal Calendar) (datediff.Diff, error) {
+	diff, err := datediff.NewDiffWithMode(start, end, datediff.ModeDays)
+	if err != nil {
+		return datediff.Diff{}, err
+	}
+	diff.Days = cal.businessDays(start, diff.Days)
+	return diff, nil
+}
+
+// businessDays returns the number of business days among n days starting
+// from the date. Weekend days are counted by weeks, holidays are checked day
+// by day.
+func (c Calendar) businessDays(start time.Time, n int) int {
+	from := start.Weekday()
+	days := n / daysInWeek * c.workdays()
+	for i := 0; i < n%daysInWeek; i++ {
+		if !c.IsWeekend(from + time.Weekday(i)) {
+			days++
+		}
+	}
+	if len(c.holidays) == 0 {
+		return days
+	}
+	year, month, day := start.Date()
+	for i := 0; i < n; i++ {
+		date := time.Date(year, month, day+i, 0, 0, 0, 0, start.Location())
+		if !c.IsWeekend(date.Weekday()) && c.IsHoliday(date) {
+			days--
+		}
+	}
+	return days
+}
+
+// workdays returns the number of days of the week that are not weekend days.
+func (c Calendar) workdays() int {
+	n := 0
+	for _, weekend := range c.weekend {
+		if !weekend {
+			n++
+		}
+	}
+	return n
+}
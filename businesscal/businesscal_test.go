@@ -0,0 +1,130 @@
+package businesscal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/businesscal"
+)
+
+func TestNewBusinessDiff(t *testing.T) {
+	// Monday
+	monday := time.Date(2023, time.October, 2, 9, 0, 0, 0, time.UTC)
+	friSat := businesscal.NewCalendar(time.Friday, time.Saturday)
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		cal      businesscal.Calendar
+		expected int
+	}{
+		{desc: "same date", start: monday, end: monday, cal: businesscal.Standard, expected: 0},
+		{desc: "one day", start: monday, end: monday.AddDate(0, 0, 1), cal: businesscal.Standard, expected: 1},
+		{desc: "less than full day", start: monday, end: monday.AddDate(0, 0, 1).Add(-time.Minute), cal: businesscal.Standard, expected: 0},
+		{desc: "full week", start: monday, end: monday.AddDate(0, 0, 7), cal: businesscal.Standard, expected: 5},
+		{desc: "from Friday to Monday", start: monday.AddDate(0, 0, 4), end: monday.AddDate(0, 0, 7), cal: businesscal.Standard, expected: 1},
+		{desc: "from Saturday to Monday", start: monday.AddDate(0, 0, 5), end: monday.AddDate(0, 0, 7), cal: businesscal.Standard, expected: 0},
+		{desc: "three weeks and two days", start: monday, end: monday.AddDate(0, 0, 23), cal: businesscal.Standard, expected: 17},
+		{desc: "Friday and Saturday weekend", start: monday, end: monday.AddDate(0, 0, 23), cal: friSat, expected: 17},
+		{desc: "Friday and Saturday weekend from Thursday", start: monday.AddDate(0, 0, 3), end: monday.AddDate(0, 0, 10), cal: friSat, expected: 5},
+		{desc: "no weekend", start: monday, end: monday.AddDate(0, 0, 23), cal: businesscal.Calendar{}, expected: 23},
+	}
+	for _, tC := range testCases {
+		got, err := businesscal.NewBusinessDiff(tC.start, tC.end, tC.cal)
+		if err != nil {
+			t.Errorf("NewBusinessDiff() %s failed: %v", tC.desc, err)
+			continue
+		}
+		if got.Days != tC.expected {
+			t.Errorf("NewBusinessDiff() %s = %d, want %d", tC.desc, got.Days, tC.expected)
+		}
+		if got.Mode() != datediff.ModeDays {
+			t.Errorf("NewBusinessDiff() %s mode = %s, want %s", tC.desc, got.Mode(), datediff.ModeDays)
+		}
+	}
+}
+
+func TestNewBusinessDiffLoop(t *testing.T) {
+	start := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, cal := range []businesscal.Calendar{
+		businesscal.Standard,
+		businesscal.NewCalendar(time.Friday, time.Saturday),
+		businesscal.NewCalendar(time.Sunday),
+	} {
+		want := 0
+		for days := 0; days < 60; days++ {
+			end := start.AddDate(0, 0, days)
+			got, err := businesscal.NewBusinessDiff(start, end, cal)
+			if err != nil {
+				t.Fatalf("NewBusinessDiff() failed: %v", err)
+			}
+			if got.Days != want {
+				t.Errorf("NewBusinessDiff(%s, %s) = %d, want %d", start, end, got.Days, want)
+			}
+			if cal.IsBusinessDay(end) {
+				want++
+			}
+		}
+	}
+}
+
+func TestNewBusinessDiffFormat(t *testing.T) {
+	start := time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC)
+	d, err := businesscal.NewBusinessDiff(start, start.AddDate(0, 0, 16), businesscal.Standard)
+	if err != nil {
+		t.Fatalf("NewBusinessDiff() failed: %v", err)
+	}
+	if got, want := d.String(), "12 days"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	got, err := d.Format("%d business days")
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+	if want := "12 business days"; got != want {
+		t.Errorf("Format() = %s, want %s", got, want)
+	}
+}
+
+func TestNewBusinessDiffFails(t *testing.T) {
+	now := time.Now()
+	_, err := businesscal.NewBusinessDiff(now.Add(time.Hour), now, businesscal.Standard)
+	if err == nil {
+		t.Errorf("want to fail due to start date is after end date")
+	} else if err.Error() != "start date is after end date" {
+		t.Errorf("NewBusinessDiff() error = %v, want start date is after end date", err)
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	nyse := businesscal.Standard.WithHolidays(businesscal.NYSE)
+	xetra := businesscal.Standard.WithHolidays(businesscal.XETRA)
+
+	testCases := []struct {
+		desc     string
+		cal      businesscal.Calendar
+		date     time.Time
+		n        int
+		expected time.Time
+	}{
+		{desc: "T+1 over Good Friday", cal: nyse, date: day(2024, time.March, 28), n: 1, expected: day(2024, time.April, 1)},
+		{desc: "T+2 over Christmas", cal: xetra, date: day(2024, time.December, 23), n: 2, expected: day(2024, time.December, 30)},
+		{desc: "T+2 from Saturday", cal: businesscal.Standard, date: day(2024, time.June, 1), n: 2, expected: day(2024, time.June, 4)},
+		{desc: "back over weekend", cal: businesscal.Standard, date: day(2024, time.June, 3), n: -1, expected: day(2024, time.May, 31)},
+		{desc: "zero days", cal: nyse, date: day(2024, time.March, 29), n: 0, expected: day(2024, time.March, 29)},
+		{
+			desc:     "no business days",
+			cal:      businesscal.NewCalendar(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday),
+			date:     day(2024, time.June, 3),
+			n:        1,
+			expected: day(2024, time.June, 3),
+		},
+	}
+	for _, tC := range testCases {
+		if got := tC.cal.AddBusinessDays(tC.date, tC.n); !got.Equal(tC.expected) {
+			t.Errorf("AddBusinessDays() %s = %s, want %s", tC.desc, got.Format("2006-01-02"), tC.expected.Format("2006-01-02"))
+		}
+	}
+}
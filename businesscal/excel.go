@@ -0,0 +1,96 @@
+package businesscal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// excelWeekends are weekend days of weekend numbers of Excel
+// NETWORKDAYS.INTL function.
+var excelWeekends = map[int][]time.Weekday{
+	1:  {time.Saturday, time.Sunday},
+	2:  {time.Sunday, time.Monday},
+	3:  {time.Monday, time.Tuesday},
+	4:  {time.Tuesday, time.Wednesday},
+	5:  {time.Wednesday, time.Thursday},
+	6:  {time.Thursday, time.Friday},
+	7:  {time.Friday, time.Saturday},
+	11: {time.Sunday},
+	12: {time.Monday},
+	13: {time.Tuesday},
+	14: {time.Wednesday},
+	15: {time.Thursday},
+	16: {time.Friday},
+	17: {time.Saturday},
+}
+
+// WeekendNumber returns calendar of the weekend number of Excel
+// NETWORKDAYS.INTL function, i.e 7 for Friday and Saturday weekend. It
+// returns error for unknown weekend numbers, as Excel returns #NUM!.
+func WeekendNumber(n int) (Calendar, error) {
+	weekend, ok := excelWeekends[n]
+	if !ok {
+		return Calendar{}, fmt.Errorf("unknown weekend number %d", n)
+	}
+	return NewCalendar(weekend...), nil
+}
+
+// ParseWeekendMask returns calendar of the weekend string of Excel
+// NETWORKDAYS.INTL function. The mask has seven characters for days from
+// Monday to Sunday, 1 is the weekend day and 0 is the working day, i.e
+// "0000011" for Saturday and Sunday weekend. It returns error for invalid
+// masks and the mask without working days, as Excel returns #VALUE!.
+func ParseWeekendMask(mask string) (Calendar, error) {
+	if len(mask) != daysInWeek {
+		return Calendar{}, fmt.Errorf("weekend mask %q is not 7 digits of 0 and 1", mask)
+	}
+	var c Calendar
+	for i := 0; i < daysInWeek; i++ {
+		switch mask[i] {
+		case '0':
+		case '1':
+			c.weekend[(i+1)%daysInWeek] = true
+		default:
+			return Calendar{}, fmt.Errorf("weekend mask %q is not 7 digits of 0 and 1", mask)
+		}
+	}
+	if mask == "1111111" {
+		return Calendar{}, fmt.Errorf("weekend mask %q has no working days", mask)
+	}
+	return c, nil
+}
+
+// NetworkDays returns the number of working days between dates as Excel
+// NETWORKDAYS function does, see NetworkDaysIntl.
+func NetworkDays(start, end time.Time, holidays ...time.Time) int {
+	return NetworkDaysIntl(start, end, Standard, holidays...)
+}
+
+// NetworkDaysIntl returns the number of working days between dates as Excel
+// NETWORKDAYS.INTL function does:
+//
+//	both start and end dates are counted
+//	only dates are taken into account, the time of the day is ignored
+//	the result is negative when start date is after end date
+//	holidays that fall on weekend days are not subtracted twice
+//
+// Weekend days of the calendar are created by WeekendNumber or
+// ParseWeekendMask, holidays of the calendar are excluded in addition to
+// the listed ones.
+func NetworkDaysIntl(start, end time.Time, weekend Calendar, holidays ...time.Time) int {
+	s, e := datediff.DateOf(start).Time(), datediff.DateOf(end).Time()
+	sign := 1
+	if s.After(e) {
+		s, e, sign = e, s, -1
+	}
+	cal := weekend
+	if len(holidays) > 0 {
+		cal = cal.WithHolidays(NewHolidayList(holidays...))
+	}
+	// dates of the start of days are in UTC, they are never after the
+	// following date
+	diff, _ := NewBusinessDiff(s, e.AddDate(0, 0, 1), cal)
+	return sign * diff.Days
+}
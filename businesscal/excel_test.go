@@ -0,0 +1,120 @@
+package businesscal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff/businesscal"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Expected values are results of Excel functions from examples of their
+// documentation.
+func TestNetworkDays(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		holidays []time.Time
+		expected int
+	}{
+		{desc: "without holidays", start: day(2012, time.October, 1), end: day(2013, time.March, 1), expected: 110},
+		{
+			desc:     "with holiday",
+			start:    day(2012, time.October, 1),
+			end:      day(2013, time.March, 1),
+			holidays: []time.Time{day(2012, time.November, 22)},
+			expected: 109,
+		},
+		{
+			desc:  "with holidays",
+			start: day(2012, time.October, 1),
+			end:   day(2013, time.March, 1),
+			holidays: []time.Time{
+				day(2012, time.November, 22), day(2012, time.December, 4), day(2013, time.January, 21),
+				// duplicated and weekend holidays
+				day(2012, time.December, 4), day(2012, time.December, 8),
+			},
+			expected: 107,
+		},
+		{desc: "same working day", start: day(2023, time.November, 20), end: day(2023, time.November, 20), expected: 1},
+		{desc: "same weekend day", start: day(2023, time.November, 19), end: day(2023, time.November, 19), expected: 0},
+		{desc: "time of the day", start: day(2023, time.November, 20).Add(23 * time.Hour), end: day(2023, time.November, 21).Add(time.Hour), expected: 2},
+		{desc: "start after end", start: day(2013, time.March, 1), end: day(2012, time.October, 1), expected: -110},
+	}
+	for _, tC := range testCases {
+		if got := businesscal.NetworkDays(tC.start, tC.end, tC.holidays...); got != tC.expected {
+			t.Errorf("NetworkDays() %s = %d, want %d", tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestNetworkDaysIntl(t *testing.T) {
+	holidays := []time.Time{day(2006, time.January, 2), day(2006, time.January, 16)}
+	weekend7, err := businesscal.WeekendNumber(7)
+	if err != nil {
+		t.Fatalf("WeekendNumber(7) failed: %v", err)
+	}
+	tuesdaySunday, err := businesscal.ParseWeekendMask("0100001")
+	if err != nil {
+		t.Fatalf("ParseWeekendMask() failed: %v", err)
+	}
+	noWeekend, err := businesscal.ParseWeekendMask("0000000")
+	if err != nil {
+		t.Fatalf("ParseWeekendMask() failed: %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		weekend  businesscal.Calendar
+		holidays []time.Time
+		expected int
+	}{
+		{desc: "default weekend", start: day(2006, time.January, 1), end: day(2006, time.January, 31), weekend: businesscal.Standard, expected: 22},
+		{desc: "start after end", start: day(2006, time.February, 28), end: day(2006, time.January, 31), weekend: businesscal.Standard, expected: -21},
+		{desc: "weekend number", start: day(2006, time.January, 1), end: day(2006, time.February, 1), weekend: weekend7, holidays: holidays, expected: 22},
+		{desc: "weekend mask", start: day(2006, time.January, 1), end: day(2006, time.February, 1), weekend: tuesdaySunday, holidays: holidays, expected: 20},
+		{desc: "no weekend", start: day(2006, time.January, 1), end: day(2006, time.February, 1), weekend: noWeekend, holidays: holidays, expected: 30},
+	}
+	for _, tC := range testCases {
+		if got := businesscal.NetworkDaysIntl(tC.start, tC.end, tC.weekend, tC.holidays...); got != tC.expected {
+			t.Errorf("NetworkDaysIntl() %s = %d, want %d", tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestWeekendFails(t *testing.T) {
+	for _, tC := range []struct {
+		n        int
+		expected string
+	}{
+		{n: 0, expected: "unknown weekend number 0"},
+		{n: 8, expected: "unknown weekend number 8"},
+	} {
+		if _, err := businesscal.WeekendNumber(tC.n); err == nil {
+			t.Errorf("WeekendNumber(%d) want to fail due to %s", tC.n, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("WeekendNumber(%d) error = %v, want %s", tC.n, err, tC.expected)
+		}
+	}
+
+	for _, tC := range []struct {
+		mask     string
+		expected string
+	}{
+		{mask: "000011", expected: `weekend mask "000011" is not 7 digits of 0 and 1`},
+		{mask: "0000012", expected: `weekend mask "0000012" is not 7 digits of 0 and 1`},
+		{mask: "1111111", expected: `weekend mask "1111111" has no working days`},
+	} {
+		if _, err := businesscal.ParseWeekendMask(tC.mask); err == nil {
+			t.Errorf("ParseWeekendMask(%s) want to fail due to %s", tC.mask, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParseWeekendMask(%s) error = %v, want %s", tC.mask, err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package businesscal
+
+import (
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// These are holiday providers of stock exchanges. They are combined with
+// the Standard calendar to calculate trading days and settlement dates, i.e
+// businesscal.Standard.WithHolidays(businesscal.NYSE).AddBusinessDays(trade, 1).
+var (
+	// NYSE defines holidays of the New York Stock Exchange, including
+	// one-off closures since 2001. Holidays that fall on Saturday are
+	// observed on the preceding Friday, except New Year's Day, ones that
+	// fall on Sunday are observed on the following Monday.
+	NYSE HolidayProvider = &yearlyHolidays{dates: nyseDates}
+	// Nasdaq defines holidays of the Nasdaq Stock Market, they are the same
+	// as NYSE holidays.
+	Nasdaq HolidayProvider = &yearlyHolidays{dates: nyseDates}
+	// LSE defines holidays of the London Stock Exchange, that is closed on
+	// bank holidays of England and Wales.
+	LSE HolidayProvider = &yearlyHolidays{dates: ukBankDates}
+	// XETRA defines trading holidays of the Xetra trading venue of the
+	// Frankfurt Stock Exchange: New Year's Day, Good Friday, Easter Monday,
+	// Labour Day, Christmas Eve, Christmas Day, December 26 and New Year's
+	// Eve.
+	XETRA HolidayProvider = &yearlyHolidays{dates: xetraDates}
+)
+
+// nyseClosures are one-off closures of the New York Stock Exchange.
+var nyseClosures = map[int][]datediff.Date{
+	2001: {
+		date(2001, time.September, 11), date(2001, time.September, 12),
+		date(2001, time.September, 13), date(2001, time.September, 14),
+	},
+	2004: {date(2004, time.June, 11)},                                  // Funeral of Ronald Reagan
+	2007: {date(2007, time.January, 2)},                                // Funeral of Gerald Ford
+	2012: {date(2012, time.October, 29), date(2012, time.October, 30)}, // Hurricane Sandy
+	2018: {date(2018, time.December, 5)},                               // Funeral of George H. W. Bush
+	2025: {date(2025, time.January, 9)},                                // Funeral of Jimmy Carter
+}
+
+func nyseDates(year int) []datediff.Date {
+	easter := easterSunday(year)
+	dates := []datediff.Date{
+		nthWeekday(year, time.February, time.Monday, 3), // Washington's Birthday
+		addDays(easter, -2),                             // Good Friday
+		lastWeekday(year, time.May, time.Monday),        // Memorial Day
+		observed(date(year, time.July, 4)),
+		nthWeekday(year, time.September, time.Monday, 1),  // Labor Day
+		nthWeekday(year, time.November, time.Thursday, 4), // Thanksgiving Day
+		observed(date(year, time.December, 25)),
+	}
+	// New Year's Day on Saturday is not observed at the end of the year
+	if newYear := date(year, time.January, 1); weekday(newYear) != time.Saturday {
+		dates = append(dates, observed(newYear))
+	}
+	if year >= 1998 {
+		dates = append(dates, nthWeekday(year, time.January, time.Monday, 3)) // Martin Luther King Jr. Day
+	}
+	if year >= 2022 {
+		dates = append(dates, observed(date(year, time.June, 19))) // Juneteenth
+	}
+	return append(dates, nyseClosures[year]...)
+}
+
+func xetraDates(year int) []datediff.Date {
+	easter := easterSunday(year)
+	return []datediff.Date{
+		date(year, time.January, 1),
+		addDays(easter, -2), // Good Friday
+		addDays(easter, 1),  // Easter Monday
+		date(year, time.May, 1),
+		date(year, time.December, 24),
+		date(year, time.December, 25),
+		date(year, time.December, 26),
+		date(year, time.December, 31),
+	}
+}
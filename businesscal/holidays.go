@@ -0,0 +1,70 @@
+package businesscal
+
+import (
+	"sync"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// HolidayProvider defines public holidays that are excluded from business
+// days. The date is passed at the start of the day in the location of the
+// start date of business days difference.
+type HolidayProvider interface {
+	IsHoliday(date time.Time) bool
+}
+
+// HolidayFunc is the adapter to use ordinary functions as holiday providers.
+type HolidayFunc func(date time.Time) bool
+
+// IsHoliday implements HolidayProvider interface.
+func (f HolidayFunc) IsHoliday(date time.Time) bool {
+	return f(date)
+}
+
+// HolidayList is the holiday provider of the fixed list of dates, i.e
+// company holidays.
+type HolidayList map[datediff.Date]bool
+
+// NewHolidayList creates holiday provider of the dates. Only dates are taken
+// into account, the time of the day is ignored.
+func NewHolidayList(dates ...time.Time) HolidayList {
+	l := make(HolidayList, len(dates))
+	for _, d := range dates {
+		l[datediff.DateOf(d)] = true
+	}
+	return l
+}
+
+// IsHoliday implements HolidayProvider interface.
+func (l HolidayList) IsHoliday(date time.Time) bool {
+	return l[datediff.DateOf(date)]
+}
+
+// yearlyHolidays is the holiday provider of holidays calculated by rules
+// for every year. Holidays of the year are calculated once.
+type yearlyHolidays struct {
+	// dates returns holidays of the year. Observed holidays can fall into
+	// the previous year, i.e US New Year's Day observed on December 31.
+	dates func(year int) []datediff.Date
+	years sync.Map // year to map[datediff.Date]bool
+}
+
+// IsHoliday implements HolidayProvider interface.
+func (h *yearlyHolidays) IsHoliday(date time.Time) bool {
+	d := datediff.DateOf(date)
+	return h.year(d.Year)[d] || h.year(d.Year + 1)[d]
+}
+
+func (h *yearlyHolidays) year(year int) map[datediff.Date]bool {
+	if m, ok := h.years.Load(year); ok {
+		return m.(map[datediff.Date]bool)
+	}
+	dates := h.dates(year)
+	m := make(map[datediff.Date]bool, len(dates))
+	for _, d := range dates {
+		m[d] = true
+	}
+	h.years.Store(year, m)
+	return m
+}
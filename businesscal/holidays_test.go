@@ -0,0 +1,153 @@
+package businesscal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/businesscal"
+)
+
+func TestHolidayProviders(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		provider businesscal.HolidayProvider
+		year     int
+		expected []string
+	}{
+		{
+			desc:     "US federal holidays",
+			provider: businesscal.USFederal,
+			year:     2021,
+			expected: []string{
+				"2021-01-01", "2021-01-18", "2021-02-15", "2021-05-31", "2021-06-18", "2021-07-05",
+				"2021-09-06", "2021-10-11", "2021-11-11", "2021-11-25", "2021-12-24", "2021-12-31",
+			},
+		},
+		{
+			desc:     "US federal holidays before Juneteenth",
+			provider: businesscal.USFederal,
+			year:     2019,
+			expected: []string{
+				"2019-01-01", "2019-01-21", "2019-02-18", "2019-05-27", "2019-07-04",
+				"2019-09-02", "2019-10-14", "2019-11-11", "2019-11-28", "2019-12-25",
+			},
+		},
+		{
+			desc:     "UK bank holidays",
+			provider: businesscal.UKBankHolidays,
+			year:     2022,
+			expected: []string{
+				"2022-01-03", "2022-04-15", "2022-04-18", "2022-05-02", "2022-06-02",
+				"2022-06-03", "2022-08-29", "2022-09-19", "2022-12-26", "2022-12-27",
+			},
+		},
+		{
+			desc:     "UK bank holidays with Christmas on Saturday",
+			provider: businesscal.UKBankHolidays,
+			year:     2021,
+			expected: []string{
+				"2021-01-01", "2021-04-02", "2021-04-05", "2021-05-03", "2021-05-31",
+				"2021-08-30", "2021-12-27", "2021-12-28",
+			},
+		},
+		{
+			desc:     "TARGET closing days",
+			provider: businesscal.TARGET,
+			year:     2024,
+			expected: []string{"2024-01-01", "2024-03-29", "2024-04-01", "2024-05-01", "2024-12-25", "2024-12-26"},
+		},
+		{
+			desc:     "NYSE holidays",
+			provider: businesscal.NYSE,
+			year:     2024,
+			expected: []string{
+				"2024-01-01", "2024-01-15", "2024-02-19", "2024-03-29", "2024-05-27",
+				"2024-06-19", "2024-07-04", "2024-09-02", "2024-11-28", "2024-12-25",
+			},
+		},
+		{
+			desc:     "NYSE holidays with New Year's Day on Saturday",
+			provider: businesscal.NYSE,
+			year:     2022,
+			expected: []string{
+				"2022-01-17", "2022-02-21", "2022-04-15", "2022-05-30", "2022-06-20",
+				"2022-07-04", "2022-09-05", "2022-11-24", "2022-12-26",
+			},
+		},
+		{
+			desc:     "Nasdaq holidays with closure",
+			provider: businesscal.Nasdaq,
+			year:     2025,
+			expected: []string{
+				"2025-01-01", "2025-01-09", "2025-01-20", "2025-02-17", "2025-04-18", "2025-05-26",
+				"2025-06-19", "2025-07-04", "2025-09-01", "2025-11-27", "2025-12-25",
+			},
+		},
+		{
+			desc:     "XETRA holidays",
+			provider: businesscal.XETRA,
+			year:     2024,
+			expected: []string{
+				"2024-01-01", "2024-03-29", "2024-04-01", "2024-05-01",
+				"2024-12-24", "2024-12-25", "2024-12-26", "2024-12-31",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		var got []string
+		start := time.Date(tC.year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		for d := start; d.Year() == tC.year; d = d.AddDate(0, 0, 1) {
+			if tC.provider.IsHoliday(d) {
+				got = append(got, d.Format("2006-01-02"))
+			}
+		}
+		if len(got) != len(tC.expected) {
+			t.Errorf("%s of %d = %v, want %v", tC.desc, tC.year, got, tC.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tC.expected[i] {
+				t.Errorf("%s of %d = %v, want %v", tC.desc, tC.year, got, tC.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestNewBusinessDiffWithHolidays(t *testing.T) {
+	// Monday, a week before Thanksgiving Day
+	start := time.Date(2023, time.November, 20, 9, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 14)
+	companyDay := businesscal.NewHolidayList(time.Date(2023, time.November, 24, 0, 0, 0, 0, time.UTC))
+	saturday := businesscal.HolidayFunc(func(date time.Time) bool { return date.Weekday() == time.Saturday })
+
+	testCases := []struct {
+		desc     string
+		cal      businesscal.Calendar
+		expected int
+	}{
+		{desc: "no holidays", cal: businesscal.Standard, expected: 10},
+		{desc: "US federal holidays", cal: businesscal.Standard.WithHolidays(businesscal.USFederal), expected: 9},
+		{desc: "US federal and company holidays", cal: businesscal.Standard.WithHolidays(businesscal.USFederal).WithHolidays(companyDay), expected: 8},
+		{desc: "holiday on the weekend", cal: businesscal.Standard.WithHolidays(saturday), expected: 10},
+		{desc: "holidays without weekend", cal: businesscal.Calendar{}.WithHolidays(saturday, companyDay), expected: 11},
+	}
+	for _, tC := range testCases {
+		got, err := businesscal.NewBusinessDiff(start, end, tC.cal)
+		if err != nil {
+			t.Errorf("NewBusinessDiff() %s failed: %v", tC.desc, err)
+		} else if got.Days != tC.expected {
+			t.Errorf("NewBusinessDiff() %s = %d, want %d", tC.desc, got.Days, tC.expected)
+		}
+	}
+}
+
+func TestWithHolidaysCopy(t *testing.T) {
+	thanksgiving := time.Date(2023, time.November, 23, 0, 0, 0, 0, time.UTC)
+	base := businesscal.Standard.WithHolidays(businesscal.TARGET)
+	_ = base.WithHolidays(businesscal.USFederal)
+	if !base.IsBusinessDay(thanksgiving) {
+		t.Errorf("IsBusinessDay(%s) = false, want true", datediff.DateOf(thanksgiving))
+	}
+}
@@ -0,0 +1,128 @@
+package businesscal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// dayEnd is the end of the day as the time of the day.
+const dayEnd = "24:00"
+
+// span is working time of the day as offsets from the start of the day.
+type span struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// WorkingHours defines working time of days of the week, i.e 09:00-17:30
+// from Monday to Friday. Days without working time are days off. The zero
+// value of WorkingHours has no working time.
+type WorkingHours struct {
+	days [daysInWeek]span
+}
+
+// NewWorkingHours creates working hours of the days of the week. Times are
+// written as "15:04", the end of the day is "24:00".
+//
+// NewWorkingHours returns error in the following cases:
+//
+//	time has invalid format
+//	end time is not after start time
+func NewWorkingHours(start, end string, days ...time.Weekday) (WorkingHours, error) {
+	return WorkingHours{}.With(start, end, days...)
+}
+
+// With returns a copy of working hours with working time of the days of the
+// week replaced, i.e shorter hours on Friday. It returns error as
+// NewWorkingHours does.
+func (w WorkingHours) With(start, end string, days ...time.Weekday) (WorkingHours, error) {
+	s, err := parseClock(start)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	e, err := parseClock(end)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	if e <= s {
+		return WorkingHours{}, fmt.Errorf("working hours %s-%s end before start", start, end)
+	}
+	for _, d := range days {
+		w.days[d%daysInWeek] = span{start: s, end: e}
+	}
+	return w, nil
+}
+
+// parseClock returns the time of the day written as "15:04" as the offset
+// from the start of the day.
+func parseClock(s string) (time.Duration, error) {
+	if s == dayEnd {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid working time %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// WorkingTime is working time elapsed between timestamps.
+type WorkingTime struct {
+	// Days are working days elapsed from the start to the end of their
+	// working hours.
+	Days int
+	// Hours is working time elapsed in days that were partially elapsed,
+	// i.e the day a support ticket was opened.
+	Hours time.Duration
+	// Elapsed is the total working time of full and partial days.
+	Elapsed time.Duration
+}
+
+// NewWorkingDiff calculates working time elapsed between timestamps, i.e
+// for support tickets SLA tracking. Working time of weekend days and holidays
+// of the calendar is not counted. Working hours are applied by the wall clock
+// in the location of the start date.
+//
+// NewWorkingDiff returns error when start date is after end date.
+func NewWorkingDiff(start, end time.Time, cal Calendar, hours WorkingHours) (WorkingTime, error) {
+	if start.After(end) {
+		return WorkingTime{}, datediff.ErrStartAfterEnd
+	}
+	loc := start.Location()
+	end = end.In(loc)
+
+	var wt WorkingTime
+	year, month, day := start.Date()
+	for i := 0; ; i++ {
+		date := time.Date(year, month, day+i, 0, 0, 0, 0, loc)
+		if date.After(end) {
+			break
+		}
+		s := hours.days[date.Weekday()]
+		if s.end == 0 || !cal.IsBusinessDay(date) {
+			continue
+		}
+		y, m, d := date.Date()
+		from := time.Date(y, m, d, 0, int(s.start/time.Minute), 0, 0, loc)
+		to := time.Date(y, m, d, 0, int(s.end/time.Minute), 0, 0, loc)
+		full := true
+		if start.After(from) {
+			from, full = start, false
+		}
+		if end.Before(to) {
+			to, full = end, false
+		}
+		if !to.After(from) {
+			continue
+		}
+		wt.Elapsed += to.Sub(from)
+		if full {
+			wt.Days++
+		} else {
+			wt.Hours += to.Sub(from)
+		}
+	}
+	return wt, nil
+}
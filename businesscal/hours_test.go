@@ -0,0 +1,132 @@
+package businesscal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff/businesscal"
+)
+
+func mustWorkingHours(t *testing.T) businesscal.WorkingHours {
+	t.Helper()
+	hours, err := businesscal.NewWorkingHours("09:00", "17:30",
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+	if err != nil {
+		t.Fatalf("NewWorkingHours() failed: %v", err)
+	}
+	if hours, err = hours.With("09:00", "13:00", time.Friday); err != nil {
+		t.Fatalf("With() failed: %v", err)
+	}
+	return hours
+}
+
+func TestNewWorkingDiff(t *testing.T) {
+	hours := mustWorkingHours(t)
+	// Monday
+	monday := time.Date(2023, time.November, 20, 0, 0, 0, 0, time.UTC)
+	at := func(days int, clock time.Duration) time.Time {
+		return monday.AddDate(0, 0, days).Add(clock)
+	}
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		cal      businesscal.Calendar
+		expected businesscal.WorkingTime
+	}{
+		{
+			desc:     "within working hours",
+			start:    at(0, 10*time.Hour),
+			end:      at(0, 12*time.Hour+30*time.Minute),
+			cal:      businesscal.Standard,
+			expected: businesscal.WorkingTime{Hours: 150 * time.Minute, Elapsed: 150 * time.Minute},
+		},
+		{
+			desc:     "outside of working hours",
+			start:    at(0, 18*time.Hour),
+			end:      at(1, 8*time.Hour),
+			cal:      businesscal.Standard,
+			expected: businesscal.WorkingTime{},
+		},
+		{
+			desc:     "full and partial days",
+			start:    at(0, 15*time.Hour),
+			end:      at(2, 11*time.Hour),
+			cal:      businesscal.Standard,
+			expected: businesscal.WorkingTime{Days: 1, Hours: 270 * time.Minute, Elapsed: 780 * time.Minute},
+		},
+		{
+			desc:     "over the weekend with short Friday",
+			start:    at(4, 12*time.Hour),
+			end:      at(7, 10*time.Hour),
+			cal:      businesscal.Standard,
+			expected: businesscal.WorkingTime{Hours: 2 * time.Hour, Elapsed: 2 * time.Hour},
+		},
+		{
+			desc:     "full week",
+			start:    at(0, 0),
+			end:      at(7, 0),
+			cal:      businesscal.Standard,
+			expected: businesscal.WorkingTime{Days: 5, Elapsed: 38 * time.Hour},
+		},
+		{
+			desc:     "full week with Thanksgiving Day",
+			start:    at(0, 0),
+			end:      at(7, 0),
+			cal:      businesscal.Standard.WithHolidays(businesscal.USFederal),
+			expected: businesscal.WorkingTime{Days: 4, Elapsed: 29*time.Hour + 30*time.Minute},
+		},
+	}
+	for _, tC := range testCases {
+		got, err := businesscal.NewWorkingDiff(tC.start, tC.end, tC.cal, hours)
+		if err != nil {
+			t.Errorf("NewWorkingDiff() %s failed: %v", tC.desc, err)
+		} else if got != tC.expected {
+			t.Errorf("NewWorkingDiff() %s = %+v, want %+v", tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestNewWorkingDiffLocation(t *testing.T) {
+	hours := mustWorkingHours(t)
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	start := time.Date(2023, time.November, 20, 9, 0, 0, 0, loc)
+	// 17:30 of the start date in the location of the start date
+	end := time.Date(2023, time.November, 20, 7, 30, 0, 0, time.UTC)
+
+	got, err := businesscal.NewWorkingDiff(start, end, businesscal.Standard, hours)
+	if err != nil {
+		t.Fatalf("NewWorkingDiff() failed: %v", err)
+	}
+	if want := (businesscal.WorkingTime{Days: 1, Elapsed: 510 * time.Minute}); got != want {
+		t.Errorf("NewWorkingDiff() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWorkingHoursFails(t *testing.T) {
+	testCases := []struct {
+		start    string
+		end      string
+		expected string
+	}{
+		{start: "9am", end: "17:30", expected: `invalid working time "9am"`},
+		{start: "09:00", end: "25:00", expected: `invalid working time "25:00"`},
+		{start: "17:30", end: "09:00", expected: "working hours 17:30-09:00 end before start"},
+		{start: "09:00", end: "09:00", expected: "working hours 09:00-09:00 end before start"},
+	}
+	for _, tC := range testCases {
+		_, err := businesscal.NewWorkingHours(tC.start, tC.end, time.Monday)
+		if err == nil {
+			t.Errorf("NewWorkingHours(%s, %s) want to fail due to %s", tC.start, tC.end, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("NewWorkingHours(%s, %s) error = %v, want %s", tC.start, tC.end, err, tC.expected)
+		}
+	}
+
+	now := time.Now()
+	_, err := businesscal.NewWorkingDiff(now.Add(time.Hour), now, businesscal.Standard, businesscal.WorkingHours{})
+	if err == nil || err.Error() != "start date is after end date" {
+		t.Errorf("NewWorkingDiff() error = %v, want start date is after end date", err)
+	}
+}
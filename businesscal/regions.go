@@ -0,0 +1,175 @@
+package businesscal
+
+import (
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// These are holiday providers of common regions.
+var (
+	// USFederal defines US federal holidays. Holidays that fall on Saturday
+	// are observed on the preceding Friday, ones that fall on Sunday are
+	// observed on the following Monday.
+	USFederal HolidayProvider = &yearlyHolidays{dates: usFederalDates}
+	// UKBankHolidays defines bank holidays of England and Wales, including
+	// substitute days and one-off bank holidays since 2011.
+	UKBankHolidays HolidayProvider = &yearlyHolidays{dates: ukBankDates}
+	// TARGET defines closing days of the TARGET2 payment system of the
+	// Eurosystem: New Year's Day, Good Friday, Easter Monday, Labour Day,
+	// Christmas Day and December 26.
+	TARGET HolidayProvider = &yearlyHolidays{dates: targetDates}
+)
+
+func usFederalDates(year int) []datediff.Date {
+	dates := []datediff.Date{
+		observed(date(year, time.January, 1)),
+		nthWeekday(year, time.February, time.Monday, 3), // Washington's Birthday
+		lastWeekday(year, time.May, time.Monday),        // Memorial Day
+		observed(date(year, time.July, 4)),
+		nthWeekday(year, time.September, time.Monday, 1), // Labor Day
+		nthWeekday(year, time.October, time.Monday, 2),   // Columbus Day
+		observed(date(year, time.November, 11)),
+		nthWeekday(year, time.November, time.Thursday, 4), // Thanksgiving Day
+		observed(date(year, time.December, 25)),
+	}
+	if year >= 1986 {
+		dates = append(dates, nthWeekday(year, time.January, time.Monday, 3)) // Martin Luther King Jr. Day
+	}
+	if year >= 2021 {
+		dates = append(dates, observed(date(year, time.June, 19))) // Juneteenth
+	}
+	return dates
+}
+
+// ukBankChanges are one-off changes of bank holidays of England and Wales,
+// dates of regular bank holidays that are moved or cancelled are mapped to
+// the actual dates.
+var ukBankChanges = map[int]struct {
+	moved map[datediff.Date]datediff.Date
+	extra []datediff.Date
+}{
+	2011: {extra: []datediff.Date{date(2011, time.April, 29)}}, // Royal Wedding
+	2012: {
+		moved: map[datediff.Date]datediff.Date{date(2012, time.May, 28): date(2012, time.June, 4)},
+		extra: []datediff.Date{date(2012, time.June, 5)}, // Diamond Jubilee
+	},
+	2020: {moved: map[datediff.Date]datediff.Date{date(2020, time.May, 4): date(2020, time.May, 8)}},
+	2022: {
+		moved: map[datediff.Date]datediff.Date{date(2022, time.May, 30): date(2022, time.June, 2)},
+		extra: []datediff.Date{
+			date(2022, time.June, 3),       // Platinum Jubilee
+			date(2022, time.September, 19), // State Funeral of Queen Elizabeth II
+		},
+	},
+	2023: {extra: []datediff.Date{date(2023, time.May, 8)}}, // Coronation of King Charles III
+}
+
+func ukBankDates(year int) []datediff.Date {
+	easter := easterSunday(year)
+	christmas, boxing := date(year, time.December, 25), date(year, time.December, 26)
+	switch weekday(christmas) {
+	case time.Friday:
+		boxing = date(year, time.December, 28)
+	case time.Saturday:
+		christmas, boxing = date(year, time.December, 27), date(year, time.December, 28)
+	case time.Sunday:
+		christmas = date(year, time.December, 27)
+	}
+
+	dates := []datediff.Date{
+		substitute(date(year, time.January, 1)),
+		addDays(easter, -2),                         // Good Friday
+		addDays(easter, 1),                          // Easter Monday
+		nthWeekday(year, time.May, time.Monday, 1),  // Early May bank holiday
+		lastWeekday(year, time.May, time.Monday),    // Spring bank holiday
+		lastWeekday(year, time.August, time.Monday), // Summer bank holiday
+		christmas,
+		boxing,
+	}
+	changes := ukBankChanges[year]
+	for i, d := range dates {
+		if moved, ok := changes.moved[d]; ok {
+			dates[i] = moved
+		}
+	}
+	return append(dates, changes.extra...)
+}
+
+func targetDates(year int) []datediff.Date {
+	easter := easterSunday(year)
+	return []datediff.Date{
+		date(year, time.January, 1),
+		addDays(easter, -2), // Good Friday
+		addDays(easter, 1),  // Easter Monday
+		date(year, time.May, 1),
+		date(year, time.December, 25),
+		date(year, time.December, 26),
+	}
+}
+
+func date(year int, month time.Month, day int) datediff.Date {
+	return datediff.Date{Year: year, Month: month, Day: day}
+}
+
+func weekday(d datediff.Date) time.Weekday {
+	return d.Time().Weekday()
+}
+
+func addDays(d datediff.Date, n int) datediff.Date {
+	return datediff.DateOf(d.Time().AddDate(0, 0, n))
+}
+
+// observed returns the date the US federal holiday is observed on.
+func observed(d datediff.Date) datediff.Date {
+	switch weekday(d) {
+	case time.Saturday:
+		return addDays(d, -1)
+	case time.Sunday:
+		return addDays(d, 1)
+	}
+	return d
+}
+
+// substitute returns the substitute day of the UK bank holiday that falls on
+// the weekend.
+func substitute(d datediff.Date) datediff.Date {
+	switch weekday(d) {
+	case time.Saturday:
+		return addDays(d, 2)
+	case time.Sunday:
+		return addDays(d, 1)
+	}
+	return d
+}
+
+// nthWeekday returns the n-th day of the week of the month.
+func nthWeekday(year int, month time.Month, wd time.Weekday, n int) datediff.Date {
+	first := date(year, month, 1)
+	offset := (int(wd) - int(weekday(first)) + daysInWeek) % daysInWeek
+	return date(year, month, 1+offset+(n-1)*daysInWeek)
+}
+
+// lastWeekday returns the last day of the week of the month.
+func lastWeekday(year int, month time.Month, wd time.Weekday) datediff.Date {
+	last := datediff.DateOf(date(year, month+1, 1).Time().AddDate(0, 0, -1))
+	offset := (int(weekday(last)) - int(wd) + daysInWeek) % daysInWeek
+	return addDays(last, -offset)
+}
+
+// easterSunday returns the date of Easter Sunday of the Gregorian calendar
+// calculated by the anonymous Gregorian algorithm.
+func easterSunday(year int) datediff.Date {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
@@ -0,0 +1,157 @@
+package datediff
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Calendar defines date arithmetic used to calculate dates difference. Dates
+// difference is calculated by the same cascade algorithm for any calendar:
+// full years are added to the start date first, then full months, weeks and
+// days, while the result is not after the end date. Alternative date systems
+// and custom business rules can be plugged in with WithCalendar, or
+// registered by name with RegisterCalendar.
+//
+// The cascade algorithm relies on the following properties of the calendar,
+// that are verified by the calendartest package:
+//
+//	adding 0 years, months or days returns the same date
+//	adding years, months and days keeps the location and the time of the day
+//	adding more years, months or days never returns an earlier date, and
+//	adding 1 year, month or day returns a later date
+//	adding days is associative, adding n days and then m days is the same
+//	as adding n+m days
+//	Compare is a total order consistent with time.Time
+//
+// Calendar implementations must be safe for concurrent use.
+type Calendar interface {
+	// AddYears returns the date advanced by n years.
+	AddYears(t time.Time, n int) time.Time
+	// AddMonths returns the date advanced by n months.
+	AddMonths(t time.Time, n int) time.Time
+	// AddDays returns the date advanced by n days.
+	AddDays(t time.Time, n int) time.Time
+	// Compare returns -1 when a is before b, 1 when a is after b, and 0 when
+	// they are equal.
+	Compare(a, b time.Time) int
+}
+
+// GregorianCalendar is the proleptic Gregorian calendar of time.Time, it's
+// the default calendar. Overflowed days are normalized as time.Time.AddDate
+// does, i.e January 31 plus 1 month is March 2 or 3.
+type GregorianCalendar struct{}
+
+// AddYears implements Calendar interface.
+func (GregorianCalendar) AddYears(t time.Time, n int) time.Time {
+	return t.AddDate(n, 0, 0)
+}
+
+// AddMonths implements Calendar interface.
+func (GregorianCalendar) AddMonths(t time.Time, n int) time.Time {
+	return t.AddDate(0, n, 0)
+}
+
+// AddDays implements Calendar interface.
+func (GregorianCalendar) AddDays(t time.Time, n int) time.Time {
+	return t.AddDate(0, 0, n)
+}
+
+// Compare implements Calendar interface.
+func (GregorianCalendar) Compare(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// WithCalendar sets the calendar used to calculate dates difference. The
+// Gregorian calendar is used by default.
+func WithCalendar(c Calendar) Option {
+	return func(o *options) {
+		o.calendar = c
+	}
+}
+
+var (
+	calendarsMu sync.RWMutex
+	calendars   = map[string]Calendar{
+		"chinese":   ChineseCalendar{},
+		"ethiopian": EthiopianCalendar{},
+		"gregorian": GregorianCalendar{},
+		"persian":   PersianCalendar{},
+		"thai":      ThaiCalendar{},
+	}
+)
+
+// RegisterCalendar makes the calendar available by the name, i.e
+// RegisterCalendar("hebrew", impl). Built-in calendars are registered as
+// "gregorian", "chinese", "persian", "ethiopian" and "thai".
+//
+// RegisterCalendar panics when the calendar is nil, or the name is already
+// registered.
+func RegisterCalendar(name string, c Calendar) {
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	if c == nil {
+		panic("datediff: RegisterCalendar calendar is nil")
+	}
+	if _, ok := calendars[name]; ok {
+		panic(fmt.Sprintf("datediff: RegisterCalendar called twice for calendar %q", name))
+	}
+	calendars[name] = c
+}
+
+// LookupCalendar returns the registered calendar by its name.
+func LookupCalendar(name string) (Calendar, error) {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	if c, ok := calendars[name]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("unknown calendar %q", name)
+}
+
+// Calendars returns names of the registered calendars.
+func Calendars() []string {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	names := make([]string, 0, len(calendars))
+	for name := range calendars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// monthsCalendar is the calendar with the fixed number of months in a year,
+// its dates are converted to and from Julian day numbers.
+type monthsCalendar interface {
+	// monthsInYear returns the number of months in a year.
+	monthsInYear() int
+	// jdn returns the Julian day number of the date, the day can overflow
+	// the month.
+	jdn(year, month, day int) int64
+	// date returns the date of the Julian day number.
+	date(jdn int64) (year, month, day int)
+}
+
+// addMonthsCalendar returns the date advanced by years and months of the
+// calendar keeping the time of the day. The day overflow is normalized, as
+// time.Time.AddDate does.
+func addMonthsCalendar(c monthsCalendar, t time.Time, years, months int) time.Time {
+	from := jdnOf(t)
+	year, month, day := c.date(from)
+	n := c.monthsInYear()
+	month += months - 1
+	year += years + month/n
+	if month %= n; month < 0 {
+		month += n
+		year--
+	}
+	return t.AddDate(0, 0, int(c.jdn(year, month+1, day)-from))
+}
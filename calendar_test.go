@@ -0,0 +1,112 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// fixedCalendar is the calendar with 30 days months and 360 days years.
+type fixedCalendar struct {
+	datediff.GregorianCalendar
+}
+
+func (c fixedCalendar) AddYears(t time.Time, n int) time.Time {
+	return c.AddDays(t, 360*n)
+}
+
+func (c fixedCalendar) AddMonths(t time.Time, n int) time.Time {
+	return c.AddDays(t, 30*n)
+}
+
+func TestGregorianCalendar(t *testing.T) {
+	c := datediff.GregorianCalendar{}
+	date := time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	if got, want := c.AddYears(date, 1), date.AddDate(1, 0, 0); !got.Equal(want) {
+		t.Errorf("AddYears() = %s, want %s", got, want)
+	}
+	if got, want := c.AddMonths(date, 1), date.AddDate(0, 1, 0); !got.Equal(want) {
+		t.Errorf("AddMonths() = %s, want %s", got, want)
+	}
+	if got, want := c.AddDays(date, -1), date.AddDate(0, 0, -1); !got.Equal(want) {
+		t.Errorf("AddDays() = %s, want %s", got, want)
+	}
+
+	testCases := []struct {
+		a, b     time.Time
+		expected int
+	}{
+		{a: date, b: date.AddDate(0, 0, 1), expected: -1},
+		{a: date.AddDate(0, 0, 1), b: date, expected: 1},
+		{a: date, b: date.In(time.FixedZone("UTC+10", 10*60*60)), expected: 0},
+	}
+	for _, tC := range testCases {
+		if got := c.Compare(tC.a, tC.b); got != tC.expected {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tC.a, tC.b, got, tC.expected)
+		}
+	}
+}
+
+func TestWithCalendar(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		format   string
+		opts     []datediff.Option
+		expected string
+	}{
+		{format: "%Y %M %D", expected: "1 year 2 months"},
+		{format: "%Y %M %D", opts: []datediff.Option{datediff.WithCalendar(fixedCalendar{})}, expected: "1 year 2 months 5 days"},
+		{
+			format:   "%Y %M",
+			opts:     []datediff.Option{datediff.WithCalendar(fixedCalendar{}), datediff.WithRounding(datediff.RoundingCeil)},
+			expected: "1 year 3 months",
+		},
+	}
+	for _, tC := range testCases {
+		diff, err := datediff.NewDiff(start, end, tC.format, tC.opts...)
+		if err != nil {
+			t.Errorf("NewDiff() failed: %v", err)
+		} else if got := diff.String(); got != tC.expected {
+			t.Errorf("NewDiff() = %s, want %s", got, tC.expected)
+		}
+	}
+}
+
+func TestRegisterCalendar(t *testing.T) {
+	// the calendar is registered once per process, i.e with -count flag
+	if _, err := datediff.LookupCalendar("fixed"); err != nil {
+		datediff.RegisterCalendar("fixed", fixedCalendar{})
+	}
+
+	c, err := datediff.LookupCalendar("fixed")
+	if err != nil {
+		t.Fatalf("LookupCalendar() failed: %v", err)
+	}
+	if _, ok := c.(fixedCalendar); !ok {
+		t.Errorf("LookupCalendar() = %T, want fixedCalendar", c)
+	}
+	found := false
+	for _, name := range datediff.Calendars() {
+		found = found || name == "fixed"
+	}
+	if !found {
+		t.Errorf("Calendars() = %q, want to contain fixed", datediff.Calendars())
+	}
+
+	if _, err := datediff.LookupCalendar("hebrew"); err == nil {
+		t.Errorf("want to fail due to unknown calendar")
+	} else if want := `unknown calendar "hebrew"`; err.Error() != want {
+		t.Errorf("LookupCalendar() error = %v, want %s", err, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("want to panic due to duplicate calendar")
+		}
+	}()
+	datediff.RegisterCalendar("gregorian", fixedCalendar{})
+}
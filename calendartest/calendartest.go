@@ -0,0 +1,206 @@
+// Package calendartest implements the conformance test of datediff calendars.
+//
+// Calendar backends can be verified in a regular Go test:
+//
+//	func TestCalendar(t *testing.T) {
+//		calendartest.Run(t, hebrew.Calendar{})
+//	}
+package calendartest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// Dates are the dates the calendar is tested with. They cover leap days,
+// ends of months and years of the Gregorian calendar, and the time of the
+// day other than midnight.
+var Dates = []time.Time{
+	time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC),
+	time.Date(2000, time.February, 29, 12, 30, 0, 0, time.UTC),
+	time.Date(2001, time.January, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(2004, time.March, 20, 6, 0, 0, 0, time.UTC),
+	time.Date(2009, time.September, 11, 0, 0, 0, 0, time.UTC),
+	time.Date(2012, time.January, 23, 18, 0, 0, 0, time.UTC),
+	time.Date(2019, time.August, 31, 0, 0, 0, 0, time.UTC),
+	time.Date(2023, time.March, 21, 1, 0, 0, 0, time.UTC),
+}
+
+// Steps are the numbers of years, months and days the dates are advanced by.
+var Steps = []int{-13, -12, -1, 0, 1, 2, 11, 12, 13, 30, 365}
+
+// locations are the locations of the tested dates, other than UTC.
+var locations = []*time.Location{
+	time.FixedZone("UTC+5:45", (5*60+45)*60),
+	time.FixedZone("UTC-10", -10*60*60),
+}
+
+type addFunc struct {
+	name string
+	add  func(t time.Time, n int) time.Time
+}
+
+// Run runs the conformance test of the calendar. It verifies that:
+//
+//	adding 0 years, months or days returns the same date
+//	adding years, months and days keeps the location and the time of the day
+//	adding more years, months or days never returns an earlier date, and
+//	adding 1 year, month or day returns a later date
+//	adding days is associative
+//	Compare is a total order consistent with time.Time
+//	dates differences of modes with years have maximal time units in the
+//	order of the cascade algorithm
+//	adding time units of dates differences of every mode to the start date
+//	doesn't pass the end date
+func Run(t *testing.T, c datediff.Calendar) {
+	t.Helper()
+
+	dates := Dates
+	for _, loc := range locations {
+		for _, d := range Dates {
+			year, month, day := d.Date()
+			hour, min, sec := d.Clock()
+			dates = append(dates, time.Date(year, month, day, hour, min, sec, 0, loc))
+		}
+	}
+	adds := []addFunc{
+		{name: "AddYears", add: c.AddYears},
+		{name: "AddMonths", add: c.AddMonths},
+		{name: "AddDays", add: c.AddDays},
+	}
+
+	t.Run("zero", func(t *testing.T) {
+		for _, a := range adds {
+			for _, d := range dates {
+				if got := a.add(d, 0); !got.Equal(d) {
+					t.Errorf("%s(%s, 0) = %s, want %s", a.name, d, got, d)
+				}
+			}
+		}
+	})
+
+	t.Run("time of day", func(t *testing.T) {
+		for _, a := range adds {
+			for _, d := range dates {
+				for _, n := range Steps {
+					got := a.add(d, n)
+					if got.Location() != d.Location() {
+						t.Errorf("%s(%s, %d) location = %s, want %s", a.name, d, n, got.Location(), d.Location())
+					}
+					if h, m, s := got.Clock(); h != d.Hour() || m != d.Minute() || s != d.Second() {
+						t.Errorf("%s(%s, %d) = %s, want the same time of the day", a.name, d, n, got)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("monotonic", func(t *testing.T) {
+		for _, a := range adds {
+			for _, d := range dates {
+				if got := a.add(d, 1); c.Compare(got, d) <= 0 {
+					t.Errorf("%s(%s, 1) = %s, want later date", a.name, d, got)
+				}
+				if got := a.add(d, -1); c.Compare(got, d) >= 0 {
+					t.Errorf("%s(%s, -1) = %s, want earlier date", a.name, d, got)
+				}
+				for i := 1; i < len(Steps); i++ {
+					prev, next := a.add(d, Steps[i-1]), a.add(d, Steps[i])
+					if c.Compare(prev, next) > 0 {
+						t.Errorf("%s(%s, %d) = %s is after %s(%s, %d) = %s",
+							a.name, d, Steps[i-1], prev, a.name, d, Steps[i], next)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("days", func(t *testing.T) {
+		for _, d := range dates {
+			for _, n := range Steps {
+				for _, m := range Steps {
+					want := c.AddDays(d, n+m)
+					if got := c.AddDays(c.AddDays(d, n), m); !got.Equal(want) {
+						t.Errorf("AddDays(AddDays(%s, %d), %d) = %s, want %s", d, n, m, got, want)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		for _, a := range dates {
+			for _, b := range dates {
+				want := 0
+				if a.Before(b) {
+					want = -1
+				} else if a.After(b) {
+					want = 1
+				}
+				if got := c.Compare(a, b); got != want {
+					t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+				}
+			}
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		for i, start := range dates {
+			for _, end := range dates[i:] {
+				if end.Location() != start.Location() || end.Before(start) {
+					continue
+				}
+				for m := 1; m < 16; m++ {
+					checkDiff(t, c, start, end, datediff.DiffMode(m<<4))
+				}
+			}
+		}
+	})
+}
+
+// checkDiff verifies that the time units of dates difference are maximal in
+// the order of the cascade algorithm. Without years the Gregorian calendar
+// counts months of full years, i.e there are 12 months from February 29 to
+// March 1, so only the end date is verified for such modes.
+func checkDiff(t *testing.T, c datediff.Calendar, start, end time.Time, mode datediff.DiffMode) {
+	t.Helper()
+
+	d, err := datediff.NewDiffWithMode(start, end, mode, datediff.WithCalendar(c))
+	if err != nil {
+		t.Errorf("NewDiffWithMode(%s, %s, %s) failed: %v", start, end, mode, err)
+		return
+	}
+
+	steps := []struct {
+		mode datediff.DiffMode
+		n    int
+		add  func(t time.Time, n int) time.Time
+	}{
+		{mode: datediff.ModeYears, n: d.Years, add: c.AddYears},
+		{mode: datediff.ModeMonths, n: d.Months, add: c.AddMonths},
+		{mode: datediff.ModeWeeks, n: d.Weeks, add: func(t time.Time, n int) time.Time { return c.AddDays(t, 7*n) }},
+		{mode: datediff.ModeDays, n: d.Days, add: c.AddDays},
+	}
+	from := start
+	for _, s := range steps {
+		if mode&s.mode == 0 {
+			if s.n != 0 {
+				t.Errorf("NewDiffWithMode(%s, %s, %s) = %v, want no %s", start, end, mode, d, s.mode)
+			}
+			continue
+		}
+		if s.n < 0 {
+			t.Errorf("NewDiffWithMode(%s, %s, %s) = %v, want non-negative %s", start, end, mode, d, s.mode)
+			return
+		}
+		if next := s.add(from, s.n+1); mode&datediff.ModeYears != 0 && c.Compare(next, end) <= 0 {
+			t.Errorf("NewDiffWithMode(%s, %s, %s) = %v, want more %s", start, end, mode, d, s.mode)
+		}
+		from = s.add(from, s.n)
+	}
+	if c.Compare(from, end) > 0 {
+		t.Errorf("NewDiffWithMode(%s, %s, %s) = %v is after the end date", start, end, mode, d)
+	}
+}
@@ -0,0 +1,20 @@
+package calendartest_test
+
+import (
+	"testing"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/calendartest"
+)
+
+func TestBuiltinCalendars(t *testing.T) {
+	for _, name := range datediff.Calendars() {
+		c, err := datediff.LookupCalendar(name)
+		if err != nil {
+			t.Fatalf("LookupCalendar(%s) failed: %v", name, err)
+		}
+		t.Run(name, func(t *testing.T) {
+			calendartest.Run(t, c)
+		})
+	}
+}
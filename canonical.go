@@ -0,0 +1,185 @@
+package datediff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// canonicalVersion is the version prefix of the canonical string.
+const canonicalVersion = "v1"
+
+// canonicalKeys are keys of time units values in the canonical string.
+var canonicalKeys = []struct {
+	key  string
+	mode DiffMode
+}{
+	{key: "y", mode: ModeYears},
+	{key: "m", mode: ModeMonths},
+	{key: "w", mode: ModeWeeks},
+	{key: "d", mode: ModeDays},
+}
+
+// Canonical returns the canonical string of dates difference, i.e
+// "v1;mode=YMD;y=2;m=3;d=4;fmt=%Y %M %D". The string starts with the version
+// followed by semicolon separated fields:
+//
+//	mode - ISO 8601 designators of the mode time units (Y, M, W, D)
+//	y, m, w, d - values of time units of the mode, or other non-zero values
+//	start, end - start and end dates in RFC 3339 format, when dates
+//	  difference was calculated from dates
+//	incl - 1 when the end date is included, see WithInclusiveEnd
+//	fmt - the format of dates difference, it's always the last field
+//
+// The canonical string keeps all fields of dates difference, it's used by
+// MarshalText and is accepted by all unmarshalers.
+func (d Diff) Canonical() string {
+	var b strings.Builder
+	b.WriteString(canonicalVersion)
+	b.WriteString(";mode=")
+	for _, u := range isoDesignators {
+		if d.mode&u.mode != 0 {
+			b.WriteByte(u.designator)
+		}
+	}
+	for _, k := range canonicalKeys {
+		n := d.value(k.mode)
+		if d.mode&k.mode == 0 && n == 0 {
+			continue
+		}
+		b.WriteByte(';')
+		b.WriteString(k.key)
+		b.WriteByte('=')
+		b.WriteString(strconv.Itoa(n))
+	}
+	for _, t := range []struct {
+		key  string
+		date time.Time
+	}{
+		{key: "start", date: d.start},
+		{key: "end", date: d.end},
+	} {
+		if t.date.IsZero() {
+			continue
+		}
+		b.WriteByte(';')
+		b.WriteString(t.key)
+		b.WriteByte('=')
+		b.WriteString(t.date.Format(time.RFC3339Nano))
+	}
+	if d.inclusive {
+		b.WriteString(";incl=1")
+	}
+	if d.rawFormat != "" {
+		b.WriteString(";fmt=")
+		b.WriteString(d.rawFormat)
+	}
+	return b.String()
+}
+
+// ParseCanonical parses the canonical string produced by Diff.Canonical.
+func ParseCanonical(s string) (Diff, error) {
+	version, rest, _ := cut(s, ";")
+	if version != canonicalVersion {
+		if strings.HasPrefix(version, "v") {
+			return Diff{}, fmt.Errorf("unsupported canonical version %q", version)
+		}
+		return Diff{}, fmt.Errorf("invalid canonical dates difference %q", s)
+	}
+
+	var (
+		diff      Diff
+		mode      DiffMode
+		modeFound bool
+		seen      DiffMode
+	)
+	for rest != "" {
+		var field string
+		if strings.HasPrefix(rest, "fmt=") {
+			// format can contain semicolons, it takes the rest of the string
+			field, rest = rest, ""
+		} else {
+			field, rest, _ = cut(rest, ";")
+		}
+		key, value, ok := cut(field, "=")
+		if !ok {
+			return Diff{}, fmt.Errorf("invalid canonical dates difference %q", s)
+		}
+
+		switch key {
+		case "mode":
+			if modeFound {
+				return Diff{}, fmt.Errorf("canonical dates difference %q has repeated field mode", s)
+			}
+			modeFound = true
+			for i := 0; i < len(value); i++ {
+				j := 0
+				for j < len(isoDesignators) && isoDesignators[j].designator != value[i] {
+					j++
+				}
+				if j == len(isoDesignators) || mode&isoDesignators[j].mode != 0 {
+					return Diff{}, fmt.Errorf("canonical dates difference %q has invalid mode %q", s, value)
+				}
+				mode |= isoDesignators[j].mode
+			}
+		case "fmt":
+			diff.rawFormat = value
+		case "incl":
+			if value != "1" {
+				return Diff{}, fmt.Errorf("canonical dates difference %q has invalid value of %s", s, key)
+			}
+			diff.inclusive = true
+		case "start", "end":
+			date, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return Diff{}, fmt.Errorf("canonical dates difference %q has invalid value of %s", s, key)
+			}
+			if key == "start" {
+				diff.start = date
+			} else {
+				diff.end = date
+			}
+		default:
+			j := 0
+			for j < len(canonicalKeys) && canonicalKeys[j].key != key {
+				j++
+			}
+			if j == len(canonicalKeys) {
+				return Diff{}, fmt.Errorf("canonical dates difference %q has unknown field %s", s, key)
+			}
+			unit := canonicalKeys[j].mode
+			if seen&unit != 0 {
+				return Diff{}, fmt.Errorf("canonical dates difference %q has repeated field %s", s, key)
+			}
+			seen |= unit
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return Diff{}, fmt.Errorf("canonical dates difference %q has invalid value of %s", s, key)
+			}
+			diff.setValue(unit, n)
+		}
+	}
+	if !modeFound {
+		return Diff{}, fmt.Errorf("canonical dates difference %q has no mode", s)
+	}
+	if diff.start.After(diff.end) && !diff.end.IsZero() {
+		return Diff{}, ErrStartAfterEnd
+	}
+
+	mode, err := decodeMode(diff.rawFormat, mode)
+	if err != nil {
+		return Diff{}, err
+	}
+	diff.mode = mode
+	return diff, nil
+}
+
+// cut slices s around the first instance of sep, it's strings.Cut that is
+// not available in Go 1.17.
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
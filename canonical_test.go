@@ -0,0 +1,114 @@
+package datediff_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/antklim/datediff"
+)
+
+func TestCanonical(t *testing.T) {
+	testCases, err := loadDatediffRecordsForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tC := range testCases {
+		desc := fmt.Sprintf("NewDiff(%s, %s, %s)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.format)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiff(tC.start, tC.end, tC.format)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testCanonicalRoundTrip(t, diff)
+		})
+
+		desc = fmt.Sprintf("NewDiffWithMode(%s, %s, %d)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.mode)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiffWithMode(tC.start, tC.end, tC.mode)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testCanonicalRoundTrip(t, diff)
+		})
+	}
+
+	testCanonicalRoundTrip(t, datediff.Diff{Years: -2, Weeks: 3})
+}
+
+func testCanonicalRoundTrip(t *testing.T, diff datediff.Diff) {
+	t.Helper()
+	s := diff.Canonical()
+	got, err := datediff.ParseCanonical(s)
+	if err != nil {
+		t.Fatalf("ParseCanonical(%s) failed: %v", s, err)
+	}
+	if !got.Equal(diff) {
+		t.Errorf("ParseCanonical(%s) = %#v, want %#v", s, got, diff)
+	}
+	if got.StringWithZeros() != diff.StringWithZeros() {
+		t.Errorf("ParseCanonical(%s) StringWithZeros() = %s, want %s", s, got.StringWithZeros(), diff.StringWithZeros())
+	}
+	if !got.Start().Equal(diff.Start()) || !got.End().Equal(diff.End()) {
+		t.Errorf("ParseCanonical(%s) dates = %s - %s, want %s - %s", s, got.Start(), got.End(), diff.Start(), diff.End())
+	}
+	if got.Canonical() != s {
+		t.Errorf("ParseCanonical(%s) Canonical() = %s, want %s", s, got.Canonical(), s)
+	}
+}
+
+func TestParseCanonical(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected string
+	}{
+		{s: "v1;mode=YMD;y=2;m=3;d=4;fmt=%Y %M %D", expected: "2 years 3 months 4 days"},
+		{s: "v1;mode=YD;y=2;d=4;fmt=%Y; %D", expected: "2 years; 4 days"},
+		{s: "v1;mode=;m=2;fmt=%M and %W", expected: "2 months and 0 weeks"},
+		{s: "v1;mode=WM;w=1;m=2", expected: "2 months 1 week"},
+		{s: "v1;mode=Y;y=1;start=2000-01-01T00:00:00Z;end=2001-02-01T00:00:00+10:00", expected: "1 year"},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseCanonical(tC.s)
+		if err != nil {
+			t.Errorf("ParseCanonical(%s) failed: %v", tC.s, err)
+		} else if got.StringWithZeros() != tC.expected {
+			t.Errorf("ParseCanonical(%s) StringWithZeros() = %s, want %s", tC.s, got.StringWithZeros(), tC.expected)
+		}
+	}
+}
+
+func TestParseCanonicalFails(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected string
+	}{
+		{s: "", expected: `invalid canonical dates difference ""`},
+		{s: "P2Y", expected: `invalid canonical dates difference "P2Y"`},
+		{s: "v2;mode=Y;y=2", expected: `unsupported canonical version "v2"`},
+		{s: "v1;y=2", expected: `canonical dates difference "v1;y=2" has no mode`},
+		{s: "v1;mode=Y;y", expected: `invalid canonical dates difference "v1;mode=Y;y"`},
+		{s: "v1;mode=H", expected: `canonical dates difference "v1;mode=H" has invalid mode "H"`},
+		{s: "v1;mode=YY", expected: `canonical dates difference "v1;mode=YY" has invalid mode "YY"`},
+		{s: "v1;mode=Y;mode=Y", expected: `canonical dates difference "v1;mode=Y;mode=Y" has repeated field mode`},
+		{s: "v1;mode=Y;h=2", expected: `canonical dates difference "v1;mode=Y;h=2" has unknown field h`},
+		{s: "v1;mode=Y;y=1;y=2", expected: `canonical dates difference "v1;mode=Y;y=1;y=2" has repeated field y`},
+		{s: "v1;mode=Y;y=two", expected: `canonical dates difference "v1;mode=Y;y=two" has invalid value of y`},
+		{s: "v1;mode=Y;start=2000-01-01", expected: `canonical dates difference "v1;mode=Y;start=2000-01-01" has invalid value of start`},
+		{s: "v1;mode=Y;incl=true", expected: `canonical dates difference "v1;mode=Y;incl=true" has invalid value of incl`},
+		{
+			s:        "v1;mode=Y;start=2001-01-01T00:00:00Z;end=2000-01-01T00:00:00Z",
+			expected: "start date is after end date",
+		},
+		{s: "v1;mode=M;fmt=%Y", expected: `mode 64 does not match format "%Y"`},
+		{s: "v1;mode=Y;fmt=%X", expected: `format "%X" has unknown verb X`},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseCanonical(tC.s)
+		if err == nil {
+			t.Errorf("ParseCanonical(%s) = %#v, want to fail due to %s", tC.s, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParseCanonical(%s) failed: %v, want to fail due to %s", tC.s, err, tC.expected)
+		}
+	}
+}
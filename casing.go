@@ -0,0 +1,70 @@
+package datediff
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Casing defines letter case of the formatted dates difference.
+type Casing uint8
+
+// These are supported casings. Casings follow rules of the locale language,
+// i.e Turkish "i" is upper cased to "İ".
+const (
+	// CasingNone keeps letter case of the locale patterns and format.
+	CasingNone Casing = iota
+	// CasingLower converts formatted dates difference to lower case. In
+	// languages with capitalized nouns, i.e German, letter case is kept.
+	CasingLower
+	// CasingUpper converts formatted dates difference to upper case.
+	CasingUpper
+	// CasingTitle converts first letters of all words to upper case.
+	CasingTitle
+	// CasingSentence converts the first letter to upper case, the rest is
+	// converted to lower case unless the language capitalizes nouns.
+	CasingSentence
+)
+
+// apply converts letter case of s according to the locale language rules.
+func (c Casing) apply(s string, l *Locale) string {
+	if c == CasingNone || s == "" {
+		return s
+	}
+
+	tag := language.Make(l.Name)
+	switch c {
+	case CasingLower:
+		if l.CapitalizedNouns {
+			return s
+		}
+		return cases.Lower(tag).String(s)
+	case CasingUpper:
+		return cases.Upper(tag).String(s)
+	case CasingTitle:
+		return cases.Title(tag).String(s)
+	case CasingSentence:
+		if !l.CapitalizedNouns {
+			s = cases.Lower(tag).String(s)
+		}
+		return upperFirst(s, tag)
+	}
+	return s
+}
+
+// upperFirst converts the first letter of s to upper case when s starts with
+// a letter.
+func upperFirst(s string, tag language.Tag) string {
+	r, _ := utf8.DecodeRuneInString(s)
+	if !unicode.IsLetter(r) {
+		return s
+	}
+	end := strings.IndexFunc(s, unicode.IsSpace)
+	if end < 0 {
+		end = len(s)
+	}
+	return cases.Title(tag, cases.NoLower).String(s[:end]) + s[end:]
+}
@@ -0,0 +1,65 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestFormatterCasing(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2002, time.April, 24, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiff(start, end, "%Y %W")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+
+	testCases := []struct {
+		locale   string
+		casing   datediff.Casing
+		format   string
+		ago      bool
+		expected string
+	}{
+		{locale: "en", casing: datediff.CasingNone, expected: "2 years 1 week"},
+		{locale: "en", casing: datediff.CasingUpper, expected: "2 YEARS 1 WEEK"},
+		{locale: "en", casing: datediff.CasingTitle, expected: "2 Years 1 Week"},
+		{locale: "en", casing: datediff.CasingSentence, ago: true, expected: "2 years 1 week ago"},
+		{locale: "en", casing: datediff.CasingSentence, format: "Waited %Y", expected: "Waited 2 years"},
+		{locale: "en", casing: datediff.CasingLower, format: "Waited %Y", expected: "waited 2 years"},
+		{locale: "de", casing: datediff.CasingLower, ago: true, expected: "vor 2 Jahren 1 Woche"},
+		{locale: "de", casing: datediff.CasingSentence, ago: true, expected: "Vor 2 Jahren 1 Woche"},
+		{locale: "de", casing: datediff.CasingUpper, expected: "2 JAHRE 1 WOCHE"},
+		{locale: "es", casing: datediff.CasingSentence, ago: true, expected: "Hace 2 años 1 semana"},
+		{locale: "tr", casing: datediff.CasingUpper, format: "%Y içinde", expected: "2 YIL İÇİNDE"},
+		{locale: "tr", casing: datediff.CasingSentence, format: "ilk %Y", expected: "İlk 2 yıl"},
+		{locale: "tr", casing: datediff.CasingLower, format: "İLK %Y", expected: "ilk 2 yıl"},
+		{locale: "en", casing: datediff.CasingUpper, format: "%Y içinde", expected: "2 YEARS IÇINDE"},
+	}
+	for _, tC := range testCases {
+		l, err := datediff.LookupLocale(tC.locale)
+		if err != nil {
+			t.Errorf("LookupLocale(%s) failed: %v", tC.locale, err)
+			continue
+		}
+		f := datediff.Formatter{Locale: l, Casing: tC.casing}
+
+		var got string
+		switch {
+		case tC.ago:
+			got = f.Ago(diff)
+		case tC.format != "":
+			got, err = f.Format(diff, tC.format)
+			if err != nil {
+				t.Errorf("%s Format(%s) failed: %v", tC.locale, tC.format, err)
+				continue
+			}
+		default:
+			got = f.String(diff)
+		}
+		if got != tC.expected {
+			t.Errorf("%s casing %d = %s, want %s", tC.locale, tC.casing, got, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package datediff
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	synodicMonth  = 29.530588861
+	tropicalYear  = 365.242189
+	chinaUTCHours = 8
+	// jdnOffset converts civil day numbers to Julian day numbers.
+	jdnOffset = 1721120
+)
+
+// ChineseDate is the date of the Chinese lunisolar calendar. Lunar year is
+// numbered by the Gregorian year of its New Year, i.e the lunar year that
+// starts on January 22, 2023 is 2023.
+type ChineseDate struct {
+	Year  int
+	Month int  // month of the lunar year, from 1 to 12
+	Leap  bool // month is the leap month that follows the month with the same number
+	Day   int  // day of the month, from 1 to 30
+}
+
+// ChineseDateOf returns the Chinese calendar date of the date in its
+// location.
+func ChineseDateOf(t time.Time) ChineseDate {
+	m, day := chineseMonthOf(jdnOf(t))
+	return ChineseDate{Year: m.year, Month: m.number, Leap: m.leap, Day: day}
+}
+
+// ChineseCalendar is the Chinese lunisolar calendar. Months start on the day
+// of the new moon in China Standard Time, the month that contains the winter
+// solstice is the 11th month, and the first month without the major solar
+// term is the leap month in years of 13 months. New moons and solar terms
+// are calculated astronomically, so the calendar is accurate for years from
+// 1900 to 2100, except for rare cases when the new moon is within minutes of
+// midnight.
+//
+// Years are added keeping the month number, the leap month is replaced by
+// the regular month with the same number when the year does not have it.
+// Months are added as lunations. The day overflow is normalized, i.e the
+// 30th day of the month of 29 days is the first day of the next month.
+type ChineseCalendar struct{}
+
+// AddYears implements Calendar interface.
+func (ChineseCalendar) AddYears(t time.Time, n int) time.Time {
+	jdn := jdnOf(t)
+	m, day := chineseMonthOf(jdn)
+	start := chineseMonthStart(m.year+n, m.number, m.leap)
+	return t.AddDate(0, 0, int(start+int64(day)-1-jdn))
+}
+
+// AddMonths implements Calendar interface.
+func (ChineseCalendar) AddMonths(t time.Time, n int) time.Time {
+	jdn := jdnOf(t)
+	m, day := chineseMonthOf(jdn)
+	start := newMoonDay(m.k + int64(n))
+	return t.AddDate(0, 0, int(start+int64(day)-1-jdn))
+}
+
+// AddDays implements Calendar interface.
+func (ChineseCalendar) AddDays(t time.Time, n int) time.Time {
+	return t.AddDate(0, 0, n)
+}
+
+// Compare implements Calendar interface.
+func (ChineseCalendar) Compare(a, b time.Time) int {
+	return GregorianCalendar{}.Compare(a, b)
+}
+
+// chineseMonth is the month of the Chinese calendar.
+type chineseMonth struct {
+	k      int64 // lunation number of the new moon the month starts on
+	year   int
+	number int
+	leap   bool
+}
+
+// suis caches months of the sui, the period from the 11th month to the next
+// 11th month, by the Gregorian year the sui ends in.
+var suis sync.Map
+
+// chineseSui returns months of the sui that ends in the Gregorian year.
+func chineseSui(year int) []chineseMonth {
+	if months, ok := suis.Load(year); ok {
+		return months.([]chineseMonth)
+	}
+	from := newMoonOnOrBefore(winterSolstice(year - 1))
+	to := newMoonOnOrBefore(winterSolstice(year))
+	leap := to-from == 13
+
+	months := make([]chineseMonth, 0, to-from)
+	number, lunarYear := 10, year-1
+	for k := from; k < to; k++ {
+		m := chineseMonth{k: k}
+		if leap && !hasMajorTerm(k) {
+			m.leap = true
+			leap = false
+		} else {
+			number = number%monthsInYear + 1
+		}
+		if number == 1 && !m.leap {
+			lunarYear = year
+		}
+		m.year, m.number = lunarYear, number
+		months = append(months, m)
+	}
+	suis.Store(year, months)
+	return months
+}
+
+// chineseMonthOf returns the month of the Chinese calendar and the day of
+// the month of the Julian day number.
+func chineseMonthOf(jdn int64) (chineseMonth, int) {
+	year, _, _ := civilFromDays(jdn - jdnOffset)
+	if jdn >= newMoonDay(newMoonOnOrBefore(winterSolstice(year))) {
+		year++
+	}
+	months := chineseSui(year)
+	m := months[0]
+	for _, next := range months[1:] {
+		if newMoonDay(next.k) > jdn {
+			break
+		}
+		m = next
+	}
+	return m, int(jdn-newMoonDay(m.k)) + 1
+}
+
+// chineseMonthStart returns the Julian day number of the first day of the
+// month. The regular month is used when the year does not have the leap
+// month.
+func chineseMonthStart(year, number int, leap bool) int64 {
+	suiYear := year
+	if number >= 11 {
+		suiYear++
+	}
+	var start int64
+	for _, m := range chineseSui(suiYear) {
+		if m.year != year || m.number != number {
+			continue
+		}
+		if m.leap == leap {
+			return newMoonDay(m.k)
+		}
+		if !m.leap {
+			start = newMoonDay(m.k)
+		}
+	}
+	return start
+}
+
+// jdnOf returns the Julian day number of the date in its location.
+func jdnOf(t time.Time) int64 {
+	year, month, day := t.Date()
+	return civilDays(year, month, day) + jdnOffset
+}
+
+// chinaDay returns the Julian day number of the day in China Standard Time
+// of the Julian date in UT.
+func chinaDay(jd float64) int64 {
+	return int64(math.Floor(jd + 0.5 + chinaUTCHours/24.0))
+}
+
+// newMoonDay returns the Julian day number of the day of the new moon in
+// China Standard Time.
+func newMoonDay(k int64) int64 {
+	return chinaDay(newMoon(k))
+}
+
+// newMoonOnOrBefore returns the lunation number of the last new moon on or
+// before the day in China Standard Time.
+func newMoonOnOrBefore(jdn int64) int64 {
+	k := int64(math.Floor((float64(jdn)-2451550.1)/synodicMonth)) + 1
+	for newMoonDay(k) > jdn {
+		k--
+	}
+	for newMoonDay(k+1) <= jdn {
+		k++
+	}
+	return k
+}
+
+// hasMajorTerm returns true when the solar longitude crosses a multiple of
+// 30 degrees, the major solar term, during the month.
+func hasMajorTerm(k int64) bool {
+	from := solarLongitude(chinaMidnight(newMoonDay(k)))
+	to := solarLongitude(chinaMidnight(newMoonDay(k + 1)))
+	return math.Floor(from/30) != math.Floor(to/30)
+}
+
+// chinaMidnight returns the Julian date in UT of the start of the day in
+// China Standard Time.
+func chinaMidnight(jdn int64) float64 {
+	return float64(jdn) - 0.5 - chinaUTCHours/24.0
+}
+
+// winterSolstice returns the Julian day number of the winter solstice of the
+// Gregorian year in China Standard Time.
+func winterSolstice(year int) int64 {
+	jd := float64(civilDays(year, time.December, 21) + jdnOffset)
+	for i := 0; i < 5; i++ {
+		delta := math.Mod(270-solarLongitude(jd)+540, 360) - 180
+		jd += delta * tropicalYear / 360
+	}
+	return chinaDay(jd)
+}
+
+// newMoon returns the Julian date in UT of the new moon of the lunation
+// number, 0 is the new moon of January 6, 2000. It's calculated by the
+// algorithm of Jean Meeus, Astronomical Algorithms, chapter 49.
+func newMoon(k int64) float64 {
+	kf := float64(k)
+	t := kf / 1236.85
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+	jde := 2451550.09766 + synodicMonth*kf + 0.00015437*t2 - 0.000000150*t3 + 0.00000000073*t4
+	e := 1 - 0.002516*t - 0.0000074*t2
+	m := rad(2.5534 + 29.10535670*kf - 0.0000014*t2 - 0.00000011*t3)
+	mp := rad(201.5643 + 385.81693528*kf + 0.0107582*t2 + 0.00001238*t3 - 0.000000058*t4)
+	f := rad(160.7108 + 390.67050284*kf - 0.0016118*t2 - 0.00000227*t3 + 0.000000011*t4)
+	om := rad(124.7746 - 1.56375588*kf + 0.0020672*t2 + 0.00000215*t3)
+
+	jde += -0.40720*math.Sin(mp) +
+		0.17241*e*math.Sin(m) +
+		0.01608*math.Sin(2*mp) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(mp-m) -
+		0.00514*e*math.Sin(mp+m) +
+		0.00208*e*e*math.Sin(2*m) -
+		0.00111*math.Sin(mp-2*f) -
+		0.00057*math.Sin(mp+2*f) +
+		0.00056*e*math.Sin(2*mp+m) -
+		0.00042*math.Sin(3*mp) +
+		0.00042*e*math.Sin(m+2*f) +
+		0.00038*e*math.Sin(m-2*f) -
+		0.00024*e*math.Sin(2*mp-m) -
+		0.00017*math.Sin(om) -
+		0.00007*math.Sin(mp+2*m) +
+		0.00004*math.Sin(2*mp-2*f) +
+		0.00004*math.Sin(3*m) +
+		0.00003*math.Sin(mp+m-2*f) +
+		0.00003*math.Sin(2*mp+2*f) -
+		0.00003*math.Sin(mp+m+2*f) +
+		0.00003*math.Sin(mp-m+2*f) -
+		0.00002*math.Sin(mp-m-2*f) -
+		0.00002*math.Sin(3*mp+m) +
+		0.00002*math.Sin(4*mp)
+
+	// planetary arguments
+	for _, a := range [...]struct{ coef, a0, a1 float64 }{
+		{0.000325, 299.77, 0.107408},
+		{0.000165, 251.88, 0.016321},
+		{0.000164, 251.83, 26.651886},
+		{0.000126, 349.42, 36.412478},
+		{0.000110, 84.66, 18.206239},
+		{0.000062, 141.74, 53.303771},
+		{0.000060, 207.14, 2.453732},
+		{0.000056, 154.84, 7.306860},
+		{0.000047, 34.52, 27.261239},
+		{0.000042, 207.19, 0.121824},
+		{0.000040, 291.34, 1.844379},
+		{0.000037, 161.72, 24.198154},
+		{0.000035, 239.56, 25.513099},
+		{0.000023, 331.55, 3.592518},
+	} {
+		jde += a.coef * math.Sin(rad(a.a0+a.a1*kf))
+	}
+	return jde - deltaT(jde)/86400
+}
+
+// solarLongitude returns the apparent longitude of the Sun in degrees at the
+// Julian date in UT. It's calculated by the low accuracy algorithm of Jean
+// Meeus, Astronomical Algorithms, chapter 25, the error is about 0.01 degree.
+func solarLongitude(jd float64) float64 {
+	jde := jd + deltaT(jd)/86400
+	t := (jde - 2451545) / 36525
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := rad(357.52911 + 35999.05029*t - 0.0001537*t*t)
+	c := (1.914602-0.004817*t-0.000014*t*t)*math.Sin(m) +
+		(0.019993-0.000101*t)*math.Sin(2*m) +
+		0.000289*math.Sin(3*m)
+	om := rad(125.04 - 1934.136*t)
+	return math.Mod(math.Mod(l0+c-0.00569-0.00478*math.Sin(om), 360)+360, 360)
+}
+
+// deltaT returns the difference between Terrestrial Time and Universal Time
+// in seconds at the Julian date, by polynomial expressions of Espenak and
+// Meeus.
+func deltaT(jd float64) float64 {
+	y := 2000 + (jd-2451545)/tropicalYear
+	switch {
+	case y < 1900:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	case y < 1920:
+		t := y - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case y < 1941:
+		t := y - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case y < 2005:
+		t := y - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,76 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestChineseDateOf(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		expected datediff.ChineseDate
+	}{
+		{date: date(1985, time.February, 20), expected: datediff.ChineseDate{Year: 1985, Month: 1, Day: 1}},
+		{date: date(2000, time.February, 5), expected: datediff.ChineseDate{Year: 2000, Month: 1, Day: 1}},
+		{date: date(2020, time.January, 24), expected: datediff.ChineseDate{Year: 2019, Month: 12, Day: 30}},
+		{date: date(2020, time.January, 25), expected: datediff.ChineseDate{Year: 2020, Month: 1, Day: 1}},
+		{date: date(2020, time.May, 22), expected: datediff.ChineseDate{Year: 2020, Month: 4, Day: 30}},
+		{date: date(2020, time.May, 23), expected: datediff.ChineseDate{Year: 2020, Month: 4, Leap: true, Day: 1}},
+		{date: date(2020, time.June, 21), expected: datediff.ChineseDate{Year: 2020, Month: 5, Day: 1}},
+		{date: date(2023, time.January, 22), expected: datediff.ChineseDate{Year: 2023, Month: 1, Day: 1}},
+		{date: date(2023, time.March, 22), expected: datediff.ChineseDate{Year: 2023, Month: 2, Leap: true, Day: 1}},
+		{date: date(2024, time.February, 10), expected: datediff.ChineseDate{Year: 2024, Month: 1, Day: 1}},
+		{date: date(2025, time.January, 29), expected: datediff.ChineseDate{Year: 2025, Month: 1, Day: 1}},
+		// the leap month after the 11th month
+		{date: date(2033, time.December, 22), expected: datediff.ChineseDate{Year: 2033, Month: 11, Leap: true, Day: 1}},
+		{date: date(2034, time.February, 19), expected: datediff.ChineseDate{Year: 2034, Month: 1, Day: 1}},
+	}
+	for _, tC := range testCases {
+		if got := datediff.ChineseDateOf(tC.date); got != tC.expected {
+			t.Errorf("ChineseDateOf(%s) = %+v, want %+v", tC.date.Format("2006-01-02"), got, tC.expected)
+		}
+	}
+}
+
+func TestChineseCalendar(t *testing.T) {
+	c := datediff.ChineseCalendar{}
+	testCases := []struct {
+		desc     string
+		got      time.Time
+		expected time.Time
+	}{
+		{desc: "add year", got: c.AddYears(date(2020, time.January, 25), 1), expected: date(2021, time.February, 12)},
+		{desc: "add year to leap month", got: c.AddYears(date(2020, time.May, 30), 1), expected: date(2021, time.May, 19)},
+		{desc: "add months over leap month", got: c.AddMonths(date(2020, time.April, 23), 2), expected: date(2020, time.June, 21)},
+		{desc: "add month with day overflow", got: c.AddMonths(date(2020, time.February, 23), 1), expected: date(2020, time.March, 24)},
+		{desc: "add days", got: c.AddDays(date(2020, time.February, 23), 7), expected: date(2020, time.March, 1)},
+	}
+	for _, tC := range testCases {
+		if !tC.got.Equal(tC.expected) {
+			t.Errorf("%s = %s, want %s", tC.desc, tC.got.Format("2006-01-02"), tC.expected.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestChineseCalendarDiff(t *testing.T) {
+	start, end := date(2020, time.January, 25), date(2021, time.February, 12)
+	testCases := []struct {
+		mode     datediff.DiffMode
+		expected datediff.Diff
+	}{
+		{mode: datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays, expected: datediff.Diff{Years: 1}},
+		// the year has the leap month
+		{mode: datediff.ModeMonths, expected: datediff.Diff{Months: 13}},
+		{mode: datediff.ModeDays, expected: datediff.Diff{Days: 384}},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.NewDiffWithMode(start, end, tC.mode, datediff.WithCalendar(datediff.ChineseCalendar{}))
+		if err != nil {
+			t.Errorf("NewDiffWithMode(%s) failed: %v", tC.mode, err)
+		} else if !got.Equal(tC.expected) {
+			t.Errorf("NewDiffWithMode(%s) = %v, want %v", tC.mode, got, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is the civil date without time of the day and location. It has the
+// same fields as civil.Date of cloud.google.com/go/civil, so civil dates can
+// be converted to Date directly:
+//
+//	diff, err := NewDateDiff(datediff.Date(start), datediff.Date(end), "%Y %M")
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of the time in its location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// dateLayouts are layouts of dates accepted by ParseDate.
+var dateLayouts = []string{"2006-01-02", time.RFC3339}
+
+// ParseDate parses the date in "2006-01-02" or RFC 3339 format, i.e
+// "2000-04-17" or "2000-04-17T10:00:00+10:00". The date without the time of
+// the day is in UTC. It's the date format of the command line tool, HTTP
+// handler and DiffService of the module.
+func ParseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// IsValid returns true when the date exists, i.e February 30 does not.
+func (d Date) IsValid() bool {
+	return DateOf(d.Time()) == d
+}
+
+// Time returns the start of the date in UTC. Dates differences of civil dates
+// are calculated in UTC, so they are not affected by daylight saving time
+// transitions.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// String returns the date in "2006-01-02" format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// NewDateDiff creates Diff of civil dates according to the provided format
+// as NewDiff does. Options that adjust the dates, i.e WithInclusiveEnd, are
+// applied as well. It returns error when any of the dates is invalid.
+func NewDateDiff(start, end Date, rawFormat string, opts ...Option) (Diff, error) {
+	if err := validateDates(start, end); err != nil {
+		return Diff{}, err
+	}
+	return NewDiff(start.Time(), end.Time(), rawFormat, opts...)
+}
+
+// NewDateDiffWithMode creates Diff of civil dates according to the provided
+// mode as NewDiffWithMode does. It returns error when any of the dates is
+// invalid.
+func NewDateDiffWithMode(start, end Date, mode DiffMode, opts ...Option) (Diff, error) {
+	if err := validateDates(start, end); err != nil {
+		return Diff{}, err
+	}
+	return NewDiffWithMode(start.Time(), end.Time(), mode, opts...)
+}
+
+func validateDates(dates ...Date) error {
+	for _, d := range dates {
+		if !d.IsValid() {
+			return fmt.Errorf("invalid date %s", d)
+		}
+	}
+	return nil
+}
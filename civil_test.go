@@ -0,0 +1,99 @@
+package datediff_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestDate(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	tm := time.Date(2020, time.February, 29, 23, 30, 0, 0, loc)
+
+	d := datediff.DateOf(tm)
+	if want := (datediff.Date{Year: 2020, Month: time.February, Day: 29}); d != want {
+		t.Errorf("DateOf(%s) = %v, want %v", tm, d, want)
+	}
+	if got, want := d.String(), "2020-02-29"; got != want {
+		t.Errorf("Date.String() = %s, want %s", got, want)
+	}
+	if got, want := d.Time(), time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Date.Time() = %s, want %s", got, want)
+	}
+
+	testCases := []struct {
+		date     datediff.Date
+		expected bool
+	}{
+		{date: datediff.Date{Year: 2020, Month: time.February, Day: 29}, expected: true},
+		{date: datediff.Date{Year: 2021, Month: time.February, Day: 29}, expected: false},
+		{date: datediff.Date{Year: 2021, Month: time.April, Day: 31}, expected: false},
+		{date: datediff.Date{Year: 2021, Month: 13, Day: 1}, expected: false},
+		{date: datediff.Date{}, expected: false},
+	}
+	for _, tC := range testCases {
+		if got := tC.date.IsValid(); got != tC.expected {
+			t.Errorf("Date(%s).IsValid() = %t, want %t", tC.date, got, tC.expected)
+		}
+	}
+}
+
+func TestNewDateDiff(t *testing.T) {
+	start := datediff.Date{Year: 2000, Month: time.April, Day: 17}
+	end := datediff.Date{Year: 2003, Month: time.March, Day: 16}
+
+	diff, err := datediff.NewDateDiff(start, end, "%Y %M %D")
+	if err != nil {
+		t.Fatalf("NewDateDiff() failed: %v", err)
+	}
+	if got, want := diff.String(), "2 years 10 months 27 days"; got != want {
+		t.Errorf("NewDateDiff() = %s, want %s", got, want)
+	}
+
+	diff, err = datediff.NewDateDiffWithMode(start, end, datediff.ModeMonths, datediff.WithInclusiveEnd())
+	if err != nil {
+		t.Fatalf("NewDateDiffWithMode() failed: %v", err)
+	}
+	if got, want := diff.Months, 35; got != want {
+		t.Errorf("NewDateDiffWithMode() = %d months, want %d", got, want)
+	}
+
+	invalid := datediff.Date{Year: 2003, Month: time.February, Day: 29}
+	if _, err := datediff.NewDateDiff(start, invalid, "%Y"); err == nil {
+		t.Errorf("want to fail due to invalid date")
+	} else if got, want := err.Error(), "invalid date 2003-02-29"; got != want {
+		t.Errorf("NewDateDiff() error = %s, want %s", got, want)
+	}
+	if _, err := datediff.NewDateDiffWithMode(invalid, end, datediff.ModeYears); err == nil {
+		t.Errorf("want to fail due to invalid date")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected time.Time
+	}{
+		{s: "2000-04-17", expected: time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)},
+		{s: "2000-04-17T10:00:00Z", expected: time.Date(2000, time.April, 17, 10, 0, 0, 0, time.UTC)},
+		{s: "2000-04-17T10:00:00+10:00", expected: time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseDate(tC.s)
+		if err != nil {
+			t.Errorf("ParseDate(%s) failed: %v", tC.s, err)
+		} else if !got.Equal(tC.expected) {
+			t.Errorf("ParseDate(%s) = %v, want %v", tC.s, got, tC.expected)
+		}
+	}
+
+	for _, s := range []string{"", "2000-04-31", "17/04/2000"} {
+		if got, err := datediff.ParseDate(s); err == nil {
+			t.Errorf("ParseDate(%q) = %v, want to fail due to invalid date %q", s, got, s)
+		} else if want := fmt.Sprintf("invalid date %q", s); err.Error() != want {
+			t.Errorf("ParseDate(%q) failed: %v, want to fail due to %s", s, err, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package datediff
+
+import "time"
+
+// civilDate is the date of the proleptic Gregorian calendar decomposed from
+// time once. Dates are advanced and compared by the day number, the time of
+// the day is taken from the original time.
+type civilDate struct {
+	days  int64 // days since March 1, year 0
+	year  int
+	month int
+	day   int
+	t     time.Time
+}
+
+func newCivilDate(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{
+		days:  civilDays(year, month, day),
+		year:  year,
+		month: int(month),
+		day:   day,
+		t:     t,
+	}
+}
+
+// civilDiff calculates dates difference as newCalendarDiff does for the
+// Gregorian calendar. Both dates are decomposed once, time units are
+// calculated with integer arithmetic of day numbers instead of advancing the
+// date by time.Time.AddDate. The date advanced by each time unit is
+// normalized as time.Time.AddDate does, since the time of the day in the
+// daylight saving time gap moves by the gap, even to another day, and it's
+// kept for the next units.
+func civilDiff(start, end time.Time, mode DiffMode) Diff64 {
+	var diff Diff64
+	s := newCivilDate(start)
+	e := newCivilDate(end.In(start.Location()))
+
+	if mode&ModeYears != 0 {
+		diff.Years = s.fullMonths(e) / monthsInYear
+		s = s.addMonths(diff.Years * monthsInYear).normalized()
+	}
+
+	if mode&ModeMonths != 0 {
+		if mode&ModeYears == 0 {
+			// months are counted from the start date advanced by full years,
+			// that can differ from months counted from the start date due to
+			// normalization of February 29
+			years := s.fullMonths(e) / monthsInYear
+			diff.Months = years*monthsInYear + s.addMonths(years*monthsInYear).normalized().fullMonths(e)
+		} else {
+			diff.Months = s.fullMonths(e)
+		}
+		s = s.addMonths(diff.Months).normalized()
+	}
+
+	if mode&ModeWeeks != 0 {
+		diff.Weeks = s.fullDays(e) / daysInWeek
+		s = s.addDays(diff.Weeks * daysInWeek).normalized()
+	}
+
+	if mode&ModeDays != 0 {
+		diff.Days = s.fullDays(e)
+	}
+
+	return diff
+}
+
+// addMonths returns the date advanced by n months, the day overflow is
+// normalized as time.Time.AddDate does.
+func (c civilDate) addMonths(n int64) civilDate {
+	months := int64(c.month-1) + n
+	year := int64(c.year) + months/monthsInYear
+	if months %= monthsInYear; months < 0 {
+		months += monthsInYear
+		year--
+	}
+	return c.addDays(civilDays(int(year), time.Month(months+1), c.day) - c.days)
+}
+
+// addDays returns the date advanced by n days.
+func (c civilDate) addDays(n int64) civilDate {
+	days := c.days + n
+	year, month, day := civilFromDays(days)
+	return civilDate{days: days, year: year, month: int(month), day: day, t: c.t}
+}
+
+// time returns the time of the date with the time of the day of the date it
+// was decomposed from, as time.Time.AddDate does.
+func (c civilDate) time() time.Time {
+	hour, min, sec := c.t.Clock()
+	return time.Date(c.year, time.Month(c.month), c.day, hour, min, sec, c.t.Nanosecond(), c.t.Location())
+}
+
+// normalized returns the date decomposed from its time. The time of the day
+// in the daylight saving time gap is moved by the gap, as time.Time.AddDate
+// does, and the new time of the day is used when the date is advanced.
+func (c civilDate) normalized() civilDate {
+	if c.t.Location() == time.UTC {
+		return c
+	}
+	return newCivilDate(c.time())
+}
+
+// after returns true when the date is after the end date. Dates that are
+// more than a day apart are compared by day numbers. Close dates are compared
+// as times in the location of the date, that takes into account daylight
+// saving time transitions as time.Time.AddDate does. The time of the day in
+// the gap can be normalized to the previous or next day, i.e when midnight is
+// skipped, or the whole day is skipped.
+func (c civilDate) after(end civilDate) bool {
+	if d := c.days - end.days; d > 1 || d < -1 {
+		return d > 0
+	}
+	return c.time().After(end.t)
+}
+
+// fullMonths returns the number of full months between the date and the end
+// date. The date advanced by the difference of months can be after the end
+// date due to the time of the day or normalization of the day overflow, then
+// months are reduced. The date advanced by one more month can be normalized
+// back to the end date or before it, then months are increased.
+func (c civilDate) fullMonths(end civilDate) int64 {
+	months := int64(end.year-c.year)*monthsInYear + int64(end.month-c.month)
+	for months > 0 && c.addMonths(months).after(end) {
+		months--
+	}
+	for !c.addMonths(months + 1).after(end) {
+		months++
+	}
+	return months
+}
+
+// fullDays returns the number of full days between the date and the end
+// date. It's adjusted in both directions as fullMonths does.
+func (c civilDate) fullDays(end civilDate) int64 {
+	days := end.days - c.days
+	for days > 0 && c.addDays(days).after(end) {
+		days--
+	}
+	for !c.addDays(days + 1).after(end) {
+		days++
+	}
+	return days
+}
+
+// civilDays returns the number of days since March 1, year 0 of the
+// proleptic Gregorian calendar.
+func civilDays(year int, month time.Month, day int) int64 {
+	y, m := int64(year), int64(month)
+	if m <= 2 {
+		y--
+		m += 12
+	}
+	era := y / 400
+	if y < 0 && y%400 != 0 {
+		era--
+	}
+	yoe := y - era*400                      // year of era [0, 399]
+	doy := (153*(m-3)+2)/5 + int64(day) - 1 // day of year starting from March 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy  // day of era
+	return era*146097 + doe
+}
+
+// civilFromDays returns the date of the number of days since March 1, year 0
+// of the proleptic Gregorian calendar, it's the inverse of civilDays.
+func civilFromDays(days int64) (int, time.Month, int) {
+	era := days / 146097
+	if days < 0 && days%146097 != 0 {
+		era--
+	}
+	doe := days - era*146097                               // day of era [0, 146096]
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 // year of era [0, 399]
+	doy := doe - (365*yoe + yoe/4 - yoe/100)               // day of year starting from March 1
+	mp := (5*doy + 2) / 153                                // month starting from March [0, 11]
+	day := doy - (153*mp+2)/5 + 1
+	month := mp + 3
+	year := yoe + era*400
+	if month > 12 {
+		month -= 12
+		year++
+	}
+	return int(year), time.Month(month), int(day)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/antklim/datediff"
+)
+
+// batchHeader is the header of columns appended to batch records.
+var batchHeader = append(append([]string(nil), datediff.CSVHeader...), "print")
+
+// batch reads start and end dates from records of the named file, or stdin
+// when the name is "-", and writes records with dates difference columns to
+// w. Records are processed one by one, so the file size is not limited by
+// memory.
+func (cfg config) batch(stdin io.Reader, w io.Writer, name string, tsv bool) error {
+	in := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	r := csv.NewReader(in)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	out := csv.NewWriter(w)
+	if tsv {
+		r.Comma, out.Comma = '\t', '\t'
+		r.LazyQuotes = true
+	}
+	err := cfg.copyRecords(r, out)
+	out.Flush()
+	if err != nil {
+		return err
+	}
+	return out.Error()
+}
+
+// copyRecords copies records of r to w with appended dates difference
+// columns.
+func (cfg config) copyRecords(r *csv.Reader, w *csv.Writer) error {
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := r.FieldPos(0)
+		if len(record) < 2 {
+			return fmt.Errorf("line %d: record has %d fields, want at least 2", line, len(record))
+		}
+		if first && strings.EqualFold(record[0], "start") {
+			if err := w.Write(append(record, batchHeader...)); err != nil {
+				return err
+			}
+			continue
+		}
+		diff, err := cfg.diff(record[0], record[1])
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		record = append(record, diff.Record()...)
+		record = append(record, diff.String())
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
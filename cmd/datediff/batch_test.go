@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunBatch(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			desc: "csv with header",
+			args: []string{"-batch", "-"},
+			stdin: "# employees\n" +
+				"start,end,name\n" +
+				"2000-04-17,2002-07-21,Alice\n" +
+				"2020-01-01,2020-01-31,Bob\n",
+			stdout: "start,end,name,mode,format,years,months,weeks,days,print\n" +
+				"2000-04-17,2002-07-21,Alice,208,%Y %M %D,2,3,0,4,2 years 3 months 4 days\n" +
+				"2020-01-01,2020-01-31,Bob,208,%Y %M %D,0,0,0,30,30 days\n",
+		},
+		{
+			desc:   "tsv without header",
+			args:   []string{"-batch", "-", "-tsv", "-format", "%W"},
+			stdin:  "2000-04-17\t2000-05-17\n",
+			stdout: "2000-04-17\t2000-05-17\t32\t%W\t0\t0\t4\t0\t4 weeks\n",
+		},
+		{
+			desc:   "invalid date",
+			args:   []string{"-batch", "-"},
+			stdin:  "2000-04-17,2002-07-21\n2000-04-17,2002-07-32\n",
+			code:   1,
+			stdout: "2000-04-17,2002-07-21,208,%Y %M %D,2,3,0,4,2 years 3 months 4 days\n",
+			stderr: "datediff: line 2: invalid date \"2002-07-32\"\n",
+		},
+		{
+			desc:   "short record",
+			args:   []string{"-batch", "-"},
+			stdin:  "2000-04-17\n",
+			code:   1,
+			stderr: "datediff: line 1: record has 1 fields, want at least 2\n",
+		},
+		{
+			desc:   "missing file",
+			args:   []string{"-batch", "testdata/missing.csv"},
+			code:   1,
+			stderr: "datediff: open testdata/missing.csv: no such file or directory\n",
+		},
+		{
+			desc:   "dates arguments",
+			args:   []string{"-batch", "-", "2000-04-17", "2002-07-21"},
+			code:   2,
+			stderr: "usage: datediff [flags] START END\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tC.args, strings.NewReader(tC.stdin), &stdout, &stderr)
+			if code != tC.code {
+				t.Errorf("run() = %d, want %d", code, tC.code)
+			}
+			if got := stdout.String(); got != tC.stdout {
+				t.Errorf("run() stdout = %q, want %q", got, tC.stdout)
+			}
+			if got := stderr.String(); !strings.HasPrefix(got, tC.stderr) || (tC.stderr == "" && got != "") {
+				t.Errorf("run() stderr = %q, want %q", got, tC.stderr)
+			}
+		})
+	}
+}
+
+func TestRunBatchFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "dates.csv")
+	if err := os.WriteFile(name, []byte("2000-04-17,2003-03-16\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-batch", name, "-format", "%M"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+	}
+	const want = "2000-04-17,2003-03-16,64,%M,0,34,0,0,34 months\n"
+	if got := stdout.String(); got != want {
+		t.Errorf("run() stdout = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// exploreFormats are formats shown by the explorer when the format is not
+// provided.
+var exploreFormats = []string{"%Y %M %D", "%M %D", "%W %D", "%D"}
+
+const exploreHelp = `commands:
+  s DATE|±N[dwmy]  set or move the start date, i.e "s 2020-01-31" or "s +1m"
+  e DATE|±N[dwmy]  set or move the end date
+  ±N[dwmy]         move the end date, i.e "+1d" or "-2w"
+  f FORMAT         add the format to the view, "f" restores default formats
+  empty line       repeat the last command
+  ?                show this help
+  q                quit`
+
+// explorer is the interactive view of dates differences between start and
+// end dates in several formats.
+type explorer struct {
+	cfg     config
+	start   time.Time
+	end     time.Time
+	formats []string
+	// clear is set when the view is redrawn on the terminal screen.
+	clear bool
+}
+
+// explore runs the explorer that reads commands from r and draws the view
+// to w after every command, until "q" command or the end of input.
+func (cfg config) explore(r io.Reader, w io.Writer, x explorer) error {
+	x.cfg = cfg
+	s := bufio.NewScanner(r)
+	var last, msg string
+	for {
+		if err := x.draw(w, msg); err != nil {
+			return err
+		}
+		if !s.Scan() {
+			fmt.Fprintln(w)
+			return s.Err()
+		}
+		cmd := strings.TrimSpace(s.Text())
+		if cmd == "" {
+			cmd = last
+		}
+		msg = ""
+		switch cmd {
+		case "":
+		case "q":
+			return nil
+		case "?":
+			msg = exploreHelp
+		default:
+			if err := x.do(cmd); err != nil {
+				msg = "error: " + err.Error()
+			} else {
+				last = cmd
+			}
+		}
+	}
+}
+
+// do executes the command.
+func (x *explorer) do(cmd string) error {
+	name, arg := cmd, ""
+	if i := strings.IndexByte(cmd, ' '); i >= 0 {
+		name, arg = cmd[:i], strings.TrimSpace(cmd[i+1:])
+	}
+	switch {
+	case name == "s":
+		return setDate(&x.start, arg)
+	case name == "e":
+		return setDate(&x.end, arg)
+	case name == "f":
+		if arg == "" {
+			x.formats = nil
+		} else {
+			x.formats = append(x.viewFormats(), arg)
+		}
+		return nil
+	case strings.HasPrefix(cmd, "+") || strings.HasPrefix(cmd, "-"):
+		return setDate(&x.end, cmd)
+	}
+	return fmt.Errorf("unknown command %q, type ? for help", cmd)
+}
+
+// viewFormats returns formats of the view.
+func (x explorer) viewFormats() []string {
+	switch {
+	case x.formats != nil:
+		return x.formats
+	case x.cfg.business != nil:
+		return []string{"%D"}
+	}
+	return append([]string(nil), exploreFormats...)
+}
+
+// setDate sets the date or moves it by the number of days, weeks, months or
+// years, i.e "+1m". Months and years are added as time.Time.AddDate does.
+func setDate(t *time.Time, arg string) error {
+	if !strings.HasPrefix(arg, "+") && !strings.HasPrefix(arg, "-") {
+		d, err := datediff.ParseDate(arg)
+		if err != nil {
+			return err
+		}
+		*t = d
+		return nil
+	}
+	unit := byte('d')
+	if last := arg[len(arg)-1]; last < '0' || last > '9' {
+		unit, arg = last, arg[:len(arg)-1]
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("invalid offset %q", arg)
+	}
+	switch unit {
+	case 'd':
+		*t = t.AddDate(0, 0, n)
+	case 'w':
+		*t = t.AddDate(0, 0, 7*n)
+	case 'm':
+		*t = t.AddDate(0, n, 0)
+	case 'y':
+		*t = t.AddDate(n, 0, 0)
+	default:
+		return fmt.Errorf("unknown offset unit %q", unit)
+	}
+	return nil
+}
+
+// draw draws dates, their differences in formats of the view and the
+// message followed by the prompt.
+func (x explorer) draw(w io.Writer, msg string) error {
+	if x.clear {
+		fmt.Fprint(w, "\x1b[H\x1b[2J")
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "start\t%s\n", x.start.Format("2006-01-02 Monday"))
+	fmt.Fprintf(tw, "end\t%s\n\n", x.end.Format("2006-01-02 Monday"))
+	for _, f := range x.viewFormats() {
+		diff, err := x.cfg.diffDates(x.start, x.end, f)
+		if err != nil {
+			fmt.Fprintf(tw, "%s\terror: %v\n", f, err)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", f, diff.String())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if msg != "" {
+		fmt.Fprintf(w, "\n%s\n", msg)
+	}
+	_, err := fmt.Fprint(w, "> ")
+	return err
+}
+
+// isTerminal reports whether w is the terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunExplore(t *testing.T) {
+	const input = "+1d\n\ns -1m\nf %W %D\nq\n+1d\n"
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-explore", "-format", "%M %D", "2021-01-31", "2021-02-28"}, strings.NewReader(input), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+	}
+
+	// January 31 plus 1 month is March 3 as time.Time.AddDate calculates it,
+	// so there is less than a month to March 1.
+	views := strings.Split(stdout.String(), "> ")
+	want := []string{
+		"start  2021-01-31 Sunday\nend    2021-02-28 Sunday\n\n%M %D  28 days\n",
+		"start  2021-01-31 Sunday\nend    2021-03-01 Monday\n\n%M %D  29 days\n",
+		"start  2021-01-31 Sunday\nend    2021-03-02 Tuesday\n\n%M %D  30 days\n",
+		"start  2020-12-31 Thursday\nend    2021-03-02 Tuesday\n\n%M %D  1 month 30 days\n",
+		"start  2020-12-31 Thursday\nend    2021-03-02 Tuesday\n\n%M %D  1 month 30 days\n%W %D  8 weeks 5 days\n",
+		"", // quit before the last command
+	}
+	if len(views) != len(want) {
+		t.Fatalf("run() views = %q, want %q", views, want)
+	}
+	for i := range want {
+		if views[i] != want[i] {
+			t.Errorf("run() view %d = %q, want %q", i, views[i], want[i])
+		}
+	}
+}
+
+func TestExplorerCommands(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		expected string // the last view
+	}{
+		{
+			desc:     "help",
+			input:    "?\n",
+			expected: "\ncommands:\n",
+		},
+		{
+			desc:     "unknown command",
+			input:    "x\n",
+			expected: "\nerror: unknown command \"x\", type ? for help\n",
+		},
+		{
+			desc:     "invalid date",
+			input:    "e 2021-02-30\n",
+			expected: "\nerror: invalid date \"2021-02-30\"\n",
+		},
+		{
+			desc:     "invalid offset",
+			input:    "e +1q\n",
+			expected: "\nerror: unknown offset unit 'q'\n",
+		},
+		{
+			desc:     "start after end",
+			input:    "s +1y\n",
+			expected: "%D        error: start date is after end date\n",
+		},
+		{
+			desc:     "default formats",
+			input:    "f %W\nf\n",
+			expected: "%Y %M %D  1 month\n%M %D     1 month\n%W %D     4 weeks\n%D        28 days\n",
+		},
+		{
+			desc:     "end of input",
+			input:    "+1w",
+			expected: "end    2021-03-08 Monday\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"-explore", "2021-02-01", "2021-03-01"}, strings.NewReader(tC.input), &stdout, &stderr)
+			if code != 0 {
+				t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+			}
+			views := strings.Split(stdout.String(), "> ")
+			if last := views[len(views)-2]; !strings.Contains(last, tC.expected) {
+				t.Errorf("run() view = %q, want it to contain %q", last, tC.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/antklim/datediff/businesscal"
+)
+
+// readHolidays reads holidays from the named file, one date per line in
+// "2006-01-02" format. Empty lines and lines starting with "#" are skipped.
+func readHolidays(name string) (businesscal.HolidayList, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var dates []time.Time
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid date %q", name, line, text)
+		}
+		dates = append(dates, t)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return businesscal.NewHolidayList(dates...), nil
+}
@@ -0,0 +1,264 @@
+// Command datediff prints the difference between two dates.
+//
+// Usage:
+//
+//	datediff [flags] START END
+//	datediff -batch FILE [flags]
+//	datediff [flags] < FILE
+//	datediff -explore [flags] [START END]
+//
+// Dates are in "2006-01-02" or RFC 3339 format. The flags are:
+//
+//	-batch file
+//		read start and end dates from the first two columns of CSV file,
+//		"-" reads standard input
+//	-business-days
+//		count business days, Saturday and Sunday are weekend days
+//	-explore
+//		explore dates differences interactively
+//	-format string
+//		dates difference format (default "%Y %M %D", "%D" with
+//		-business-days)
+//	-holidays file
+//		exclude holidays listed in the file from business days, it implies
+//		-business-days
+//	-locale name
+//		locale of the text output, i.e "de"
+//	-output string
+//		output mode: text or json (default "text")
+//	-template string
+//		Go template of the output, i.e "{{.Years}}y {{.Months}}m", it
+//		overrides the output mode
+//	-tsv
+//		batch file has tab-separated values
+//
+// JSON output has the schema of datediff.Diff MarshalJSON, so it can be piped
+// into jq:
+//
+//	datediff -output=json 2000-04-17 2002-07-21 | jq .years
+//
+// Without dates arguments the command streams standard input, every line has
+// start and end dates separated by spaces, and prints one result per line in
+// the output mode. Empty lines and lines starting with "#" are skipped.
+//
+// Explorer shows dates differences between start and end dates, today by
+// default, in several formats, or the provided format, and updates them after
+// every command read from standard input. Commands move dates by days,
+// weeks, months or years, i.e "+1d" moves the end date to the next day and an
+// empty line repeats it, so month and week boundaries can be scrubbed through.
+// Type "?" for the list of commands.
+//
+// Holidays file lists dates in "2006-01-02" format, one date per line. Empty
+// lines and lines starting with "#" are skipped.
+//
+// Batch mode copies records of the file to standard output and appends
+// columns of dates difference to them, the columns mirror testdata/datediff.csv:
+// mode, format, years, months, weeks, days and print. Lines starting with "#"
+// are skipped, the header with "start" first column gets the names of the
+// appended columns.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/businesscal"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// config is the command configuration defined by flags.
+type config struct {
+	format   string
+	output   string
+	template *template.Template
+	locale   *datediff.Locale
+	business *businesscal.Calendar
+}
+
+// run runs the command with the arguments and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("datediff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: datediff [flags] START END")
+		fmt.Fprintln(stderr, "       datediff -batch FILE [flags]")
+		fmt.Fprintln(stderr, "       datediff [flags] < FILE")
+		fmt.Fprintln(stderr, "       datediff -explore [flags] [START END]")
+		fs.PrintDefaults()
+	}
+	var cfg config
+	var tmpl, batch, locale, holidays string
+	var tsv, business, explore bool
+	fs.StringVar(&batch, "batch", "", "read start and end dates from the first two columns of CSV `file`, \"-\" reads standard input")
+	fs.StringVar(&cfg.format, "format", "%Y %M %D", "dates difference `format`")
+	fs.StringVar(&cfg.output, "output", outputText, "output `mode`: text or json")
+	fs.StringVar(&tmpl, "template", "", "Go `template` of the output, i.e \"{{.Years}}y {{.Months}}m\", it overrides the output mode")
+	fs.BoolVar(&explore, "explore", false, "explore dates differences interactively")
+	fs.BoolVar(&tsv, "tsv", false, "batch file has tab-separated values")
+	fs.StringVar(&locale, "locale", "", "locale `name` of the text output, i.e \"de\"")
+	fs.BoolVar(&business, "business-days", false, "count business days, Saturday and Sunday are weekend days")
+	fs.StringVar(&holidays, "holidays", "", "exclude holidays listed in the `file` from business days, it implies -business-days")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	formatSet := false
+	fs.Visit(func(f *flag.Flag) {
+		formatSet = formatSet || f.Name == "format"
+	})
+	if (fs.NArg() != 2 && fs.NArg() != 0) || (batch != "" && (fs.NArg() != 0 || explore)) {
+		fs.Usage()
+		return 2
+	}
+	if cfg.output != outputText && cfg.output != outputJSON {
+		fmt.Fprintf(stderr, "datediff: unknown output %q\n", cfg.output)
+		return 2
+	}
+	if tmpl != "" {
+		t, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			fmt.Fprintf(stderr, "datediff: %v\n", err)
+			return 2
+		}
+		cfg.template = t
+	}
+	if locale != "" {
+		l, err := datediff.LookupLocale(locale)
+		if err != nil {
+			fmt.Fprintf(stderr, "datediff: %v\n", err)
+			return 2
+		}
+		cfg.locale = l
+	}
+	if business || holidays != "" {
+		cal := businesscal.Standard
+		if holidays != "" {
+			list, err := readHolidays(holidays)
+			if err != nil {
+				fmt.Fprintf(stderr, "datediff: %v\n", err)
+				return 2
+			}
+			cal = cal.WithHolidays(list)
+		}
+		cfg.business = &cal
+		if !formatSet {
+			cfg.format = "%D"
+		}
+	}
+
+	var err error
+	switch {
+	case explore:
+		err = cfg.startExplorer(stdin, stdout, fs.Args(), formatSet)
+	case batch != "":
+		err = cfg.batch(stdin, stdout, batch, tsv)
+	case fs.NArg() == 0:
+		err = cfg.stream(stdin, stdout)
+	default:
+		err = cfg.print(stdout, fs.Arg(0), fs.Arg(1))
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "datediff: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// startExplorer starts the explorer of dates differences between dates of
+// the arguments, or today when there are no arguments.
+func (cfg config) startExplorer(stdin io.Reader, stdout io.Writer, args []string, formatSet bool) error {
+	x := explorer{clear: isTerminal(stdout)}
+	if len(args) == 0 {
+		now := time.Now()
+		x.start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		x.end = x.start
+	} else {
+		var err error
+		if x.start, err = datediff.ParseDate(args[0]); err != nil {
+			return err
+		}
+		if x.end, err = datediff.ParseDate(args[1]); err != nil {
+			return err
+		}
+	}
+	if formatSet {
+		x.formats = []string{cfg.format}
+	}
+	return cfg.explore(stdin, stdout, x)
+}
+
+// print calculates the difference between dates and writes it to w.
+func (cfg config) print(w io.Writer, start, end string) error {
+	diff, err := cfg.diff(start, end)
+	if err != nil {
+		return err
+	}
+	return cfg.write(w, diff)
+}
+
+// diff calculates the difference between dates.
+func (cfg config) diff(start, end string) (datediff.Diff, error) {
+	s, err := datediff.ParseDate(start)
+	if err != nil {
+		return datediff.Diff{}, err
+	}
+	e, err := datediff.ParseDate(end)
+	if err != nil {
+		return datediff.Diff{}, err
+	}
+	return cfg.diffDates(s, e, cfg.format)
+}
+
+// diffDates calculates the difference between dates in the format.
+func (cfg config) diffDates(start, end time.Time, format string) (datediff.Diff, error) {
+	var diff datediff.Diff
+	var err error
+	if cfg.business != nil {
+		if diff, err = businesscal.NewBusinessDiff(start, end, *cfg.business); err == nil {
+			diff, err = diff.WithFormat(format)
+		}
+	} else {
+		diff, err = datediff.NewDiff(start, end, format)
+	}
+	if err != nil {
+		return datediff.Diff{}, err
+	}
+	if cfg.locale != nil {
+		diff = diff.WithLocale(cfg.locale)
+	}
+	return diff, nil
+}
+
+// write writes the dates difference to w in the output mode.
+func (cfg config) write(w io.Writer, diff datediff.Diff) error {
+	switch {
+	case cfg.template != nil:
+		if err := cfg.template.Execute(w, diff); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(w)
+		return err
+	case cfg.output == outputJSON:
+		b, err := json.Marshal(diff)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+	_, err := fmt.Fprintln(w, diff.String())
+	return err
+}
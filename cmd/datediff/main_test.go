@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			desc:   "text output",
+			args:   []string{"2000-04-17", "2002-07-21"},
+			stdout: "2 years 3 months 4 days\n",
+		},
+		{
+			desc:   "format",
+			args:   []string{"-format", "%M %D", "2000-04-17", "2002-07-21"},
+			stdout: "27 months 4 days\n",
+		},
+		{
+			desc:   "format with zero units",
+			args:   []string{"-format", "%Y %M %D", "2000-04-17", "2000-04-21"},
+			stdout: "4 days\n",
+		},
+		{
+			desc:   "RFC 3339 dates",
+			args:   []string{"2000-04-17T10:00:00Z", "2000-04-20T09:00:00Z"},
+			stdout: "2 days\n",
+		},
+		{
+			desc:   "json output",
+			args:   []string{"--output=json", "-format", "%Y %D", "2000-04-17", "2002-07-21"},
+			stdout: `{"years":2,"months":0,"weeks":0,"days":95,"format":"%Y %D","mode":"years|days","start":"2000-04-17T00:00:00Z","end":"2002-07-21T00:00:00Z"}` + "\n",
+		},
+		{
+			desc:   "template output",
+			args:   []string{"--template", "{{.Years}}y {{.Months}}m {{.Days}}d", "2000-04-17", "2002-07-21"},
+			stdout: "2y 3m 4d\n",
+		},
+		{
+			desc:   "template overrides output mode",
+			args:   []string{"--output=json", "--template", "{{.}}", "2000-04-17", "2002-07-21"},
+			stdout: "2 years 3 months 4 days\n",
+		},
+		{
+			desc:   "locale",
+			args:   []string{"-locale", "de", "2000-04-17", "2002-07-21"},
+			stdout: "2 Jahre 3 Monate 4 Tage\n",
+		},
+		{
+			desc:   "region locale",
+			args:   []string{"-locale", "fr-CA", "-template", "{{.}}", "2000-04-17", "2002-07-21"},
+			stdout: "2 ans 3 mois 4 jours\n",
+		},
+		{
+			desc:   "business days",
+			args:   []string{"-business-days", "2021-12-20", "2022-01-04"},
+			stdout: "11 days\n",
+		},
+		{
+			desc:   "business days with holidays",
+			args:   []string{"-holidays", "testdata/holidays.txt", "-locale", "de", "2021-12-20", "2022-01-04"},
+			stdout: "8 Tage\n",
+		},
+		{
+			desc:   "business days json",
+			args:   []string{"-business-days", "-output=json", "2021-12-20", "2021-12-27"},
+			stdout: `{"years":0,"months":0,"weeks":0,"days":5,"format":"%D","mode":"days","start":"2021-12-20T00:00:00Z","end":"2021-12-27T00:00:00Z"}` + "\n",
+		},
+		{
+			desc:   "business days format",
+			args:   []string{"-business-days", "-format", "%Y", "2021-12-20", "2022-01-04"},
+			code:   1,
+			stderr: "datediff: format \"%Y\" does not match mode days\n",
+		},
+		{
+			desc:   "unknown locale",
+			args:   []string{"-locale", "xx", "2000-04-17", "2002-07-21"},
+			code:   2,
+			stderr: "datediff: unknown locale \"xx\"\n",
+		},
+		{
+			desc:   "invalid holidays",
+			args:   []string{"-holidays", "testdata/badholidays.txt", "2021-12-20", "2022-01-04"},
+			code:   2,
+			stderr: "datediff: testdata/badholidays.txt:2: invalid date \"December 28\"\n",
+		},
+		{
+			desc:   "unknown output",
+			args:   []string{"--output=xml", "2000-04-17", "2002-07-21"},
+			code:   2,
+			stderr: "datediff: unknown output \"xml\"\n",
+		},
+		{
+			desc:   "invalid template",
+			args:   []string{"--template", "{{.Years", "2000-04-17", "2002-07-21"},
+			code:   2,
+			stderr: "datediff: template: output:1: unclosed action\n",
+		},
+		{
+			desc:   "invalid date",
+			args:   []string{"2000-04-31", "2002-07-21"},
+			code:   1,
+			stderr: "datediff: invalid date \"2000-04-31\"\n",
+		},
+		{
+			desc:   "start after end",
+			args:   []string{"2002-07-21", "2000-04-17"},
+			code:   1,
+			stderr: "datediff: start date is after end date\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tC.args, nil, &stdout, &stderr)
+			if code != tC.code {
+				t.Errorf("run() = %d, want %d", code, tC.code)
+			}
+			if got := stdout.String(); got != tC.stdout {
+				t.Errorf("run() stdout = %q, want %q", got, tC.stdout)
+			}
+			if got := stderr.String(); got != tC.stderr {
+				t.Errorf("run() stderr = %q, want %q", got, tC.stderr)
+			}
+		})
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"2000-04-17"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("run() = %d, want 2", code)
+	}
+	if !bytes.HasPrefix(stderr.Bytes(), []byte("usage: datediff [flags] START END\n")) {
+		t.Errorf("run() stderr = %q, want usage", stderr.String())
+	}
+}
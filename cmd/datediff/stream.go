@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// stream reads lines of start and end dates from r and writes dates
+// difference of every line to w. Lines are processed one by one and the
+// output is buffered, so memory use does not depend on the input size.
+func (cfg config) stream(r io.Reader, w io.Writer) error {
+	out := bufio.NewWriter(w)
+	err := cfg.streamLines(r, out)
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// streamLines writes dates difference of lines of r to w.
+func (cfg config) streamLines(r io.Reader, w *bufio.Writer) error {
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: has %d fields, want 2", line, len(fields))
+		}
+		if err := cfg.print(w, fields[0], fields[1]); err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+	}
+	return s.Err()
+}
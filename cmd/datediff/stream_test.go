@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRunStream(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			desc:   "text output",
+			stdin:  "# dates\n2000-04-17 2002-07-21\n\n  2020-01-01\t2020-01-31  \n",
+			stdout: "2 years 3 months 4 days\n30 days\n",
+		},
+		{
+			desc:   "json output",
+			args:   []string{"-output=json", "-format", "%W"},
+			stdin:  "2000-04-17 2000-05-17\n",
+			stdout: `{"years":0,"months":0,"weeks":4,"days":0,"format":"%W","mode":"weeks","start":"2000-04-17T00:00:00Z","end":"2000-05-17T00:00:00Z"}` + "\n",
+		},
+		{
+			desc:   "empty input",
+			stdin:  "",
+			stdout: "",
+		},
+		{
+			desc:   "invalid line",
+			stdin:  "2000-04-17 2002-07-21\n2000-04-17\n",
+			code:   1,
+			stdout: "2 years 3 months 4 days\n",
+			stderr: "datediff: line 2: has 1 fields, want 2\n",
+		},
+		{
+			desc:   "invalid date",
+			stdin:  "2000-04-17 2002-07-21\n2000-04-17 2002-02-30\n",
+			code:   1,
+			stdout: "2 years 3 months 4 days\n",
+			stderr: "datediff: line 2: invalid date \"2002-02-30\"\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tC.args, strings.NewReader(tC.stdin), &stdout, &stderr)
+			if code != tC.code {
+				t.Errorf("run() = %d, want %d", code, tC.code)
+			}
+			if got := stdout.String(); got != tC.stdout {
+				t.Errorf("run() stdout = %q, want %q", got, tC.stdout)
+			}
+			if got := stderr.String(); got != tC.stderr {
+				t.Errorf("run() stderr = %q, want %q", got, tC.stderr)
+			}
+		})
+	}
+}
+
+// pairs generates n lines of dates pairs without keeping them in memory.
+type pairs struct {
+	n    int
+	line []byte
+	off  int
+}
+
+func (p *pairs) Read(b []byte) (int, error) {
+	if p.n == 0 {
+		return 0, io.EOF
+	}
+	n := copy(b, p.line[p.off:])
+	if p.off += n; p.off == len(p.line) {
+		p.off = 0
+		p.n--
+	}
+	return n, nil
+}
+
+func TestRunStreamLargeInput(t *testing.T) {
+	const lines = 100000
+	in := &pairs{n: lines, line: []byte("2000-04-17 2002-07-21\n")}
+
+	var stderr bytes.Buffer
+	var count lineCounter
+	if code := run(nil, in, &count, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+	}
+	if count != lines {
+		t.Errorf("run() wrote %d lines, want %d", count, lines)
+	}
+}
+
+// lineCounter counts written lines.
+type lineCounter int
+
+func (c *lineCounter) Write(b []byte) (int, error) {
+	*c += lineCounter(bytes.Count(b, []byte("\n")))
+	return len(b), nil
+}
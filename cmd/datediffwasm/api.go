@@ -0,0 +1,112 @@
+// Command datediffwasm exposes dates difference calculation to JavaScript,
+// so web frontends use the same calendar logic as Go backends. It's built
+// for js/wasm target:
+//
+//	GOOS=js GOARCH=wasm go build -o datediff.wasm ./cmd/datediffwasm
+//
+// and loaded with wasm_exec.js of the Go distribution. The module sets the
+// global datediff object with the functions:
+//
+//	datediff.newDiff(start, end, format = "%Y %M %D", options = {})
+//	datediff.format(diff, format, locale = "")
+//
+// Dates are Date objects or strings in "2006-01-02" or RFC 3339 format, the
+// local date of Date objects is used and the time of the day is discarded.
+// Options are locale, calendar (a name of the registered calendar, i.e
+// "persian") and inclusiveEnd. newDiff returns the object with years,
+// months, weeks, days, format, mode, start, end and text properties, format
+// formats years, months, weeks and days properties of the diff object. The
+// functions return Error object when the calculation fails.
+package main
+
+import (
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// defaultFormat is the format of newDiff when it's not provided.
+const defaultFormat = "%Y %M %D"
+
+// diffOptions are options of newDiff.
+type diffOptions struct {
+	locale       string
+	calendar     string
+	inclusiveEnd bool
+}
+
+// newDiff calculates dates difference and returns it as the map of the
+// result object properties.
+func newDiff(start, end, format string, o diffOptions) (map[string]interface{}, error) {
+	s, err := datediff.ParseDate(start)
+	if err != nil {
+		return nil, err
+	}
+	e, err := datediff.ParseDate(end)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = defaultFormat
+	}
+
+	var opts []datediff.Option
+	if o.locale != "" {
+		l, err := datediff.LookupLocale(o.locale)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, datediff.WithLocale(l))
+	}
+	if o.calendar != "" {
+		c, err := datediff.LookupCalendar(o.calendar)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, datediff.WithCalendar(c))
+	}
+	if o.inclusiveEnd {
+		opts = append(opts, datediff.WithInclusiveEnd())
+	}
+
+	d, err := datediff.NewDiff(s, e, format, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"years":  d.Years,
+		"months": d.Months,
+		"weeks":  d.Weeks,
+		"days":   d.Days,
+		"format": d.RawFormat(),
+		"mode":   d.Mode().String(),
+		"start":  d.Start().Format(time.RFC3339),
+		"end":    d.End().Format(time.RFC3339),
+		"text":   d.String(),
+	}, nil
+}
+
+// localDate returns the date of local fields of JavaScript Date object in
+// "2006-01-02" format, the month is counted from 1. Dates picked in the
+// browser are local midnights, in time zones ahead of UTC their UTC time is
+// on the previous day.
+func localDate(year, month, day int) string {
+	return datediff.Date{Year: year, Month: time.Month(month), Day: day}.String()
+}
+
+// formatDiff formats dates difference of years, months, weeks and days.
+func formatDiff(units [4]int, format, locale string) (string, error) {
+	d := datediff.Diff{Years: units[0], Months: units[1], Weeks: units[2], Days: units[3]}
+	d, err := d.WithFormat(format)
+	if err != nil {
+		return "", err
+	}
+	if locale != "" {
+		l, err := datediff.LookupLocale(locale)
+		if err != nil {
+			return "", err
+		}
+		d = d.WithLocale(l)
+	}
+	return d.String(), nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewDiff(t *testing.T) {
+	got, err := newDiff("2000-04-17", "2002-07-21T00:00:00.000Z", "", diffOptions{locale: "fr"})
+	if err != nil {
+		t.Fatalf("newDiff() failed: %v", err)
+	}
+	want := map[string]interface{}{
+		"years":  2,
+		"months": 3,
+		"weeks":  0,
+		"days":   4,
+		"format": "%Y %M %D",
+		"mode":   "years|months|days",
+		"start":  "2000-04-17T00:00:00Z",
+		"end":    "2002-07-21T00:00:00Z",
+		"text":   "2 ans 3 mois 4 jours",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newDiff() = %v, want %v", got, want)
+	}
+
+	got, err = newDiff("2021-03-21", "2022-03-20", "%Y %D", diffOptions{calendar: "persian", inclusiveEnd: true})
+	if err != nil {
+		t.Fatalf("newDiff() in Persian calendar failed: %v", err)
+	}
+	if got["text"] != "1 year" {
+		t.Errorf("newDiff() in Persian calendar = %q, want %q", got["text"], "1 year")
+	}
+}
+
+func TestLocalDate(t *testing.T) {
+	// new Date(2002, 6, 21) in Sydney is 2002-07-20T14:00:00.000Z in UTC
+	end := time.Date(2002, time.July, 21, 0, 0, 0, 0, time.FixedZone("AEST", 10*60*60))
+	if got := end.UTC().Format("2006-01-02"); got != "2002-07-20" {
+		t.Fatalf("UTC date of %v = %s, want 2002-07-20", end, got)
+	}
+
+	got := localDate(end.Year(), int(end.Month()), end.Day())
+	if got != "2002-07-21" {
+		t.Errorf("localDate(%v) = %s, want 2002-07-21", end, got)
+	}
+	d, err := newDiff(localDate(2000, 4, 17), got, "", diffOptions{})
+	if err != nil {
+		t.Fatalf("newDiff() failed: %v", err)
+	}
+	if d["text"] != "2 years 3 months 4 days" {
+		t.Errorf("newDiff() of local dates = %q, want %q", d["text"], "2 years 3 months 4 days")
+	}
+}
+
+func TestNewDiffFails(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		start  string
+		end    string
+		format string
+		opts   diffOptions
+		err    string
+	}{
+		{desc: "invalid date", start: "2000-02-30", end: "2002-07-21", err: `invalid date "2000-02-30"`},
+		{desc: "start after end", start: "2002-07-21", end: "2000-04-17", err: "start date is after end date"},
+		{desc: "unknown locale", start: "2000-04-17", end: "2002-07-21", opts: diffOptions{locale: "xx"}, err: `unknown locale "xx"`},
+		{desc: "unknown calendar", start: "2000-04-17", end: "2002-07-21", opts: diffOptions{calendar: "mayan"}, err: `unknown calendar "mayan"`},
+	}
+	for _, tC := range testCases {
+		_, err := newDiff(tC.start, tC.end, tC.format, tC.opts)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.err {
+			t.Errorf("newDiff() %s error = %q, want %q", tC.desc, err.Error(), tC.err)
+		}
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		units    [4]int
+		format   string
+		locale   string
+		expected string
+	}{
+		{desc: "format", units: [4]int{2, 3, 0, 4}, format: "%Y, %M and %D", expected: "2 years, 3 months and 4 days"},
+		{desc: "locale", units: [4]int{0, 0, 1, 2}, format: "%W %D", locale: "de", expected: "1 Woche 2 Tage"},
+		{desc: "zero units", units: [4]int{0, 3, 0, 4}, format: "%Y %M %D", expected: "3 months 4 days"},
+	}
+	for _, tC := range testCases {
+		got, err := formatDiff(tC.units, tC.format, tC.locale)
+		if err != nil {
+			t.Errorf("formatDiff() %s failed: %v", tC.desc, err)
+		} else if got != tC.expected {
+			t.Errorf("formatDiff() %s = %q, want %q", tC.desc, got, tC.expected)
+		}
+	}
+
+	if _, err := formatDiff([4]int{}, "%Q", ""); err == nil {
+		t.Errorf("want to fail due to unsupported verb")
+	}
+}
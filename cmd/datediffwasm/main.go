@@ -0,0 +1,89 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"math"
+	"syscall/js"
+)
+
+func main() {
+	js.Global().Set("datediff", js.ValueOf(map[string]interface{}{
+		"newDiff": js.FuncOf(jsNewDiff),
+		"format":  js.FuncOf(jsFormat),
+	}))
+	select {}
+}
+
+// jsNewDiff implements datediff.newDiff(start, end, format, options).
+func jsNewDiff(_ js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError("newDiff requires start and end dates")
+	}
+	var o diffOptions
+	if opts := arg(args, 3); opts.Type() == js.TypeObject {
+		o.locale = stringProp(opts, "locale")
+		o.calendar = stringProp(opts, "calendar")
+		o.inclusiveEnd = opts.Get("inclusiveEnd").Truthy()
+	}
+	d, err := newDiff(dateArg(args[0]), dateArg(args[1]), stringArg(arg(args, 2)), o)
+	if err != nil {
+		return jsError(err.Error())
+	}
+	return js.ValueOf(d)
+}
+
+// jsFormat implements datediff.format(diff, format, locale).
+func jsFormat(_ js.Value, args []js.Value) interface{} {
+	if len(args) < 2 || args[0].Type() != js.TypeObject {
+		return jsError("format requires diff object and format")
+	}
+	var units [4]int
+	for i, name := range []string{"years", "months", "weeks", "days"} {
+		if v := args[0].Get(name); v.Type() == js.TypeNumber {
+			units[i] = v.Int()
+		}
+	}
+	s, err := formatDiff(units, stringArg(args[1]), stringArg(arg(args, 2)))
+	if err != nil {
+		return jsError(err.Error())
+	}
+	return s
+}
+
+// arg returns i-th argument, it's undefined when there is no argument.
+func arg(args []js.Value, i int) js.Value {
+	if i < len(args) {
+		return args[i]
+	}
+	return js.Undefined()
+}
+
+// dateArg returns the date argument as a string, Date objects are
+// converted to their local dates, see localDate.
+func dateArg(v js.Value) string {
+	if v.Type() == js.TypeObject && v.Get("getFullYear").Type() == js.TypeFunction {
+		if math.IsNaN(v.Call("getTime").Float()) {
+			return v.Call("toString").String()
+		}
+		return localDate(v.Call("getFullYear").Int(), v.Call("getMonth").Int()+1, v.Call("getDate").Int())
+	}
+	return stringArg(v)
+}
+
+// stringArg returns the string argument, it's empty when the argument is
+// undefined or null.
+func stringArg(v js.Value) string {
+	if v.Type() == js.TypeString {
+		return v.String()
+	}
+	return ""
+}
+
+func stringProp(v js.Value, name string) string {
+	return stringArg(v.Get(name))
+}
+
+func jsError(msg string) js.Value {
+	return js.Global().Get("Error").New("datediff: " + msg)
+}
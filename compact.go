@@ -0,0 +1,131 @@
+package datediff
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errCompactLength = errors.New("invalid compact encoding length")
+
+// EncodeCompact encodes dates difference with a short fixed layout suitable
+// for storing large amount of dates differences as cache values:
+//
+//	mode and flags byte, the high nibble is the mode, the low nibble has
+//	  flags of the binary encoding, see binaryStart, binaryEnd and
+//	  binaryInclusive
+//	presence and width byte, the high nibble marks stored time units in the
+//	  same bits as the mode, the low nibble is the width code of values
+//	values of stored time units as little endian signed integers of
+//	  1 << width code bytes
+//	start and end dates, when they are known, see appendBinaryTime
+//	format bytes till the end of data
+//
+// Time units of the mode and other time units that have non-zero value are
+// stored. All values have the same width, the smallest one that fits the
+// values. For example, 2 years 3 months 4 days difference with "%Y %M %D"
+// format that was not calculated from dates takes 13 bytes.
+func (d Diff) EncodeCompact() []byte {
+	var present DiffMode
+	var width uint8
+	for _, u := range diffUnits {
+		n := d.value(u)
+		if d.mode&u == 0 && n == 0 {
+			continue
+		}
+		present |= u
+		for w := width; w < 3 && !fitsWidth(n, w); w++ {
+			width = w + 1
+		}
+	}
+
+	size := 1 << width
+	flags := d.binaryFlags()
+	b := make([]byte, 2, 2+4*size+2*binaryTimeLen+len(d.rawFormat))
+	b[0] = byte(d.mode) | flags
+	b[1] = byte(present) | width
+	var buf [8]byte
+	for _, u := range diffUnits {
+		if present&u == 0 {
+			continue
+		}
+		binary.LittleEndian.PutUint64(buf[:], uint64(d.value(u)))
+		b = append(b, buf[:size]...)
+	}
+	if flags&binaryStart != 0 {
+		b = appendBinaryTime(b, d.start)
+	}
+	if flags&binaryEnd != 0 {
+		b = appendBinaryTime(b, d.end)
+	}
+	return append(b, d.rawFormat...)
+}
+
+// DecodeCompact decodes dates difference encoded by EncodeCompact.
+func DecodeCompact(data []byte) (Diff, error) {
+	if len(data) < 2 {
+		return Diff{}, errCompactLength
+	}
+	mode := DiffMode(data[0] & 0xf0)
+	flags := data[0] & 0x0f
+	if flags&^binaryFlagsMask != 0 {
+		return Diff{}, fmt.Errorf("invalid compact encoding flags 0x%02x", flags)
+	}
+	present := DiffMode(data[1] & 0xf0)
+	width := data[1] & 0x0f
+	if width > 3 {
+		return Diff{}, errCompactLength
+	}
+	size := 1 << width
+	data = data[2:]
+
+	var diff Diff
+	for _, u := range diffUnits {
+		if present&u == 0 {
+			continue
+		}
+		if len(data) < size {
+			return Diff{}, errCompactLength
+		}
+		var buf [8]byte
+		copy(buf[:], data[:size])
+		v := int64(binary.LittleEndian.Uint64(buf[:]))
+		// sign extension of the value
+		shift := 64 - 8*size
+		v = v << shift >> shift
+		if int64(int(v)) != v {
+			return Diff{}, errCompactLength
+		}
+		diff.setValue(u, int(v))
+		data = data[size:]
+	}
+	data, err := diff.readBinaryDates(flags, data)
+	if err == errBinaryLength {
+		return Diff{}, errCompactLength
+	} else if err != nil {
+		return Diff{}, err
+	}
+
+	rawFormat := string(data)
+	mode, err = decodeMode(rawFormat, mode)
+	if err != nil {
+		return Diff{}, err
+	}
+	diff.rawFormat = rawFormat
+	diff.mode = mode
+	return diff, nil
+}
+
+// fitsWidth returns true when n fits into signed integer of 1 << width bytes.
+func fitsWidth(n int, width uint8) bool {
+	switch width {
+	case 0:
+		return n >= math.MinInt8 && n <= math.MaxInt8
+	case 1:
+		return n >= math.MinInt16 && n <= math.MaxInt16
+	case 2:
+		return n >= math.MinInt32 && n <= math.MaxInt32
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package datediff_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestCompact(t *testing.T) {
+	testCases, err := loadDatediffRecordsForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tC := range testCases {
+		desc := fmt.Sprintf("NewDiff(%s, %s, %s)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.format)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiff(tC.start, tC.end, tC.format)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testCompactRoundTrip(t, diff)
+		})
+
+		desc = fmt.Sprintf("NewDiffWithMode(%s, %s, %d)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.mode)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiffWithMode(tC.start, tC.end, tC.mode)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testCompactRoundTrip(t, diff)
+		})
+	}
+
+	testCompactRoundTrip(t, datediff.Diff{Years: -2, Weeks: 300})
+	testCompactRoundTrip(t, datediff.Diff{Days: -1 << 20})
+	testCompactRoundTrip(t, datediff.Diff{Months: 1 << 40})
+}
+
+func testCompactRoundTrip(t *testing.T, diff datediff.Diff) {
+	t.Helper()
+	data := diff.EncodeCompact()
+	got, err := datediff.DecodeCompact(data)
+	if err != nil {
+		t.Fatalf("DecodeCompact(%v) failed: %v", data, err)
+	}
+	if !got.Equal(diff) {
+		t.Errorf("DecodeCompact(%v) = %#v, want %#v", data, got, diff)
+	}
+	if got.String() != diff.String() {
+		t.Errorf("DecodeCompact(%v) String() = %s, want %s", data, got.String(), diff.String())
+	}
+}
+
+func TestEncodeCompact(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	withMode, err := datediff.NewDiffWithMode(start, end, datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	// start and end dates encoded as in the binary layout
+	dates := []byte{128, 208, 210, 143, 7, 0, 0, 128, 180, 182, 236, 7, 0, 0}
+	testCases := []struct {
+		diff     datediff.Diff
+		expected []byte
+	}{
+		{
+			diff:     mustNewDiff(t, start, end, "%Y %M %D"),
+			expected: append(append([]byte{211, 208, 3, 1, 1}, dates...), '%', 'Y', ' ', '%', 'M', ' ', '%', 'D'),
+		},
+		{diff: withMode, expected: append([]byte{19, 17, 0x66, 0x04}, dates...)},
+		{diff: datediff.Diff{}, expected: []byte{0, 0}},
+		{diff: datediff.Diff{Weeks: -1}, expected: []byte{0, 32, 0xff}},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.EncodeCompact(); string(got) != string(tC.expected) {
+			t.Errorf("EncodeCompact(%#v) = %v, want %v", tC.diff, got, tC.expected)
+		}
+	}
+}
+
+func TestDecodeCompactFails(t *testing.T) {
+	testCases := []struct {
+		data     []byte
+		expected string
+	}{
+		{data: nil, expected: "invalid compact encoding length"},
+		{data: []byte{16, 20}, expected: "invalid compact encoding length"},
+		{data: []byte{16, 17, 1}, expected: "invalid compact encoding length"},
+		{data: []byte{128, 128, 2, '%', 'X'}, expected: `format "%X" has unknown verb X`},
+		{data: []byte{64, 128, 2, '%', 'Y'}, expected: `mode 64 does not match format "%Y"`},
+		{data: []byte{8, 0}, expected: "invalid compact encoding flags 0x08"},
+		{data: []byte{3, 0, 2, 0}, expected: "invalid compact encoding length"},
+		{data: []byte{3, 0, 2, 0, 0, 0, 0, 0}, expected: "start date is after end date"},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.DecodeCompact(tC.data)
+		if err == nil {
+			t.Errorf("DecodeCompact(%v) = %#v, want to fail due to %s", tC.data, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("DecodeCompact(%v) failed: %v, want to fail due to %s", tC.data, err, tC.expected)
+		}
+	}
+}
+
+// benchmarkDiff is a typical dates difference stored in cache.
+var benchmarkDiff datediff.Diff
+
+func init() {
+	var err error
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2012, time.August, 3, 0, 0, 0, 0, time.UTC)
+	if benchmarkDiff, err = datediff.NewDiff(start, end, "%Y %M %D"); err != nil {
+		panic(err)
+	}
+}
+
+func BenchmarkEncodeCompact(b *testing.B) {
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data = benchmarkDiff.EncodeCompact()
+	}
+	b.ReportMetric(float64(len(data)), "bytes/diff")
+}
+
+func BenchmarkDecodeCompact(b *testing.B) {
+	data := benchmarkDiff.EncodeCompact()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := datediff.DecodeCompact(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalBinary(b *testing.B) {
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = benchmarkDiff.MarshalBinary()
+	}
+	b.ReportMetric(float64(len(data)), "bytes/diff")
+}
+
+func BenchmarkUnmarshalBinary(b *testing.B) {
+	data, _ := benchmarkDiff.MarshalBinary()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var d datediff.Diff
+		if err := d.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = json.Marshal(benchmarkDiff)
+	}
+	b.ReportMetric(float64(len(data)), "bytes/diff")
+}
+
+func BenchmarkMarshalText(b *testing.B) {
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = benchmarkDiff.MarshalText()
+	}
+	b.ReportMetric(float64(len(data)), "bytes/diff")
+}
@@ -0,0 +1,64 @@
+package datediff
+
+import "time"
+
+// CompiledFormat is the validated format of dates difference. It allows to
+// validate the format once, i.e in the package level variable, and create
+// dates differences without scanning the format again:
+//
+//	var ageFormat = datediff.MustCompileFormat("%Y %M %D")
+//
+//	func age(birthDate, now time.Time) (datediff.Diff, error) {
+//		return datediff.NewDiffWithFormat(birthDate, now, ageFormat)
+//	}
+//
+// The zero value of CompiledFormat has undefined mode.
+type CompiledFormat struct {
+	raw  string
+	mode DiffMode
+}
+
+// CompileFormat validates the format and returns the compiled format. See
+// NewDiff for the supported verbs.
+func CompileFormat(rawFormat string) (CompiledFormat, error) {
+	mode, err := unmarshal(rawFormat)
+	if err != nil {
+		return CompiledFormat{}, err
+	}
+	return CompiledFormat{raw: rawFormat, mode: mode}, nil
+}
+
+// MustCompileFormat is like CompileFormat but panics when the format is
+// invalid.
+func MustCompileFormat(rawFormat string) CompiledFormat {
+	f, err := CompileFormat(rawFormat)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Mode returns the mode defined by verbs of the format.
+func (f CompiledFormat) Mode() DiffMode {
+	return f.mode
+}
+
+// String returns the format.
+func (f CompiledFormat) String() string {
+	return f.raw
+}
+
+// NewDiffWithFormat creates Diff according to the compiled format as NewDiff
+// does. It returns error when start date is after end date, or the format
+// is the zero value.
+func NewDiffWithFormat(start, end time.Time, f CompiledFormat, opts ...Option) (Diff, error) {
+	if f.mode == 0 {
+		return Diff{}, ErrUndefinedMode
+	}
+	diff, err := newDiffWithOptions(start, end, f.mode, opts)
+	if err != nil {
+		return Diff{}, err
+	}
+	diff.rawFormat = f.raw
+	return diff, nil
+}
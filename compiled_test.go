@@ -0,0 +1,58 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestCompileFormat(t *testing.T) {
+	f, err := datediff.CompileFormat("%Y and %D")
+	if err != nil {
+		t.Fatalf("CompileFormat() failed: %v", err)
+	}
+	if got, want := f.Mode(), datediff.ModeYears|datediff.ModeDays; got != want {
+		t.Errorf("CompileFormat().Mode() = %s, want %s", got, want)
+	}
+	if got, want := f.String(), "%Y and %D"; got != want {
+		t.Errorf("CompileFormat().String() = %s, want %s", got, want)
+	}
+
+	for _, tC := range testInvalidFormat {
+		if _, err := datediff.CompileFormat(tC.format); err == nil {
+			t.Errorf("CompileFormat(%s) want to fail due to %s", tC.format, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("CompileFormat(%s) failed: %v, want to fail due to %s", tC.format, err, tC.expected)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustCompileFormat() want to panic due to invalid format")
+		}
+	}()
+	datediff.MustCompileFormat("%Q")
+}
+
+func TestNewDiffWithFormat(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.March, 16, 0, 0, 0, 0, time.UTC)
+	f := datediff.MustCompileFormat("%Y %M %D")
+
+	got, err := datediff.NewDiffWithFormat(start, end, f, datediff.WithInclusiveEnd())
+	if err != nil {
+		t.Fatalf("NewDiffWithFormat() failed: %v", err)
+	}
+	want, _ := datediff.NewDiff(start, end, "%Y %M %D", datediff.WithInclusiveEnd())
+	if !got.Equal(want) || got.RawFormat() != want.RawFormat() || got.Mode() != want.Mode() {
+		t.Errorf("NewDiffWithFormat() = %#v, want %#v", got, want)
+	}
+
+	if _, err := datediff.NewDiffWithFormat(end, start, f); err != datediff.ErrStartAfterEnd {
+		t.Errorf("NewDiffWithFormat() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+	if _, err := datediff.NewDiffWithFormat(start, end, datediff.CompiledFormat{}); err != datediff.ErrUndefinedMode {
+		t.Errorf("NewDiffWithFormat() error = %v, want %v", err, datediff.ErrUndefinedMode)
+	}
+}
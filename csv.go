@@ -0,0 +1,62 @@
+package datediff
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CSVHeader is the header of CSV record produced by Diff.Record. The columns
+// follow the layout of dates difference columns in testdata/datediff.csv.
+var CSVHeader = []string{"mode", "format", "years", "months", "weeks", "days"}
+
+// Record returns CSV record of dates difference with CSVHeader columns, i.e
+// ["208", "%Y %M %D", "2", "3", "0", "4"]. The mode is written as a number.
+func (d Diff) Record() []string {
+	return []string{
+		strconv.Itoa(int(d.mode)),
+		d.rawFormat,
+		strconv.Itoa(d.Years),
+		strconv.Itoa(d.Months),
+		strconv.Itoa(d.Weeks),
+		strconv.Itoa(d.Days),
+	}
+}
+
+// ParseRecord parses CSV record produced by Diff.Record. When format is
+// provided the mode is derived from it, the provided mode should match the
+// format. Empty mode column is allowed when format is provided.
+func ParseRecord(record []string) (Diff, error) {
+	if len(record) != len(CSVHeader) {
+		return Diff{}, fmt.Errorf("record has %d fields, want %d", len(record), len(CSVHeader))
+	}
+
+	var rawMode uint64
+	if record[0] != "" {
+		var err error
+		if rawMode, err = strconv.ParseUint(record[0], 10, 8); err != nil {
+			return Diff{}, fmt.Errorf("record field mode has invalid value %q", record[0])
+		}
+	}
+
+	var values [4]int
+	for i, v := range record[2:] {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return Diff{}, fmt.Errorf("record field %s has invalid value %q", CSVHeader[i+2], v)
+		}
+		values[i] = n
+	}
+
+	mode, err := decodeMode(record[1], DiffMode(rawMode))
+	if err != nil {
+		return Diff{}, err
+	}
+	return Diff{
+		Years:     values[0],
+		Months:    values[1],
+		Weeks:     values[2],
+		Days:      values[3],
+		rawFormat: record[1],
+		mode:      mode,
+	}, nil
+}
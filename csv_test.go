@@ -0,0 +1,88 @@
+package datediff_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/antklim/datediff"
+)
+
+func TestRecord(t *testing.T) {
+	f, err := os.Open("testdata/datediff.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	rawRecords, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rr := range rawRecords {
+		tC, err := loadDatediffRecord(rr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc := fmt.Sprintf("NewDiff(%s, %s, %s)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.format)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiff(tC.start, tC.end, tC.format)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			expected := rr[modeFld : daysFld+1]
+			got := diff.Record()
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Record() = %q, want %q", got, expected)
+			}
+
+			parsed, err := datediff.ParseRecord(got)
+			if err != nil {
+				t.Fatalf("ParseRecord(%q) failed: %v", got, err)
+			}
+			if !parsed.Equal(diff) {
+				t.Errorf("ParseRecord(%q) = %#v, want %#v", got, parsed, diff)
+			}
+			if parsed.String() != tC.print {
+				t.Errorf("ParseRecord(%q) String() = %s, want %s", got, parsed.String(), tC.print)
+			}
+		})
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	got, err := datediff.ParseRecord([]string{"", "%Y and %D", "2", "0", "0", "4"})
+	if err != nil {
+		t.Fatalf("ParseRecord() failed: %v", err)
+	}
+	expected := "2 years and 4 days"
+	if got.String() != expected {
+		t.Errorf("ParseRecord() String() = %s, want %s", got.String(), expected)
+	}
+}
+
+func TestParseRecordFails(t *testing.T) {
+	testCases := []struct {
+		record   []string
+		expected string
+	}{
+		{record: []string{"128", "%Y", "2"}, expected: "record has 3 fields, want 6"},
+		{record: []string{"x", "%Y", "2", "0", "0", "0"}, expected: `record field mode has invalid value "x"`},
+		{record: []string{"128", "%Y", "2", "0", "a", "0"}, expected: `record field weeks has invalid value "a"`},
+		{record: []string{"128", "%X", "2", "0", "0", "0"}, expected: `format "%X" has unknown verb X`},
+		{record: []string{"64", "%Y", "2", "0", "0", "0"}, expected: `mode 64 does not match format "%Y"`},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseRecord(tC.record)
+		if err == nil {
+			t.Errorf("ParseRecord(%q) = %#v, want to fail due to %s", tC.record, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParseRecord(%q) failed: %v, want to fail due to %s", tC.record, err, tC.expected)
+		}
+	}
+}
@@ -12,10 +12,27 @@ const (
 )
 
 var (
-	errStartIsAfterEnd   = errors.New("start date is after end date")
-	errUndefinedDiffMode = errors.New("undefined dates difference mode")
+	// ErrStartAfterEnd is returned when start date is after end date.
+	ErrStartAfterEnd = errors.New("start date is after end date")
+	// ErrUndefinedMode is returned when dates difference mode does not have
+	// any time units, i.e the format does not contain any of the supported
+	// "verbs".
+	ErrUndefinedMode = errors.New("undefined dates difference mode")
 )
 
+// FormatError describes the format that contains unsupported "verb".
+type FormatError struct {
+	Format string // the format
+	Verb   rune   // unsupported verb, 0 when the format ends with "%"
+}
+
+func (e *FormatError) Error() string {
+	if e.Verb == 0 {
+		return fmt.Sprintf("format %q ends with incomplete verb", e.Format)
+	}
+	return fmt.Sprintf("format %q has unknown verb %c", e.Format, e.Verb)
+}
+
 type DiffMode uint8
 
 const (
@@ -25,6 +42,9 @@ const (
 	ModeDays
 )
 
+// ModeAll combines all time units.
+const ModeAll = ModeYears | ModeMonths | ModeWeeks | ModeDays
+
 func unmarshal(rawFormat string) (DiffMode, error) {
 	var mode DiffMode
 	end := len(rawFormat)
@@ -38,6 +58,9 @@ func unmarshal(rawFormat string) (DiffMode, error) {
 		}
 		// process verb
 		i++
+		if i >= end {
+			return 0, &FormatError{Format: rawFormat}
+		}
 		switch c := rawFormat[i]; c {
 		case 'Y', 'y':
 			mode |= ModeYears
@@ -48,18 +71,40 @@ func unmarshal(rawFormat string) (DiffMode, error) {
 		case 'D', 'd':
 			mode |= ModeDays
 		default:
-			return 0, fmt.Errorf("format %q has unknown verb %c", rawFormat, c)
+			return 0, &FormatError{Format: rawFormat, Verb: rune(c)}
 		}
 	}
 
 	if mode == 0 {
-		return 0, errUndefinedDiffMode
+		return 0, ErrUndefinedMode
 	}
 
 	return mode, nil
 }
 
+// ParseFormat validates the format and returns the mode defined by its
+// verbs, i.e "%Y and %D" defines ModeYears | ModeDays. See NewDiff for the
+// supported verbs.
+func ParseFormat(rawFormat string) (DiffMode, error) {
+	return unmarshal(rawFormat)
+}
+
+// MustParseFormat is like ParseFormat but panics when the format is invalid.
+// It simplifies initialization of package level variables.
+func MustParseFormat(rawFormat string) DiffMode {
+	mode, err := ParseFormat(rawFormat)
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}
+
 // Diff describes dates difference in years, months, weeks, and days.
+//
+// The zero value of Diff is a valid dates difference that has 0 values of all
+// time units, so Diff can be embedded in structs without a constructor call.
+// Its String returns empty string, or "0 days" with Formatter.ShowZero, and
+// Format works with any valid format.
 type Diff struct {
 	Years     int
 	Months    int
@@ -67,6 +112,12 @@ type Diff struct {
 	Days      int
 	rawFormat string // initial format, i.e "%Y and %M"
 	mode      DiffMode
+	start     time.Time // start date of the dates difference, if known
+	end       time.Time // end date of the dates difference, if known
+	inclusive bool      // end date is included, see WithInclusiveEnd
+	formatter Formatter // formatter used by String, i.e locale
+	excluded  int       // days excluded by WithExclusions
+	zone      ZonePolicy
 }
 
 // NewDiff creates Diff according to the provided format.
@@ -90,14 +141,16 @@ type Diff struct {
 //	fmt.Println(diff2) // 34 month
 //	fmt.Println(diff3) // 2 years 10 months
 //
+// Options configure the calculation, i.e WithInclusiveEnd or WithRounding.
+//
 // NewDiff returns error in the following cases:
 //
 //	start date is after end date
 //	format contains unsupported "verb"
 //	undefined dates difference mode (it happens when the format does not contain any of the supported "verbs")
-func NewDiff(start, end time.Time, rawFormat string) (Diff, error) {
+func NewDiff(start, end time.Time, rawFormat string, opts ...Option) (Diff, error) {
 	if start.After(end) {
-		return Diff{}, errStartIsAfterEnd
+		return Diff{}, ErrStartAfterEnd
 	}
 
 	mode, err := unmarshal(rawFormat)
@@ -105,12 +158,25 @@ func NewDiff(start, end time.Time, rawFormat string) (Diff, error) {
 		return Diff{}, err
 	}
 
-	diff := newDiff(start, end, mode)
+	diff, err := newDiffWithOptions(start, end, mode, opts)
+	if err != nil {
+		return Diff{}, err
+	}
 	diff.rawFormat = rawFormat
 
 	return diff, nil
 }
 
+// MustNewDiff is like NewDiff but panics on error. It's intended for package
+// level variables and tests where dates and format are constants.
+func MustNewDiff(start, end time.Time, rawFormat string, opts ...Option) Diff {
+	diff, err := NewDiff(start, end, rawFormat, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return diff
+}
+
 // NewDiffWithMode creates Diff according to the provided mode.
 // There are four modes defined:
 //
@@ -130,15 +196,13 @@ func NewDiff(start, end time.Time, rawFormat string) (Diff, error) {
 //	fmt.Println(diff2) // 34 month
 //	fmt.Println(diff3) // 2 years 10 months
 //
+// Options configure the calculation as for NewDiff.
+//
 // NewDiffWithMode returns error in the following cases:
 //
 //	start date is after end date
-func NewDiffWithMode(start, end time.Time, mode DiffMode) (Diff, error) {
-	if start.After(end) {
-		return Diff{}, errStartIsAfterEnd
-	}
-	diff := newDiff(start, end, mode)
-	return diff, nil
+func NewDiffWithMode(start, end time.Time, mode DiffMode, opts ...Option) (Diff, error) {
+	return newDiffWithOptions(start, end, mode, opts)
 }
 
 // Equal returns true when two dates differences are equal.
@@ -149,107 +213,420 @@ func (d Diff) Equal(other Diff) bool {
 		d.Days == other.Days
 }
 
+// IsZero returns true when all time units values of dates difference are 0.
+func (d Diff) IsZero() bool {
+	return d.Years == 0 && d.Months == 0 && d.Weeks == 0 && d.Days == 0
+}
+
+// Validate checks consistency of dates difference, i.e one that was
+// constructed as a struct literal. Dates difference without the mode and the
+// format can have values of any time units.
+//
+// Validate returns error in the following cases:
+//
+//	time unit has negative value
+//	time unit has non 0 value and it's not in the mode
+//	format does not match the mode, or contains unsupported "verb"
+//	start date is after end date
+func (d Diff) Validate() error {
+	mode, err := decodeMode(d.rawFormat, d.mode)
+	if err != nil {
+		return err
+	}
+	for _, u := range diffUnits {
+		v := d.value(u)
+		if v < 0 {
+			return fmt.Errorf("dates difference has negative %s", u)
+		}
+		if v != 0 && mode != 0 && mode&u == 0 {
+			return fmt.Errorf("dates difference has %s not in mode %s", u, mode)
+		}
+	}
+	if d.start.After(d.end) && !d.end.IsZero() {
+		return ErrStartAfterEnd
+	}
+	return nil
+}
+
+// Mode returns time units the dates difference was calculated in. It's
+// derived from the format when dates difference was created by NewDiff.
+func (d Diff) Mode() DiffMode {
+	return d.mode
+}
+
+// RawFormat returns the format provided at initialization of dates
+// difference. It's empty when dates difference was created by
+// NewDiffWithMode.
+func (d Diff) RawFormat() string {
+	return d.rawFormat
+}
+
+// WithFormat returns a copy of the dates difference with the new format used
+// by String and StringWithZeros, i.e to reorder time units or change the
+// words between them. Time units values are not recalculated, so the format
+// should define the same time units as the mode of dates difference. Dates
+// difference without the mode, i.e a struct literal, gets the mode of the
+// format.
+//
+// WithFormat returns error in the following cases:
+//
+//	format contains unsupported "verb"
+//	undefined dates difference mode
+//	format does not match the mode of dates difference
+func (d Diff) WithFormat(rawFormat string) (Diff, error) {
+	mode, err := unmarshal(rawFormat)
+	if err != nil {
+		return Diff{}, err
+	}
+	if d.mode != 0 && d.mode != mode {
+		return Diff{}, fmt.Errorf("format %q does not match mode %s", rawFormat, d.mode)
+	}
+	d.rawFormat = rawFormat
+	d.mode = mode
+	return d, nil
+}
+
+// WithMode returns a copy of the dates difference with the mode, i.e to
+// restore dates difference created by NewDiffWithMode from its values. Time
+// units values are not recalculated. Use WithFormat to restore dates
+// difference created by NewDiff.
+//
+// WithMode returns error in the following cases:
+//
+//	undefined dates difference mode
+//	mode does not match the format of dates difference
+func (d Diff) WithMode(mode DiffMode) (Diff, error) {
+	if mode == 0 || !mode.valid() {
+		return Diff{}, ErrUndefinedMode
+	}
+	if d.rawFormat != "" && d.mode != mode {
+		return Diff{}, fmt.Errorf("mode %s does not match format %q", mode, d.rawFormat)
+	}
+	d.mode = mode
+	return d, nil
+}
+
+// WithDates returns a copy of the dates difference with the start and end
+// dates it was calculated from, i.e to restore dates difference decoded from
+// the storage. The end date is included when inclusiveEnd is true, as
+// WithInclusiveEnd option does. Time units values are not recalculated, so
+// they should be dates difference between the dates. Dates are used by Start,
+// End, Duration and Compare.
+//
+// WithDates returns ErrStartAfterEnd when start date is after end date.
+func (d Diff) WithDates(start, end time.Time, inclusiveEnd bool) (Diff, error) {
+	if start.After(end) {
+		return Diff{}, ErrStartAfterEnd
+	}
+	d.start, d.end, d.inclusive = start, end, inclusiveEnd
+	return d, nil
+}
+
+// Start returns the start date the dates difference was calculated from. It
+// returns zero time when dates difference was not calculated from dates, i.e
+// it was created as a struct literal or parsed from a string.
+func (d Diff) Start() time.Time {
+	return d.start
+}
+
+// End returns the end date the dates difference was calculated to, as it
+// was provided. It returns zero time when dates difference was not calculated
+// from dates.
+func (d Diff) End() time.Time {
+	return d.end
+}
+
+// InclusiveEnd returns true when the end date is included in dates
+// difference, see WithInclusiveEnd.
+func (d Diff) InclusiveEnd() bool {
+	return d.inclusive
+}
+
+// rangeEnd returns the end of the range of dates the dates difference was
+// calculated from, it's the day after the end date when the end date is
+// included.
+func (d Diff) rangeEnd() time.Time {
+	if d.inclusive && !d.end.IsZero() {
+		return d.end.AddDate(0, 0, 1)
+	}
+	return d.end
+}
+
+// ZonePolicy returns the zone policy the dates difference was calculated
+// with, the location of its day boundaries is the location of Start.
+func (d Diff) ZonePolicy() ZonePolicy {
+	return d.zone
+}
+
+// Contains returns true when the date is within the range of dates the dates
+// difference was calculated from. The start date is always included, the end
+// date is included when inclusive is true. It returns false when dates
+// difference was not calculated from dates.
+func (d Diff) Contains(t time.Time, inclusive bool) bool {
+	if !d.hasDates() {
+		return false
+	}
+	return contains(d.start, d.rangeEnd(), t, inclusive)
+}
+
 // Format formats dates difference accordig to provided format.
 func (d Diff) Format(rawFormat string) (string, error) {
-	_, err := unmarshal(rawFormat)
-	if err != nil {
-		return "", err
+	if rawFormat == "" {
+		return "", ErrUndefinedMode
 	}
-	return format(d, rawFormat), nil
+	return Formatter{}.Format(d, rawFormat)
 }
 
 // FormatWithZeros formats dates difference accordig to provided format.
 func (d Diff) FormatWithZeros(rawFormat string) (string, error) {
-	_, err := unmarshal(rawFormat)
-	if err != nil {
-		return "", err
+	if rawFormat == "" {
+		return "", ErrUndefinedMode
 	}
-	return formatWithZeros(d, rawFormat), nil
+	return Formatter{WithZeros: true}.Format(d, rawFormat)
 }
 
 // String formats dates difference according to the format provided at
 // initialization of dates difference. Time units that have 0 value omitted.
 func (d Diff) String() string {
-	return d.format(false)
+	return d.formatter.String(d)
+}
+
+// AppendString appends dates difference formatted as String does to b and
+// returns the extended buffer, see Formatter.AppendString.
+func (d Diff) AppendString(b []byte) []byte {
+	return d.formatter.AppendString(b, d)
 }
 
 // StringWithZeros formats dates difference according to the format provided at
 // initialization of dates difference. It keeps time units values that are 0.
 func (d Diff) StringWithZeros() string {
-	return d.format(true)
+	f := d.formatter
+	f.WithZeros = true
+	return f.String(d)
+}
+
+// WithLocale returns a copy of the dates difference that uses the locale in
+// String and StringWithZeros. The original dates difference is not modified,
+// so variants can be safely derived from a shared dates difference by
+// concurrent code.
+func (d Diff) WithLocale(l *Locale) Diff {
+	d.formatter.Locale = l
+	return d
+}
+
+// WithZeros returns a copy of the dates difference that keeps time units
+// with 0 value in String, as StringWithZeros does.
+func (d Diff) WithZeros() Diff {
+	d.formatter.WithZeros = true
+	return d
+}
+
+// WithMaxUnits returns a copy of the dates difference that shows at most n
+// most significant time units in String, i.e "2 years 3 months" instead of
+// "2 years 3 months 5 days" when n is 2. See Formatter.MaxUnits.
+func (d Diff) WithMaxUnits(n int) Diff {
+	d.formatter.MaxUnits = n
+	return d
+}
+
+// WithFormatter returns a copy of the dates difference that uses the
+// formatter in String. StringWithZeros uses the formatter with WithZeros set.
+func (d Diff) WithFormatter(f Formatter) Diff {
+	d.formatter = f
+	return d
 }
 
-func (d Diff) format(withZeros bool) string {
-	if d.rawFormat == "" {
-		return formatMode(d, d.mode, withZeros)
+// diffUnits are time units in the order of significance.
+var diffUnits = []DiffMode{ModeYears, ModeMonths, ModeWeeks, ModeDays}
+
+// Units returns the iterator over time units of the dates difference mode and
+// their values in the order of significance, i.e ModeYears and 2, then
+// ModeDays and 4 for 2 years 4 days difference calculated in years and days.
+// All time units are yielded when the mode is not defined, i.e dates
+// difference is a struct literal. It allows to render dates difference
+// without switching over its fields:
+//
+//	d.Units()(func(unit DiffMode, v int) bool {
+//		fmt.Printf("%s: %d\n", unit, v)
+//		return true
+//	})
+//
+// The iterator has the signature of iter.Seq2[DiffMode, int], in Go 1.23 and
+// later it can be used with range-over-func.
+func (d Diff) Units() func(yield func(DiffMode, int) bool) {
+	return func(yield func(DiffMode, int) bool) {
+		mode := d.mode
+		if mode == 0 {
+			mode = ModeAll
+		}
+		for _, u := range diffUnits {
+			if mode&u != 0 && !yield(u, d.value(u)) {
+				return
+			}
+		}
+	}
+}
+
+// value returns the value of the time unit of the mode.
+func (d Diff) value(unit DiffMode) int {
+	switch unit {
+	case ModeYears:
+		return d.Years
+	case ModeMonths:
+		return d.Months
+	case ModeWeeks:
+		return d.Weeks
+	case ModeDays:
+		return d.Days
+	}
+	return 0
+}
+
+// setValue sets the value of the time unit of the mode.
+func (d *Diff) setValue(unit DiffMode, n int) {
+	switch unit {
+	case ModeYears:
+		d.Years = n
+	case ModeMonths:
+		d.Months = n
+	case ModeWeeks:
+		d.Weeks = n
+	case ModeDays:
+		d.Days = n
+	}
+}
+
+func newDiffWithOptions(start, end time.Time, mode DiffMode, opts []Option) (Diff, error) {
+	if start.After(end) {
+		return Diff{}, ErrStartAfterEnd
 	}
-	if withZeros {
-		return formatWithZeros(d, d.rawFormat)
+	o := newOptions(opts)
+	start, end = o.zoneDates(start, end)
+	calcEnd := o.leapDayEnd(start, o.rangeEnd(end), mode)
+	diff := newCalendarDiff(o.calendar, start, calcEnd, mode)
+	if len(o.exclusions) == 0 {
+		diff = o.round(diff, start, calcEnd)
+	} else if mode&(ModeYears|ModeMonths) != 0 {
+		return Diff{}, errExclusionsMode
+	} else {
+		diff = o.exclude(diff, start, calcEnd)
 	}
-	return format(d, d.rawFormat)
+	diff.end = end
+	diff.inclusive = o.inclusiveEnd
+	diff.zone = o.zone
+	diff.formatter.Locale = o.locale
+	return diff, nil
 }
 
 func newDiff(start, end time.Time, mode DiffMode) Diff {
-	diff := Diff{mode: mode}
+	return newCalendarDiff(GregorianCalendar{}, start, end, mode)
+}
+
+func newCalendarDiff(c Calendar, start, end time.Time, mode DiffMode) Diff {
+	if isGregorian(c) {
+		return gregorianDiff(start, end, mode)
+	}
+	diff := Diff{mode: mode, start: start, end: end}
 
 	if mode&ModeYears != 0 {
-		diff.Years = fullYearsDiff(start, end)
-		start = start.AddDate(diff.Years, 0, 0)
+		diff.Years = fullYearsDiff(c, start, end)
+		start = c.AddYears(start, diff.Years)
 	}
 
 	if mode&ModeMonths != 0 {
-		// getting to the closest year to the end date to reduce
-		// amount of the interations during the full month calculation
-		var years int
-		if mode&ModeYears == 0 {
-			years = fullYearsDiff(start, end)
-		}
-		months := fullMonthsDiff(start.AddDate(years, 0, 0), end)
-		diff.Months = years*monthsInYear + months
-		start = start.AddDate(0, diff.Months, 0)
+		// months are counted one by one, since years of other calendars
+		// can have other than 12 months, i.e leap years of lunisolar
+		// calendars
+		diff.Months = fullMonthsDiff(c, start, end)
+		start = c.AddMonths(start, diff.Months)
 	}
 
 	if mode&ModeWeeks != 0 {
-		diff.Weeks = fullWeeksDiff(start, end)
-		start = start.AddDate(0, 0, diff.Weeks*daysInWeek)
+		diff.Weeks = fullWeeksDiff(c, start, end)
+		start = c.AddDays(start, diff.Weeks*daysInWeek)
 	}
 
 	if mode&ModeDays != 0 {
-		diff.Days = fullDaysDiff(start, end)
+		diff.Days = fullDaysDiff(c, start, end)
 	}
 
 	return diff
 }
 
-func fullYearsDiff(start, end time.Time) (years int) {
+// isGregorian reports whether the calendar has dates of the Gregorian
+// calendar, so dates difference can be calculated with day numbers.
+func isGregorian(c Calendar) bool {
+	switch c.(type) {
+	case GregorianCalendar, ThaiCalendar:
+		return true
+	}
+	return false
+}
+
+// gregorianDiff calculates dates difference of the Gregorian calendar with
+// integer arithmetic of day numbers, see civilDiff.
+func gregorianDiff(start, end time.Time, mode DiffMode) Diff {
+	d := civilDiff(start, end, mode)
+	return Diff{
+		Years:  int(d.Years),
+		Months: int(d.Months),
+		Weeks:  int(d.Weeks),
+		Days:   int(d.Days),
+		mode:   mode,
+		start:  start,
+		end:    end,
+	}
+}
+
+// fullYearsDiff starts from the difference of Gregorian years of the dates,
+// that is exact for the Gregorian calendar and close enough for others.
+func fullYearsDiff(c Calendar, start, end time.Time) (years int) {
 	years = end.Year() - start.Year()
-	if start.AddDate(years, 0, 0).After(end) {
+	for years > 0 && c.Compare(c.AddYears(start, years), end) > 0 {
 		years--
 	}
+	for c.Compare(c.AddYears(start, years+1), end) <= 0 {
+		years++
+	}
 	return
 }
 
-func fullMonthsDiff(start, end time.Time) (months int) {
-	for start.AddDate(0, months+1, 0).Before(end) ||
-		start.AddDate(0, months+1, 0).Equal(end) {
+// fullMonthsDiff calculates months of the Gregorian calendar from years and
+// months of the dates. The date advanced by these months can be after the end
+// date due to normalization of the day overflow, i.e January 31 plus 1 month
+// is March 2 or 3, then months are reduced. Other calendars advance the date
+// month by month.
+func fullMonthsDiff(c Calendar, start, end time.Time) (months int) {
+	if isGregorian(c) {
+		return int(newCivilDate(start).fullMonths(newCivilDate(end.In(start.Location()))))
+	}
+	for c.Compare(c.AddMonths(start, months+1), end) <= 0 {
 		months++
 	}
 	return
 }
 
-func fullWeeksDiff(start, end time.Time) (weeks int) {
+// fullWeeksDiff calculates weeks of the Gregorian calendar from full days.
+// Other calendars advance the date week by week.
+func fullWeeksDiff(c Calendar, start, end time.Time) (weeks int) {
+	if isGregorian(c) {
+		return fullDaysDiff(c, start, end) / daysInWeek
+	}
 	days := daysInWeek
-	for start.AddDate(0, 0, days).Before(end) ||
-		start.AddDate(0, 0, days).Equal(end) {
+	for c.Compare(c.AddDays(start, days), end) <= 0 {
 		weeks++
 		days += daysInWeek
 	}
 	return
 }
 
-func fullDaysDiff(start, end time.Time) (days int) {
-	for start.AddDate(0, 0, days+1).Before(end) ||
-		start.AddDate(0, 0, days+1).Equal(end) {
+// fullDaysDiff calculates days of the Gregorian calendar from day numbers of
+// the dates. Other calendars advance the date day by day.
+func fullDaysDiff(c Calendar, start, end time.Time) (days int) {
+	if isGregorian(c) {
+		return int(newCivilDate(start).fullDays(newCivilDate(end.In(start.Location()))))
+	}
+	for c.Compare(c.AddDays(start, days+1), end) <= 0 {
 		days++
 	}
 	return
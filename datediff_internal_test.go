@@ -0,0 +1,351 @@
+package datediff
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+// loopFullDaysDiff is the reference implementation of fullDaysDiff that
+// advances the date day by day.
+func loopFullDaysDiff(start, end time.Time) (days int) {
+	for !start.AddDate(0, 0, days+1).After(end) {
+		days++
+	}
+	return
+}
+
+// loopFullWeeksDiff is the reference implementation of fullWeeksDiff that
+// advances the date week by week.
+func loopFullWeeksDiff(start, end time.Time) (weeks int) {
+	for !start.AddDate(0, 0, (weeks+1)*daysInWeek).After(end) {
+		weeks++
+	}
+	return
+}
+
+// loopFullMonthsDiff is the reference implementation of fullMonthsDiff that
+// advances the date month by month.
+func loopFullMonthsDiff(start, end time.Time) (months int) {
+	for !start.AddDate(0, months+1, 0).After(end) {
+		months++
+	}
+	return
+}
+
+// testLocations returns locations to cross-check algorithms, including ones
+// with daylight saving time when time zone database is available.
+func testLocations(t *testing.T) []*time.Location {
+	locs := []*time.Location{time.UTC, time.FixedZone("UTC-9:30", -(9*60+30)*60)}
+	for _, name := range []string{"America/New_York", "Australia/Lord_Howe", "Europe/London", "Asia/Tehran",
+		"America/Havana", "America/Sao_Paulo", "Pacific/Apia"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Logf("LoadLocation(%s) failed: %v", name, err)
+			continue
+		}
+		locs = append(locs, loc)
+	}
+	return locs
+}
+
+// randomRanges returns random ranges of dates up to the maximum number of
+// days long.
+func randomRanges(t *testing.T, n, maxDays int) [][2]time.Time {
+	r := rand.New(rand.NewSource(1))
+	var ranges [][2]time.Time
+	for _, loc := range testLocations(t) {
+		for i := 0; i < n; i++ {
+			start := time.Date(1950+r.Intn(100), time.Month(1+r.Intn(12)), 1+r.Intn(31),
+				r.Intn(24), r.Intn(60), 0, 0, loc)
+			end := start.Add(time.Duration(r.Int63n(int64(maxDays) * int64(24*time.Hour))))
+			ranges = append(ranges, [2]time.Time{start, end})
+		}
+	}
+	return ranges
+}
+
+func TestFullDaysDiff(t *testing.T) {
+	for _, r := range randomRanges(t, 1000, 3*365) {
+		start, end := r[0], r[1]
+		if got, want := fullDaysDiff(GregorianCalendar{}, start, end), loopFullDaysDiff(start, end); got != want {
+			t.Errorf("fullDaysDiff(%s, %s) = %d, want %d", start, end, got, want)
+		}
+	}
+}
+
+func TestFullWeeksDiff(t *testing.T) {
+	for _, r := range randomRanges(t, 1000, 3*365) {
+		start, end := r[0], r[1]
+		if got, want := fullWeeksDiff(GregorianCalendar{}, start, end), loopFullWeeksDiff(start, end); got != want {
+			t.Errorf("fullWeeksDiff(%s, %s) = %d, want %d", start, end, got, want)
+		}
+	}
+}
+
+func TestFullMonthsDiff(t *testing.T) {
+	for _, r := range randomRanges(t, 1000, 30*365) {
+		start, end := r[0], r[1]
+		if got, want := fullMonthsDiff(GregorianCalendar{}, start, end), loopFullMonthsDiff(start, end); got != want {
+			t.Errorf("fullMonthsDiff(%s, %s) = %d, want %d", start, end, got, want)
+		}
+	}
+
+	// day overflow of the last days of months
+	for _, start := range []time.Time{
+		time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 29, 12, 0, 0, 0, time.UTC),
+		time.Date(2020, time.August, 31, 0, 0, 0, 0, time.UTC),
+	} {
+		for days := 0; days < 120; days++ {
+			end := start.AddDate(0, 0, days)
+			if got, want := fullMonthsDiff(GregorianCalendar{}, start, end), loopFullMonthsDiff(start, end); got != want {
+				t.Errorf("fullMonthsDiff(%s, %s) = %d, want %d", start, end, got, want)
+			}
+		}
+	}
+}
+
+// loopDiff is the reference implementation of gregorianDiff that advances
+// the date unit by unit.
+func loopDiff(start, end time.Time, mode DiffMode) Diff {
+	diff := Diff{mode: mode, start: start, end: end}
+	if mode&ModeYears != 0 {
+		diff.Years = loopFullMonthsDiff(start, end) / monthsInYear
+		start = start.AddDate(diff.Years, 0, 0)
+	}
+	if mode&ModeMonths != 0 {
+		var years int
+		if mode&ModeYears == 0 {
+			years = loopFullMonthsDiff(start, end) / monthsInYear
+		}
+		diff.Months = years*monthsInYear + loopFullMonthsDiff(start.AddDate(years, 0, 0), end)
+		start = start.AddDate(0, diff.Months, 0)
+	}
+	if mode&ModeWeeks != 0 {
+		diff.Weeks = loopFullWeeksDiff(start, end)
+		start = start.AddDate(0, 0, diff.Weeks*daysInWeek)
+	}
+	if mode&ModeDays != 0 {
+		diff.Days = loopFullDaysDiff(start, end)
+	}
+	return diff
+}
+
+func TestGregorianDiff(t *testing.T) {
+	ranges := randomRanges(t, 300, 10*365)
+
+	// dates on the same day of daylight saving time transitions
+	for _, loc := range testLocations(t) {
+		for _, day := range []time.Time{
+			time.Date(2021, time.March, 14, 0, 0, 0, 0, loc),
+			time.Date(2021, time.November, 7, 0, 0, 0, 0, loc),
+			time.Date(2021, time.April, 4, 0, 0, 0, 0, loc),
+			time.Date(2021, time.October, 3, 0, 0, 0, 0, loc),
+		} {
+			for m := 0; m < 24*60; m += 15 {
+				end := day.Add(time.Duration(m) * time.Minute)
+				ranges = append(ranges, [2]time.Time{end.AddDate(0, -1, -8), end})
+				ranges = append(ranges, [2]time.Time{day.AddDate(0, 0, -1).Add(time.Duration(m) * time.Minute), day.Add(2 * time.Hour)})
+			}
+		}
+	}
+
+	// the date advanced in the daylight saving time gap, at skipped midnight
+	// or skipped day
+	for _, tC := range []struct {
+		loc      string
+		start    [6]int
+		end      [6]int
+		mode     DiffMode
+		expected string
+	}{
+		{
+			loc:      "Europe/London",
+			start:    [6]int{2014, 9, 27, 1, 59, 6},
+			end:      [6]int{2016, 4, 17, 1, 59, 6},
+			mode:     ModeMonths | ModeDays,
+			expected: "18 months 20 days",
+		},
+		{
+			loc:      "Asia/Tehran",
+			start:    [6]int{1996, 3, 22, 0, 29, 27},
+			end:      [6]int{2001, 5, 13, 0, 29, 27},
+			mode:     ModeYears | ModeMonths | ModeDays,
+			expected: "5 years 1 month 20 days",
+		},
+		{
+			loc:      "America/Havana",
+			start:    [6]int{2011, 12, 1, 0, 30, 0},
+			end:      [6]int{2012, 3, 31, 23, 30, 0},
+			mode:     ModeMonths | ModeDays,
+			expected: "4 months",
+		},
+		{
+			loc:      "America/Havana",
+			start:    [6]int{1993, 2, 20, 0, 0, 0},
+			end:      [6]int{1993, 4, 3, 23, 30, 0},
+			mode:     ModeAll,
+			expected: "1 month 2 weeks 1 day",
+		},
+		{
+			loc:      "Pacific/Apia",
+			start:    [6]int{2011, 11, 29, 12, 0, 0},
+			end:      [6]int{2011, 12, 31, 11, 0, 0},
+			mode:     ModeMonths | ModeDays,
+			expected: "1 month",
+		},
+	} {
+		loc, err := time.LoadLocation(tC.loc)
+		if err != nil {
+			t.Logf("LoadLocation(%s) failed: %v", tC.loc, err)
+			continue
+		}
+		start := time.Date(tC.start[0], time.Month(tC.start[1]), tC.start[2], tC.start[3], tC.start[4], tC.start[5], 0, loc)
+		end := time.Date(tC.end[0], time.Month(tC.end[1]), tC.end[2], tC.end[3], tC.end[4], tC.end[5], 0, loc)
+		if got := gregorianDiff(start, end, tC.mode); got.String() != tC.expected {
+			t.Errorf("gregorianDiff(%s, %s, %s) = %v, want %s", start, end, tC.mode, got, tC.expected)
+		}
+		ranges = append(ranges, [2]time.Time{start, end})
+	}
+
+	for _, r := range ranges {
+		start, end := r[0], r[1]
+		if start.After(end) {
+			continue
+		}
+		for m := 1; m < 16; m++ {
+			mode := DiffMode(m << 4)
+			if got, want := gregorianDiff(start, end, mode), loopDiff(start, end, mode); !got.Equal(want) {
+				t.Errorf("gregorianDiff(%s, %s, %s) = %v, want %v", start, end, mode, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkFullWeeksDiff(b *testing.B) {
+	start := time.Date(1950, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC)
+
+	b.Run("arithmetic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fullWeeksDiff(GregorianCalendar{}, start, end)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loopFullWeeksDiff(start, end)
+		}
+	})
+}
+
+func BenchmarkFullMonthsDiff(b *testing.B) {
+	start := time.Date(1920, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC)
+
+	b.Run("arithmetic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fullMonthsDiff(GregorianCalendar{}, start, end)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loopFullMonthsDiff(start, end)
+		}
+	})
+}
+
+func BenchmarkFullDaysDiff(b *testing.B) {
+	start := time.Date(1950, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC)
+
+	b.Run("arithmetic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fullDaysDiff(GregorianCalendar{}, start, end)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loopFullDaysDiff(start, end)
+		}
+	})
+}
+
+// replaceFormat is the reference implementation of appendFormat that replaces
+// verbs one by one in the order of verbs.
+func replaceFormat(diff Diff, rawFormat string, withZeros bool, l *Locale, verbs []string) string {
+	result := rawFormat
+	for _, verb := range verbs {
+		if !strings.Contains(rawFormat, verb) {
+			continue
+		}
+		fv := formatVerbs[formatVerb(verb[1])]
+		unit, n := fv.unit, diff.value(fv.mode)
+		if n == 0 && !withZeros {
+			result = strings.ReplaceAll(result, " "+verb, "")
+			result = strings.ReplaceAll(result, verb+" ", "")
+			result = strings.ReplaceAll(result, verb, "")
+			continue
+		}
+		replacement := l.Numbers.format(n)
+		if unicode.IsUpper(rune(verb[1])) {
+			replacement = l.noun(n, unit)
+		}
+		result = strings.ReplaceAll(result, verb, replacement)
+	}
+	return result
+}
+
+// TestAppendFormat verifies that appendFormat formats dates difference as one
+// of the orders of verbs replacement does. Trimming of adjacent verbs with 0
+// values depended on the order of verbs, that was not defined.
+func TestAppendFormat(t *testing.T) {
+	var verbs []string
+	for _, v := range formatVerbs {
+		verbs = append(verbs, "%"+string(v.verb))
+	}
+	tokens := append([]string{" ", " ", "and", ", ", "x"}, verbs...)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		var sb strings.Builder
+		for j := r.Intn(8); j >= 0; j-- {
+			sb.WriteString(tokens[r.Intn(len(tokens))])
+		}
+		rawFormat := sb.String()
+		diff := Diff{Years: r.Intn(3), Months: r.Intn(3), Weeks: r.Intn(3), Days: r.Intn(3)}
+		for _, withZeros := range []bool{false, true} {
+			got := string(appendFormat(nil, diff, rawFormat, withZeros, english))
+			var want []string
+			found := false
+			for k := 0; k < 50 && !found; k++ {
+				r.Shuffle(len(verbs), func(i, j int) { verbs[i], verbs[j] = verbs[j], verbs[i] })
+				w := replaceFormat(diff, rawFormat, withZeros, english, verbs)
+				found = got == w
+				want = append(want, w)
+			}
+			if !found {
+				t.Errorf("appendFormat(%v, %q, %t) = %q, want one of %q", diff, rawFormat, withZeros, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkAppendFormat(b *testing.B) {
+	diff := Diff{Years: 2, Months: 0, Weeks: 1, Days: 5}
+	const rawFormat = "%Y, %M, %W and %D"
+
+	b.Run("single pass", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			appendFormat(nil, diff, rawFormat, false, english)
+		}
+	})
+	b.Run("replace", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			replaceFormat(diff, rawFormat, false, english, []string{"%Y", "%M", "%W", "%D"})
+		}
+	})
+}
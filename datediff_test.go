@@ -2,6 +2,8 @@ package datediff_test
 
 import (
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -120,6 +122,10 @@ var testInvalidFormat = []struct {
 		format:   "Years and months",
 		expected: "undefined dates difference mode",
 	},
+	{
+		format:   "%Y %",
+		expected: `format "%Y %" ends with incomplete verb`,
+	},
 }
 
 func TestNewDiff(t *testing.T) {
@@ -201,6 +207,28 @@ func TestNewDiffWithModeFails(t *testing.T) {
 	}
 }
 
+func TestErrors(t *testing.T) {
+	now := time.Now()
+
+	_, err := datediff.NewDiff(now.Add(time.Hour), now, "%D")
+	if !errors.Is(err, datediff.ErrStartAfterEnd) {
+		t.Errorf("NewDiff() error = %v, want ErrStartAfterEnd", err)
+	}
+	_, err = datediff.NewDiff(now, now, "days")
+	if !errors.Is(err, datediff.ErrUndefinedMode) {
+		t.Errorf("NewDiff() error = %v, want ErrUndefinedMode", err)
+	}
+
+	_, err = datediff.NewDiff(now, now, "%Y %Q")
+	var fe *datediff.FormatError
+	if !errors.As(err, &fe) {
+		t.Fatalf("NewDiff() error = %v, want FormatError", err)
+	}
+	if fe.Format != "%Y %Q" || fe.Verb != 'Q' {
+		t.Errorf("NewDiff() error = %#v, want format %q and verb Q", fe, "%Y %Q")
+	}
+}
+
 func TestString(t *testing.T) {
 	testCases, err := loadDatediffRecordsForTest()
 	if err != nil {
@@ -356,3 +384,532 @@ func TestFormatFails(t *testing.T) {
 		}
 	}
 }
+
+func TestModeAndRawFormat(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	diff, err := datediff.NewDiff(start, end, "%Y and %d")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	if got, expected := diff.Mode(), datediff.ModeYears|datediff.ModeDays; got != expected {
+		t.Errorf("Mode() = %s, want %s", got, expected)
+	}
+	if got, expected := diff.RawFormat(), "%Y and %d"; got != expected {
+		t.Errorf("RawFormat() = %s, want %s", got, expected)
+	}
+
+	diff, err = datediff.NewDiffWithMode(start, end, datediff.ModeWeeks)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	if got, expected := diff.Mode(), datediff.ModeWeeks; got != expected {
+		t.Errorf("Mode() = %s, want %s", got, expected)
+	}
+	if got := diff.RawFormat(); got != "" {
+		t.Errorf("RawFormat() = %s, want empty format", got)
+	}
+}
+
+func TestStartEnd(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	diff, err := datediff.NewDiff(start, end, "%Y %M")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	if !diff.Start().Equal(start) || !diff.End().Equal(end) {
+		t.Errorf("Start(), End() = %s, %s, want %s, %s", diff.Start(), diff.End(), start, end)
+	}
+
+	data, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var got datediff.Diff
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) failed: %v", data, err)
+	}
+	if !got.Start().Equal(start) || !got.End().Equal(end) {
+		t.Errorf("json.Unmarshal(%s) Start(), End() = %s, %s, want %s, %s", data, got.Start(), got.End(), start, end)
+	}
+
+	literal := datediff.Diff{Years: 1}
+	if !literal.Start().IsZero() || !literal.End().IsZero() {
+		t.Errorf("Start(), End() = %s, %s, want zero time", literal.Start(), literal.End())
+	}
+}
+
+func TestContains(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	diff, err := datediff.NewDiff(start, end, "%Y %M")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	testCases := []struct {
+		date      time.Time
+		inclusive bool
+		expected  bool
+	}{
+		{date: start.Add(-time.Nanosecond), inclusive: true, expected: false},
+		{date: start, inclusive: false, expected: true},
+		{date: start.AddDate(2, 0, 0), inclusive: false, expected: true},
+		{date: end, inclusive: false, expected: false},
+		{date: end, inclusive: true, expected: true},
+		{date: end.Add(time.Nanosecond), inclusive: true, expected: false},
+	}
+	for _, tC := range testCases {
+		if got := diff.Contains(tC.date, tC.inclusive); got != tC.expected {
+			t.Errorf("Contains(%s, %t) = %t, want %t", tC.date, tC.inclusive, got, tC.expected)
+		}
+	}
+
+	literal := datediff.Diff{Years: 3}
+	if literal.Contains(start, true) {
+		t.Errorf("Contains(%s, true) = true, want false for dates difference without dates", start)
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		diff     datediff.Diff
+		expected bool
+	}{
+		{diff: datediff.Diff{}, expected: true},
+		{diff: mustNewDiff(t, start, start.AddDate(0, 0, 6), "%Y %M %W"), expected: true},
+		{diff: mustNewDiff(t, start, start.AddDate(0, 0, 6), "%D"), expected: false},
+		{diff: datediff.Diff{Weeks: -1}, expected: false},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.IsZero(); got != tC.expected {
+			t.Errorf("IsZero(%#v) = %t, want %t", tC.diff, got, tC.expected)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		format   string
+		expected datediff.DiffMode
+	}{
+		{format: "%Y", expected: datediff.ModeYears},
+		{format: "%Y and %d", expected: datediff.ModeYears | datediff.ModeDays},
+		{format: "%m %W", expected: datediff.ModeMonths | datediff.ModeWeeks},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseFormat(tC.format)
+		if err != nil {
+			t.Errorf("ParseFormat(%s) failed: %v", tC.format, err)
+		} else if got != tC.expected {
+			t.Errorf("ParseFormat(%s) = %s, want %s", tC.format, got, tC.expected)
+		}
+	}
+
+	for _, tC := range testInvalidFormat {
+		got, err := datediff.ParseFormat(tC.format)
+		if err == nil {
+			t.Errorf("ParseFormat(%s) = %s, want to fail due to %s", tC.format, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParseFormat(%s) failed: %v, want to fail due to %s", tC.format, err, tC.expected)
+		}
+	}
+}
+
+func TestMust(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	if got := datediff.MustNewDiff(start, end, "%Y"); got.String() != "3 years" {
+		t.Errorf("MustNewDiff() String() = %s, want 3 years", got.String())
+	}
+	if got := datediff.MustParseFormat("%Y %D"); got != datediff.ModeYears|datediff.ModeDays {
+		t.Errorf("MustParseFormat() = %s, want years|days", got)
+	}
+
+	mustPanic := func(desc string, f func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("%s want to panic", desc)
+			}
+		}()
+		f()
+	}
+	mustPanic("MustNewDiff(end, start)", func() { datediff.MustNewDiff(end, start, "%Y") })
+	mustPanic("MustNewDiff(%X)", func() { datediff.MustNewDiff(start, end, "%X") })
+	mustPanic("MustParseFormat(%X)", func() { datediff.MustParseFormat("%X") })
+}
+
+func TestUnits(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+
+	type unitValue struct {
+		unit  datediff.DiffMode
+		value int
+	}
+
+	testCases := []struct {
+		desc     string
+		diff     datediff.Diff
+		expected []unitValue
+	}{
+		{
+			desc: "units of the mode",
+			diff: mustNewDiff(t, start, start.AddDate(2, 0, 4), "%D and %Y"),
+			expected: []unitValue{
+				{unit: datediff.ModeYears, value: 2},
+				{unit: datediff.ModeDays, value: 4},
+			},
+		},
+		{
+			desc: "undefined mode",
+			diff: datediff.Diff{Months: 1, Days: 3},
+			expected: []unitValue{
+				{unit: datediff.ModeYears},
+				{unit: datediff.ModeMonths, value: 1},
+				{unit: datediff.ModeWeeks},
+				{unit: datediff.ModeDays, value: 3},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		var got []unitValue
+		tC.diff.Units()(func(unit datediff.DiffMode, v int) bool {
+			got = append(got, unitValue{unit: unit, value: v})
+			return true
+		})
+		if len(got) != len(tC.expected) {
+			t.Errorf("%s: Units() = %v, want %v", tC.desc, got, tC.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tC.expected[i] {
+				t.Errorf("%s: Units() = %v, want %v", tC.desc, got, tC.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestWithFormat(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	diff := mustNewDiff(t, start, start.AddDate(2, 0, 4), "%Y %D")
+
+	got, err := diff.WithFormat("%D and %Y")
+	if err != nil {
+		t.Fatalf("WithFormat() failed: %v", err)
+	}
+	if want := "4 days and 2 years"; got.String() != want {
+		t.Errorf("WithFormat().String() = %s, want %s", got.String(), want)
+	}
+	if got.Mode() != diff.Mode() || !got.Start().Equal(diff.Start()) || !got.End().Equal(diff.End()) {
+		t.Errorf("WithFormat() = %#v, want mode and dates of %#v", got, diff)
+	}
+	if diff.RawFormat() != "%Y %D" {
+		t.Errorf("WithFormat() changed the format of the original dates difference to %s", diff.RawFormat())
+	}
+
+	got, err = datediff.Diff{Months: 3}.WithFormat("%M")
+	if err != nil {
+		t.Fatalf("WithFormat() failed: %v", err)
+	}
+	if got.Mode() != datediff.ModeMonths || got.String() != "3 months" {
+		t.Errorf("WithFormat() = %#v, want 3 months in months mode", got)
+	}
+
+	testCases := []struct {
+		desc     string
+		format   string
+		expected string
+	}{
+		{
+			desc:     "unsupported verb",
+			format:   "%Y %Z",
+			expected: `format "%Y %Z" has unknown verb Z`,
+		},
+		{
+			desc:     "undefined mode",
+			format:   "",
+			expected: "undefined dates difference mode",
+		},
+		{
+			desc:     "format does not match mode",
+			format:   "%Y %M %D",
+			expected: `format "%Y %M %D" does not match mode years|days`,
+		},
+	}
+	for _, tC := range testCases {
+		_, err := diff.WithFormat(tC.format)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("WithFormat(%s) error = %v, want %s", tC.format, err, tC.expected)
+		}
+	}
+}
+
+func TestWithMode(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiffWithMode(start, start.AddDate(2, 0, 4), datediff.ModeYears|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+
+	got, err := datediff.Diff{Years: 2, Days: 4}.WithMode(datediff.ModeYears | datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("WithMode() failed: %v", err)
+	}
+	if !got.Equal(diff) || got.String() != diff.String() {
+		t.Errorf("WithMode() = %#v, want %#v", got, diff)
+	}
+
+	testCases := []struct {
+		desc     string
+		diff     datediff.Diff
+		mode     datediff.DiffMode
+		expected string
+	}{
+		{desc: "zero mode", mode: 0, expected: "undefined dates difference mode"},
+		{desc: "unknown mode", mode: 1, expected: "undefined dates difference mode"},
+		{
+			desc:     "mode does not match format",
+			diff:     mustNewDiff(t, start, start.AddDate(2, 0, 4), "%Y %D"),
+			mode:     datediff.ModeDays,
+			expected: `mode days does not match format "%Y %D"`,
+		},
+	}
+	for _, tC := range testCases {
+		_, err := tC.diff.WithMode(tC.mode)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("WithMode(%d) error = %v, want %s", tC.mode, err, tC.expected)
+		}
+	}
+}
+
+func TestWithDates(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	diff := mustNewDiff(t, start, end, "%M")
+
+	got, err := datediff.Diff{Months: 1}.WithFormat("%M")
+	if err != nil {
+		t.Fatalf("WithFormat() failed: %v", err)
+	}
+	if got, err = got.WithDates(start, end, false); err != nil {
+		t.Fatalf("WithDates() failed: %v", err)
+	}
+	if !got.Start().Equal(start) || !got.End().Equal(end) {
+		t.Errorf("WithDates() = %#v, want dates %s - %s", got, start, end)
+	}
+	if got.Compare(diff) != 0 {
+		t.Errorf("WithDates().Compare() = %d, want 0", got.Compare(diff))
+	}
+	gotDuration, err := got.Duration()
+	if err != nil {
+		t.Fatalf("Duration() failed: %v", err)
+	}
+	if want := end.Sub(start); gotDuration != want {
+		t.Errorf("WithDates().Duration() = %s, want %s", gotDuration, want)
+	}
+
+	if _, err := got.WithDates(end, start, false); err != datediff.ErrStartAfterEnd {
+		t.Errorf("WithDates() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+}
+
+func TestWithCopies(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	base := mustNewDiff(t, start, start.AddDate(2, 3, 5), "%Y %M %W %D")
+	de, err := datediff.LookupLocale("de")
+	if err != nil {
+		t.Fatalf("LookupLocale(de) failed: %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		diff     datediff.Diff
+		expected string
+	}{
+		{desc: "base", diff: base, expected: "2 years 3 months 5 days"},
+		{desc: "locale", diff: base.WithLocale(de), expected: "2 Jahre 3 Monate 5 Tage"},
+		{desc: "zeros", diff: base.WithZeros(), expected: "2 years 3 months 0 weeks 5 days"},
+		{desc: "max units", diff: base.WithMaxUnits(2), expected: "2 years 3 months"},
+		{desc: "combined", diff: base.WithLocale(de).WithMaxUnits(1), expected: "2 Jahre"},
+		{desc: "formatter", diff: base.WithFormatter(datediff.Formatter{Compact: true}), expected: "2y 3mo 5d"},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.String(); got != tC.expected {
+			t.Errorf("%s: String() = %s, want %s", tC.desc, got, tC.expected)
+		}
+		if !tC.diff.Equal(base) {
+			t.Errorf("%s: %v is not equal to %v", tC.desc, tC.diff, base)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	diff := mustNewDiff(t, start, start.AddDate(2, 0, 4), "%Y %D")
+
+	for _, d := range []datediff.Diff{
+		{},
+		{Years: 1, Weeks: 3},
+		diff,
+		diff.Mul(3),
+	} {
+		if err := d.Validate(); err != nil {
+			t.Errorf("Validate(%#v) failed: %v", d, err)
+		}
+	}
+
+	monthsDiff := diff
+	monthsDiff.Months = 1
+
+	testCases := []struct {
+		desc     string
+		diff     datediff.Diff
+		expected string
+	}{
+		{
+			desc:     "negative value",
+			diff:     datediff.Diff{Days: -5},
+			expected: "dates difference has negative days",
+		},
+		{
+			desc:     "value not in mode",
+			diff:     monthsDiff,
+			expected: "dates difference has months not in mode years|days",
+		},
+		{
+			desc:     "negative value of calculated dates difference",
+			diff:     diff.Sub(diff.Mul(2)),
+			expected: "dates difference has negative years",
+		},
+	}
+	for _, tC := range testCases {
+		err := tC.diff.Validate()
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("Validate(%#v) error = %v, want %s", tC.diff, err, tC.expected)
+		}
+	}
+}
+
+func TestLiteralString(t *testing.T) {
+	testCases := []struct {
+		diff      datediff.Diff
+		expected  string
+		withZeros string
+	}{
+		{diff: datediff.Diff{Months: 3, Days: 1}, expected: "3 months 1 day", withZeros: "0 years 3 months 0 weeks 1 day"},
+		{diff: datediff.Diff{Weeks: -2}, expected: "-2 weeks", withZeros: "0 years 0 months -2 weeks 0 days"},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.String(); got != tC.expected {
+			t.Errorf("String(%#v) = %s, want %s", tC.diff, got, tC.expected)
+		}
+		if got := tC.diff.StringWithZeros(); got != tC.withZeros {
+			t.Errorf("StringWithZeros(%#v) = %s, want %s", tC.diff, got, tC.withZeros)
+		}
+	}
+}
+
+func TestFormatZeroUnits(t *testing.T) {
+	testCases := []struct {
+		diff      datediff.Diff
+		rawFormat string
+		expected  string
+	}{
+		{diff: datediff.Diff{Days: 2}, rawFormat: "%Y %D", expected: "2 days"},
+		{diff: datediff.Diff{Days: 2}, rawFormat: "%Y %M %D", expected: "2 days"},
+		{diff: datediff.Diff{Days: 2}, rawFormat: "%D %Y", expected: "2 days"},
+		{diff: datediff.Diff{Days: 2}, rawFormat: "(%Y %D)", expected: "(2 days)"},
+		{diff: datediff.Diff{Years: 1, Days: 2}, rawFormat: "%Y %M %D", expected: "1 year 2 days"},
+		{diff: datediff.Diff{Years: 1}, rawFormat: "%Y %M %D", expected: "1 year"},
+	}
+	for _, tC := range testCases {
+		got, err := tC.diff.Format(tC.rawFormat)
+		if err != nil {
+			t.Errorf("Format(%s) failed: %v", tC.rawFormat, err)
+		} else if got != tC.expected {
+			t.Errorf("Format(%s) of %#v = %q, want %q", tC.rawFormat, tC.diff, got, tC.expected)
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var s struct {
+		Tenure datediff.Diff
+	}
+	d := s.Tenure
+
+	if got := d.String(); got != "" {
+		t.Errorf("String() = %q, want empty string", got)
+	}
+	if got, want := d.StringWithZeros(), "0 years 0 months 0 weeks 0 days"; got != want {
+		t.Errorf("StringWithZeros() = %s, want %s", got, want)
+	}
+	if got, err := d.Format("%Y and %M"); err != nil || got != "" {
+		t.Errorf("Format(%%Y and %%M) = %q, %v, want empty string", got, err)
+	}
+	if got, err := d.FormatWithZeros("%Y and %M"); err != nil || got != "0 years and 0 months" {
+		t.Errorf("FormatWithZeros(%%Y and %%M) = %q, %v, want 0 years and 0 months", got, err)
+	}
+
+	f := datediff.Formatter{ShowZero: true}
+	if got, want := d.WithFormatter(f).String(), "0 days"; got != want {
+		t.Errorf("String() with ShowZero = %s, want %s", got, want)
+	}
+	if got, err := f.Format(d, "%Y %M"); err != nil || got != "0 months" {
+		t.Errorf("Format(%%Y %%M) with ShowZero = %q, %v, want 0 months", got, err)
+	}
+	if !d.IsZero() || d.Validate() != nil || !d.Equal(datediff.Diff{}) {
+		t.Errorf("zero value %#v is not zero or valid", d)
+	}
+
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	zero := mustNewDiff(t, start, start, "%Y %M")
+	if got, want := zero.WithFormatter(f).String(), "0 months"; got != want {
+		t.Errorf("String() with ShowZero = %s, want %s", got, want)
+	}
+}
+
+func TestStringAllocs(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	withFormat := mustNewDiff(t, start, start.AddDate(2, 3, 5), "%Y, %M and %D")
+	withMode, err := datediff.NewDiffWithMode(start, start.AddDate(2, 3, 5), datediff.ModeAll)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	// longer than the initial capacity of pooled buffers
+	withLongFormat := mustNewDiff(t, start, start.AddDate(2, 3, 5),
+		"it took %Y, then another %M and then %D more to finish the whole project")
+
+	for _, d := range []datediff.Diff{withFormat, withMode, withLongFormat} {
+		if allocs := testing.AllocsPerRun(100, func() { _ = d.String() }); allocs > 1 {
+			t.Errorf("String() of %s allocates %.0f times, want at most 1", d, allocs)
+		}
+		if allocs := testing.AllocsPerRun(100, func() { _ = d.StringWithZeros() }); allocs > 1 {
+			t.Errorf("StringWithZeros() of %s allocates %.0f times, want at most 1", d, allocs)
+		}
+		if allocs := testing.AllocsPerRun(100, func() { _, _ = d.Format("") }); allocs > 1 {
+			t.Errorf("Format() of %s allocates %.0f times, want at most 1", d, allocs)
+		}
+		buf := make([]byte, 0, 128)
+		if allocs := testing.AllocsPerRun(100, func() { buf = d.AppendString(buf[:0]) }); allocs > 0 {
+			t.Errorf("AppendString() of %s allocates %.0f times, want 0", d, allocs)
+		}
+		if got := string(d.AppendString([]byte("tenure: "))); got != "tenure: "+d.String() {
+			t.Errorf("AppendString() = %s, want tenure: %s", got, d.String())
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Package datediffgrpc provides gRPC stubs of DiffService of datediff.proto
+// and the server that calculates dates differences. It's a separate module,
+// so the datediff module does not depend on gRPC.
+//
+// Stubs have the layout of protoc-gen-go-grpc v1.3.0 output. The generator
+// writes stubs to the package of messages, here they refer to messages of
+// the datediffpb package.
+package datediffgrpc
+
+import (
+	"context"
+
+	"github.com/antklim/datediff/datediffpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this file is compatible
+// with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	DiffService_Diff_FullMethodName = "/datediff.v1.DiffService/Diff"
+)
+
+// DiffServiceClient is the client API for DiffService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DiffServiceClient interface {
+	// Diff calculates dates difference between the start and end dates.
+	Diff(ctx context.Context, in *datediffpb.DiffRequest, opts ...grpc.CallOption) (*datediffpb.DiffResponse, error)
+}
+
+type diffServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDiffServiceClient(cc grpc.ClientConnInterface) DiffServiceClient {
+	return &diffServiceClient{cc}
+}
+
+func (c *diffServiceClient) Diff(ctx context.Context, in *datediffpb.DiffRequest, opts ...grpc.CallOption) (*datediffpb.DiffResponse, error) {
+	out := new(datediffpb.DiffResponse)
+	err := c.cc.Invoke(ctx, DiffService_Diff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DiffServiceServer is the server API for DiffService service.
+// All implementations must embed UnimplementedDiffServiceServer
+// for forward compatibility
+type DiffServiceServer interface {
+	// Diff calculates dates difference between the start and end dates.
+	Diff(context.Context, *datediffpb.DiffRequest) (*datediffpb.DiffResponse, error)
+	mustEmbedUnimplementedDiffServiceServer()
+}
+
+// UnimplementedDiffServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDiffServiceServer struct {
+}
+
+func (UnimplementedDiffServiceServer) Diff(context.Context, *datediffpb.DiffRequest) (*datediffpb.DiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedDiffServiceServer) mustEmbedUnimplementedDiffServiceServer() {}
+
+// UnsafeDiffServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DiffServiceServer will
+// result in compilation errors.
+type UnsafeDiffServiceServer interface {
+	mustEmbedUnimplementedDiffServiceServer()
+}
+
+func RegisterDiffServiceServer(s grpc.ServiceRegistrar, srv DiffServiceServer) {
+	s.RegisterService(&DiffService_ServiceDesc, srv)
+}
+
+func _DiffService_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(datediffpb.DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiffServiceServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiffService_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiffServiceServer).Diff(ctx, req.(*datediffpb.DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DiffService_ServiceDesc is the grpc.ServiceDesc for DiffService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DiffService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "datediff.v1.DiffService",
+	HandlerType: (*DiffServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Diff",
+			Handler:    _DiffService_Diff_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "datediff.proto",
+}
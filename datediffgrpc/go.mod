@@ -0,0 +1,21 @@
+module github.com/antklim/datediff/datediffgrpc
+
+go 1.17
+
+require (
+	github.com/antklim/datediff v0.0.0
+	google.golang.org/grpc v1.55.0
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.8.0 // indirect
+	golang.org/x/sys v0.6.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	google.golang.org/genproto v0.0.0-20230306155012-7f2fa6fef1f4 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The service is developed together with the datediff module.
+replace github.com/antklim/datediff => ../
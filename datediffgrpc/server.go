@@ -0,0 +1,36 @@
+package datediffgrpc
+
+import (
+	"context"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/datediffpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements DiffServiceServer with datediffpb.Server. Errors of
+// invalid requests are returned with InvalidArgument status code, errors of
+// canceled or expired contexts with Canceled or DeadlineExceeded:
+//
+//	s := grpc.NewServer()
+//	datediffgrpc.RegisterDiffServiceServer(s, datediffgrpc.Server{})
+type Server struct {
+	UnimplementedDiffServiceServer
+
+	// Options configure the calculation, i.e time zone. Locale and calendar
+	// of the request override options.
+	Options []datediff.Option
+}
+
+// Diff calculates dates difference of the request.
+func (s Server) Diff(ctx context.Context, req *datediffpb.DiffRequest) (*datediffpb.DiffResponse, error) {
+	resp, err := datediffpb.Server{Options: s.Options}.Diff(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if err == ctx.Err() {
+		return nil, status.FromContextError(err).Err()
+	}
+	return nil, status.Error(codes.InvalidArgument, err.Error())
+}
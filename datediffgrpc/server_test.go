@@ -0,0 +1,100 @@
+package datediffgrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/antklim/datediff/datediffgrpc"
+	"github.com/antklim/datediff/datediffpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestDiffService(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	datediffgrpc.RegisterDiffServiceServer(s, datediffgrpc.Server{})
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("DialContext() failed: %v", err)
+	}
+	defer conn.Close()
+	client := datediffgrpc.NewDiffServiceClient(conn)
+
+	resp, err := client.Diff(context.Background(), &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21", Locale: "de"})
+	if err != nil {
+		t.Fatalf("Diff() failed: %v", err)
+	}
+	if got, want := resp.GetText(), "2 Jahre 3 Monate 4 Tage"; got != want {
+		t.Errorf("Diff() text = %s, want %s", got, want)
+	}
+
+	_, err = client.Diff(context.Background(), &datediffpb.DiffRequest{Start: "2002-07-21", End: "2000-04-17"})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("Diff() of start after end status = %s, want %s", got, codes.InvalidArgument)
+	}
+}
+
+func TestServerDiffFails(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		desc string
+		ctx  context.Context
+		req  *datediffpb.DiffRequest
+		code codes.Code
+		msg  string
+	}{
+		{
+			desc: "canceled context",
+			ctx:  canceled,
+			req:  &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21"},
+			code: codes.Canceled,
+			msg:  "context canceled",
+		},
+		{
+			desc: "missing start date",
+			req:  &datediffpb.DiffRequest{End: "2002-07-21"},
+			code: codes.InvalidArgument,
+			msg:  "missing start date",
+		},
+		{
+			desc: "unknown locale",
+			req:  &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21", Locale: "xx"},
+			code: codes.InvalidArgument,
+			msg:  `unknown locale "xx"`,
+		},
+	}
+	for _, tC := range testCases {
+		ctx := tC.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		_, err := datediffgrpc.Server{}.Diff(ctx, tC.req)
+		st, ok := status.FromError(err)
+		if err == nil || !ok {
+			t.Errorf("Diff() %s error = %v, want to fail with status %s", tC.desc, err, tC.code)
+		} else if st.Code() != tC.code || st.Message() != tC.msg {
+			t.Errorf("Diff() %s status = %s %q, want %s %q", tC.desc, st.Code(), st.Message(), tC.code, tC.msg)
+		}
+	}
+}
+
+var _ datediffgrpc.DiffServiceServer = datediffgrpc.Server{}
+
+func TestUnimplementedDiffServiceServer(t *testing.T) {
+	_, err := datediffgrpc.UnimplementedDiffServiceServer{}.Diff(context.Background(), &datediffpb.DiffRequest{})
+	if got := status.Code(err); got != codes.Unimplemented {
+		t.Errorf("UnimplementedDiffServiceServer.Diff() status = %s, want %s", got, codes.Unimplemented)
+	}
+}
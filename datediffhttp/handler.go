@@ -0,0 +1,96 @@
+// Package datediffhttp provides HTTP handler of dates difference
+// calculation, so the calculation can be added to existing servers:
+//
+//	http.Handle("/diff", datediffhttp.Handler{})
+package datediffhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// DefaultFormat is the format of dates difference used when the request does
+// not have format parameter.
+const DefaultFormat = "%Y %M %D"
+
+// Handler calculates dates difference between start and end query
+// parameters, in "2006-01-02" or RFC 3339 format, in the format of format
+// query parameter, i.e
+//
+//	GET /diff?start=2000-04-17&end=2002-07-21&format=%25Y+%25M
+//
+// It responds with JSON of dates difference that has the schema of
+// datediff.Diff MarshalJSON. Invalid requests get 400 status code with JSON
+// error, i.e {"error":"start date is after end date"}. Only GET and HEAD
+// methods are allowed.
+type Handler struct {
+	// Options configure the calculation, i.e time zone or calendar.
+	Options []datediff.Option
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", r.Method))
+		return
+	}
+	d, err := h.diff(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
+// diff calculates dates difference of request parameters.
+func (h Handler) diff(r *http.Request) (datediff.Diff, error) {
+	q := r.URL.Query()
+	start, err := parseDate("start", q.Get("start"))
+	if err != nil {
+		return datediff.Diff{}, err
+	}
+	end, err := parseDate("end", q.Get("end"))
+	if err != nil {
+		return datediff.Diff{}, err
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = DefaultFormat
+	}
+	return datediff.NewDiff(start, end, format, h.Options...)
+}
+
+// parseDate parses the date parameter as datediff.ParseDate does.
+func parseDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("missing %s parameter", name)
+	}
+	t, err := datediff.ParseDate(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s parameter %q", name, value)
+	}
+	return t, nil
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, struct {
+		Error string `json:"error"`
+	}{msg})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	// the status is sent, write error means the client has gone
+	_, _ = w.Write(append(b, '\n'))
+}
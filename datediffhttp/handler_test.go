@@ -0,0 +1,100 @@
+package datediffhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/datediffhttp"
+)
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		method string
+		target string
+		code   int
+		body   string
+	}{
+		{
+			desc:   "default format",
+			target: "/diff?start=2000-04-17&end=2002-07-21",
+			code:   http.StatusOK,
+			body:   `{"years":2,"months":3,"weeks":0,"days":4,"format":"%Y %M %D","mode":"years|months|days","start":"2000-04-17T00:00:00Z","end":"2002-07-21T00:00:00Z"}` + "\n",
+		},
+		{
+			desc:   "format",
+			target: "/diff?start=2000-04-17T10:00:00Z&end=2000-05-17T10:00:00Z&format=%25W+%25D",
+			code:   http.StatusOK,
+			body:   `{"years":0,"months":0,"weeks":4,"days":2,"format":"%W %D","mode":"weeks|days","start":"2000-04-17T10:00:00Z","end":"2000-05-17T10:00:00Z"}` + "\n",
+		},
+		{
+			desc:   "missing start",
+			target: "/diff?end=2002-07-21",
+			code:   http.StatusBadRequest,
+			body:   `{"error":"missing start parameter"}` + "\n",
+		},
+		{
+			desc:   "invalid end",
+			target: "/diff?start=2000-04-17&end=21.07.2002",
+			code:   http.StatusBadRequest,
+			body:   `{"error":"invalid end parameter \"21.07.2002\""}` + "\n",
+		},
+		{
+			desc:   "start after end",
+			target: "/diff?start=2002-07-21&end=2000-04-17",
+			code:   http.StatusBadRequest,
+			body:   `{"error":"start date is after end date"}` + "\n",
+		},
+		{
+			desc:   "unsupported format",
+			target: "/diff?start=2000-04-17&end=2002-07-21&format=%25Q",
+			code:   http.StatusBadRequest,
+		},
+		{
+			desc:   "method not allowed",
+			method: http.MethodPost,
+			target: "/diff?start=2000-04-17&end=2002-07-21",
+			code:   http.StatusMethodNotAllowed,
+			body:   `{"error":"method POST is not allowed"}` + "\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			method := tC.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rec := httptest.NewRecorder()
+			datediffhttp.Handler{}.ServeHTTP(rec, httptest.NewRequest(method, tC.target, nil))
+
+			if rec.Code != tC.code {
+				t.Errorf("ServeHTTP() code = %d, want %d", rec.Code, tC.code)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("ServeHTTP() Content-Type = %q, want application/json", got)
+			}
+			if tC.body != "" && rec.Body.String() != tC.body {
+				t.Errorf("ServeHTTP() body = %s, want %s", rec.Body.String(), tC.body)
+			}
+		})
+	}
+}
+
+func TestHandlerOptions(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skip(err)
+	}
+	h := datediffhttp.Handler{Options: []datediff.Option{datediff.WithLocation(sydney)}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/diff?start=2021-01-31T20:00:00Z&end=2021-03-01T00:00:00Z&format=%25M+%25D", nil))
+
+	const want = `{"years":0,"months":1,"weeks":0,"days":0,"format":"%M %D","mode":"months|days","start":"2021-02-01T07:00:00+11:00","end":"2021-03-01T11:00:00+11:00"}` + "\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("ServeHTTP() body = %s, want %s", got, want)
+	}
+}
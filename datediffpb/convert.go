@@ -0,0 +1,136 @@
+// Package datediffpb provides the protocol buffers definition of dates
+// difference and conversion helpers between datediff.Diff and the message.
+// It also defines DiffService and its reference implementation Server.
+package datediffpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative datediff.proto
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+var modes = []struct {
+	mode  datediff.DiffMode
+	proto DiffMode
+}{
+	{mode: datediff.ModeYears, proto: DiffMode_DIFF_MODE_YEARS},
+	{mode: datediff.ModeMonths, proto: DiffMode_DIFF_MODE_MONTHS},
+	{mode: datediff.ModeWeeks, proto: DiffMode_DIFF_MODE_WEEKS},
+	{mode: datediff.ModeDays, proto: DiffMode_DIFF_MODE_DAYS},
+}
+
+// ToProto converts dates difference to the protocol buffers message. Start
+// and end dates are set in RFC 3339 format when dates difference was
+// calculated from dates.
+func ToProto(d datediff.Diff) *Diff {
+	return &Diff{
+		Years:        int64(d.Years),
+		Months:       int64(d.Months),
+		Weeks:        int64(d.Weeks),
+		Days:         int64(d.Days),
+		Format:       d.RawFormat(),
+		Modes:        ModeToProto(d.Mode()),
+		Start:        formatDate(d.Start()),
+		End:          formatDate(d.End()),
+		InclusiveEnd: d.InclusiveEnd(),
+	}
+}
+
+// FromProto converts the protocol buffers message to dates difference. It
+// returns error when the format, modes or dates are invalid, or values do
+// not fit into int.
+func FromProto(m *Diff) (datediff.Diff, error) {
+	if m == nil {
+		return datediff.Diff{}, nil
+	}
+	var d datediff.Diff
+	for _, v := range []struct {
+		n   int64
+		dst *int
+	}{
+		{n: m.GetYears(), dst: &d.Years},
+		{n: m.GetMonths(), dst: &d.Months},
+		{n: m.GetWeeks(), dst: &d.Weeks},
+		{n: m.GetDays(), dst: &d.Days},
+	} {
+		if v.n > math.MaxInt || v.n < math.MinInt {
+			return datediff.Diff{}, fmt.Errorf("value %d overflows int", v.n)
+		}
+		*v.dst = int(v.n)
+	}
+
+	mode, err := ModeFromProto(m.GetModes())
+	if err != nil {
+		return datediff.Diff{}, err
+	}
+	if m.GetFormat() != "" {
+		if d, err = d.WithFormat(m.GetFormat()); err != nil {
+			return datediff.Diff{}, err
+		}
+	}
+	if mode != 0 {
+		if d, err = d.WithMode(mode); err != nil {
+			return datediff.Diff{}, err
+		}
+	}
+	if m.GetStart() != "" || m.GetEnd() != "" {
+		var dates [2]time.Time
+		for i, v := range []struct{ name, date string }{
+			{name: "start", date: m.GetStart()},
+			{name: "end", date: m.GetEnd()},
+		} {
+			if dates[i], err = time.Parse(time.RFC3339Nano, v.date); err != nil {
+				return datediff.Diff{}, fmt.Errorf("invalid %s date %q", v.name, v.date)
+			}
+		}
+		if d, err = d.WithDates(dates[0], dates[1], m.GetInclusiveEnd()); err != nil {
+			return datediff.Diff{}, err
+		}
+	}
+	return d, nil
+}
+
+// formatDate returns the date in RFC 3339 format, or empty string for zero
+// time.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// ModeToProto converts dates difference mode to the list of modes in the
+// order of time units significance.
+func ModeToProto(mode datediff.DiffMode) []DiffMode {
+	var pm []DiffMode
+	for _, m := range modes {
+		if mode&m.mode != 0 {
+			pm = append(pm, m.proto)
+		}
+	}
+	return pm
+}
+
+// ModeFromProto converts the list of modes to dates difference mode. It
+// returns error when the list contains unspecified or unknown mode.
+func ModeFromProto(pm []DiffMode) (datediff.DiffMode, error) {
+	var mode datediff.DiffMode
+	for _, p := range pm {
+		found := false
+		for _, m := range modes {
+			if m.proto == p {
+				mode |= m.mode
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid dates difference mode %s", p)
+		}
+	}
+	return mode, nil
+}
@@ -0,0 +1,138 @@
+package datediffpb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/datediffpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	withFormat, err := datediff.NewDiff(start, end, "%Y and %D")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	withMode, err := datediff.NewDiffWithMode(start, end, datediff.ModeMonths|datediff.ModeWeeks)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+
+	zone := time.FixedZone("AEST", 10*60*60)
+	inclusive, err := datediff.NewDiff(start.In(zone), end.In(zone), "%M %D", datediff.WithInclusiveEnd())
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	// the same duration as withFormat, but shorter by time units
+	weeks, err := datediff.NewDiff(start, end, "%W")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+
+	for _, diff := range []datediff.Diff{withFormat, withMode, inclusive} {
+		m := datediffpb.ToProto(diff)
+		data, err := proto.Marshal(m)
+		if err != nil {
+			t.Fatalf("proto.Marshal() failed: %v", err)
+		}
+		var decoded datediffpb.Diff
+		if err := proto.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("proto.Unmarshal() failed: %v", err)
+		}
+		got, err := datediffpb.FromProto(&decoded)
+		if err != nil {
+			t.Fatalf("FromProto() failed: %v", err)
+		}
+		if !got.Equal(diff) {
+			t.Errorf("FromProto() = %#v, want %#v", got, diff)
+		}
+		if got.String() != diff.String() {
+			t.Errorf("FromProto() String() = %s, want %s", got.String(), diff.String())
+		}
+		if !got.Start().Equal(diff.Start()) || got.End().Format(time.RFC3339Nano) != diff.End().Format(time.RFC3339Nano) || got.InclusiveEnd() != diff.InclusiveEnd() {
+			t.Errorf("FromProto() dates %v - %v (inclusive %t), want %v - %v (inclusive %t)",
+				got.Start(), got.End(), got.InclusiveEnd(), diff.Start(), diff.End(), diff.InclusiveEnd())
+		}
+		if a, b := got.Compare(weeks), diff.Compare(weeks); a != b {
+			t.Errorf("FromProto() Compare(%s) = %d, want %d", weeks, a, b)
+		}
+		gotDuration, err := got.Duration()
+		if err != nil {
+			t.Fatalf("Duration() failed: %v", err)
+		}
+		if want, _ := diff.Duration(); gotDuration != want {
+			t.Errorf("FromProto() Duration() = %v, want %v", gotDuration, want)
+		}
+	}
+}
+
+func TestToProto(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiff(start, end, "%Y %M %D")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+
+	expected := &datediffpb.Diff{
+		Years:  3,
+		Months: 1,
+		Days:   1,
+		Format: "%Y %M %D",
+		Modes: []datediffpb.DiffMode{
+			datediffpb.DiffMode_DIFF_MODE_YEARS,
+			datediffpb.DiffMode_DIFF_MODE_MONTHS,
+			datediffpb.DiffMode_DIFF_MODE_DAYS,
+		},
+		Start: "2000-04-17T00:00:00Z",
+		End:   "2003-05-18T00:00:00Z",
+	}
+	got := datediffpb.ToProto(diff)
+	if !proto.Equal(got, expected) {
+		t.Errorf("ToProto() = %v, want %v", got, expected)
+	}
+}
+
+func TestFromProtoFails(t *testing.T) {
+	testCases := []struct {
+		m        *datediffpb.Diff
+		expected string
+	}{
+		{
+			m:        &datediffpb.Diff{Years: 1, Format: "%X"},
+			expected: `format "%X" has unknown verb X`,
+		},
+		{
+			m:        &datediffpb.Diff{Years: 1, Format: "%Y", Modes: []datediffpb.DiffMode{datediffpb.DiffMode_DIFF_MODE_DAYS}},
+			expected: `mode days does not match format "%Y"`,
+		},
+		{
+			m:        &datediffpb.Diff{Years: 1, Modes: []datediffpb.DiffMode{datediffpb.DiffMode_DIFF_MODE_UNSPECIFIED}},
+			expected: "invalid dates difference mode DIFF_MODE_UNSPECIFIED",
+		},
+		{
+			m:        &datediffpb.Diff{Years: 1, Start: "2000-04-17"},
+			expected: `invalid start date "2000-04-17"`,
+		},
+		{
+			m:        &datediffpb.Diff{Years: 1, Start: "2000-04-17T00:00:00Z"},
+			expected: `invalid end date ""`,
+		},
+		{
+			m:        &datediffpb.Diff{Years: 1, Start: "2001-04-17T00:00:00Z", End: "2000-04-17T00:00:00Z"},
+			expected: "start date is after end date",
+		},
+	}
+	for _, tC := range testCases {
+		got, err := datediffpb.FromProto(tC.m)
+		if err == nil {
+			t.Errorf("FromProto(%v) = %#v, want to fail due to %s", tC.m, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("FromProto(%v) failed: %v, want to fail due to %s", tC.m, err, tC.expected)
+		}
+	}
+}
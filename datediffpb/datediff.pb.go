@@ -0,0 +1,502 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: datediff.proto
+
+package datediffpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DiffMode is a time unit of the dates difference calculation.
+type DiffMode int32
+
+const (
+	DiffMode_DIFF_MODE_UNSPECIFIED DiffMode = 0
+	DiffMode_DIFF_MODE_YEARS       DiffMode = 1
+	DiffMode_DIFF_MODE_MONTHS      DiffMode = 2
+	DiffMode_DIFF_MODE_WEEKS       DiffMode = 3
+	DiffMode_DIFF_MODE_DAYS        DiffMode = 4
+)
+
+// Enum value maps for DiffMode.
+var (
+	DiffMode_name = map[int32]string{
+		0: "DIFF_MODE_UNSPECIFIED",
+		1: "DIFF_MODE_YEARS",
+		2: "DIFF_MODE_MONTHS",
+		3: "DIFF_MODE_WEEKS",
+		4: "DIFF_MODE_DAYS",
+	}
+	DiffMode_value = map[string]int32{
+		"DIFF_MODE_UNSPECIFIED": 0,
+		"DIFF_MODE_YEARS":       1,
+		"DIFF_MODE_MONTHS":      2,
+		"DIFF_MODE_WEEKS":       3,
+		"DIFF_MODE_DAYS":        4,
+	}
+)
+
+func (x DiffMode) Enum() *DiffMode {
+	p := new(DiffMode)
+	*p = x
+	return p
+}
+
+func (x DiffMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiffMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_datediff_proto_enumTypes[0].Descriptor()
+}
+
+func (DiffMode) Type() protoreflect.EnumType {
+	return &file_datediff_proto_enumTypes[0]
+}
+
+func (x DiffMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiffMode.Descriptor instead.
+func (DiffMode) EnumDescriptor() ([]byte, []int) {
+	return file_datediff_proto_rawDescGZIP(), []int{0}
+}
+
+// Diff describes dates difference in years, months, weeks, and days.
+type Diff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Years  int64 `protobuf:"varint,1,opt,name=years,proto3" json:"years,omitempty"`
+	Months int64 `protobuf:"varint,2,opt,name=months,proto3" json:"months,omitempty"`
+	Weeks  int64 `protobuf:"varint,3,opt,name=weeks,proto3" json:"weeks,omitempty"`
+	Days   int64 `protobuf:"varint,4,opt,name=days,proto3" json:"days,omitempty"`
+	// Format is the format dates difference was created with, i.e "%Y %M %D".
+	// It is empty when dates difference was created with the mode.
+	Format string `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
+	// Modes are time units dates difference was calculated in.
+	Modes []DiffMode `protobuf:"varint,6,rep,packed,name=modes,proto3,enum=datediff.v1.DiffMode" json:"modes,omitempty"`
+	// Start is the start date in RFC 3339 format. It is empty when dates
+	// difference was not calculated from dates.
+	Start string `protobuf:"bytes,7,opt,name=start,proto3" json:"start,omitempty"`
+	// End is the end date in RFC 3339 format, as it was provided. It is empty
+	// when dates difference was not calculated from dates.
+	End string `protobuf:"bytes,8,opt,name=end,proto3" json:"end,omitempty"`
+	// InclusiveEnd is set when the end date is included.
+	InclusiveEnd bool `protobuf:"varint,9,opt,name=inclusive_end,json=inclusiveEnd,proto3" json:"inclusive_end,omitempty"`
+}
+
+func (x *Diff) Reset() {
+	*x = Diff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datediff_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Diff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diff) ProtoMessage() {}
+
+func (x *Diff) ProtoReflect() protoreflect.Message {
+	mi := &file_datediff_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diff.ProtoReflect.Descriptor instead.
+func (*Diff) Descriptor() ([]byte, []int) {
+	return file_datediff_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Diff) GetYears() int64 {
+	if x != nil {
+		return x.Years
+	}
+	return 0
+}
+
+func (x *Diff) GetMonths() int64 {
+	if x != nil {
+		return x.Months
+	}
+	return 0
+}
+
+func (x *Diff) GetWeeks() int64 {
+	if x != nil {
+		return x.Weeks
+	}
+	return 0
+}
+
+func (x *Diff) GetDays() int64 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *Diff) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Diff) GetModes() []DiffMode {
+	if x != nil {
+		return x.Modes
+	}
+	return nil
+}
+
+func (x *Diff) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *Diff) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *Diff) GetInclusiveEnd() bool {
+	if x != nil {
+		return x.InclusiveEnd
+	}
+	return false
+}
+
+// DiffRequest is the request of dates difference calculation.
+type DiffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Start is the start date in "2006-01-02" or RFC 3339 format.
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// End is the end date in "2006-01-02" or RFC 3339 format.
+	End string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Format is the format of dates difference, i.e "%Y %M %D".
+	Format string `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	// Modes are time units of dates difference, they are used when the
+	// format is empty. Years, months and days are used when both are empty.
+	Modes []DiffMode `protobuf:"varint,4,rep,packed,name=modes,proto3,enum=datediff.v1.DiffMode" json:"modes,omitempty"`
+	// Locale is the name of the built-in locale of the text, i.e "de".
+	Locale string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Calendar is the name of the registered calendar, i.e "persian". The
+	// Gregorian calendar is used when it is empty.
+	Calendar string `protobuf:"bytes,6,opt,name=calendar,proto3" json:"calendar,omitempty"`
+}
+
+func (x *DiffRequest) Reset() {
+	*x = DiffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datediff_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRequest) ProtoMessage() {}
+
+func (x *DiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_datediff_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRequest.ProtoReflect.Descriptor instead.
+func (*DiffRequest) Descriptor() ([]byte, []int) {
+	return file_datediff_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiffRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetModes() []DiffMode {
+	if x != nil {
+		return x.Modes
+	}
+	return nil
+}
+
+func (x *DiffRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+// DiffResponse is the result of dates difference calculation.
+type DiffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Diff is dates difference between the start and end dates.
+	Diff *Diff `protobuf:"bytes,1,opt,name=diff,proto3" json:"diff,omitempty"`
+	// Text is dates difference formatted in the locale, i.e "2 years 3 months".
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *DiffResponse) Reset() {
+	*x = DiffResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datediff_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffResponse) ProtoMessage() {}
+
+func (x *DiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_datediff_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffResponse.ProtoReflect.Descriptor instead.
+func (*DiffResponse) Descriptor() ([]byte, []int) {
+	return file_datediff_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DiffResponse) GetDiff() *Diff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+var File_datediff_proto protoreflect.FileDescriptor
+
+var file_datediff_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69, 0x66, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0b, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x22, 0xf0, 0x01,
+	0x0a, 0x04, 0x44, 0x69, 0x66, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x79, 0x65, 0x61, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x79, 0x65, 0x61, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x6f,
+	0x6e, 0x74, 0x68, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x65, 0x65, 0x6b, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x77, 0x65, 0x65, 0x6b, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x79, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69, 0x66, 0x66,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6d, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x45, 0x6e, 0x64,
+	0x22, 0xae, 0x01, 0x0a, 0x0b, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x2b, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69,
+	0x66, 0x66, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61,
+	0x72, 0x22, 0x49, 0x0a, 0x0c, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x25, 0x0a, 0x04, 0x64, 0x69, 0x66, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69,
+	0x66, 0x66, 0x52, 0x04, 0x64, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x2a, 0x79, 0x0a, 0x08,
+	0x44, 0x69, 0x66, 0x66, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x49, 0x46, 0x46,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x46, 0x46, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x59, 0x45, 0x41, 0x52, 0x53, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x49, 0x46, 0x46,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x4f, 0x4e, 0x54, 0x48, 0x53, 0x10, 0x02, 0x12, 0x13,
+	0x0a, 0x0f, 0x44, 0x49, 0x46, 0x46, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x57, 0x45, 0x45, 0x4b,
+	0x53, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x49, 0x46, 0x46, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x44, 0x41, 0x59, 0x53, 0x10, 0x04, 0x32, 0x4a, 0x0a, 0x0b, 0x44, 0x69, 0x66, 0x66, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x44, 0x69, 0x66, 0x66, 0x12, 0x18,
+	0x2e, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x66,
+	0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x69, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x61, 0x6e, 0x74, 0x6b, 0x6c, 0x69, 0x6d, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69,
+	0x66, 0x66, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x64, 0x69, 0x66, 0x66, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_datediff_proto_rawDescOnce sync.Once
+	file_datediff_proto_rawDescData = file_datediff_proto_rawDesc
+)
+
+func file_datediff_proto_rawDescGZIP() []byte {
+	file_datediff_proto_rawDescOnce.Do(func() {
+		file_datediff_proto_rawDescData = protoimpl.X.CompressGZIP(file_datediff_proto_rawDescData)
+	})
+	return file_datediff_proto_rawDescData
+}
+
+var file_datediff_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_datediff_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_datediff_proto_goTypes = []interface{}{
+	(DiffMode)(0),        // 0: datediff.v1.DiffMode
+	(*Diff)(nil),         // 1: datediff.v1.Diff
+	(*DiffRequest)(nil),  // 2: datediff.v1.DiffRequest
+	(*DiffResponse)(nil), // 3: datediff.v1.DiffResponse
+}
+var file_datediff_proto_depIdxs = []int32{
+	0, // 0: datediff.v1.Diff.modes:type_name -> datediff.v1.DiffMode
+	0, // 1: datediff.v1.DiffRequest.modes:type_name -> datediff.v1.DiffMode
+	1, // 2: datediff.v1.DiffResponse.diff:type_name -> datediff.v1.Diff
+	2, // 3: datediff.v1.DiffService.Diff:input_type -> datediff.v1.DiffRequest
+	3, // 4: datediff.v1.DiffService.Diff:output_type -> datediff.v1.DiffResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_datediff_proto_init() }
+func file_datediff_proto_init() {
+	if File_datediff_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_datediff_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Diff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_datediff_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_datediff_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_datediff_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_datediff_proto_goTypes,
+		DependencyIndexes: file_datediff_proto_depIdxs,
+		EnumInfos:         file_datediff_proto_enumTypes,
+		MessageInfos:      file_datediff_proto_msgTypes,
+	}.Build()
+	File_datediff_proto = out.File
+	file_datediff_proto_rawDesc = nil
+	file_datediff_proto_goTypes = nil
+	file_datediff_proto_depIdxs = nil
+}
@@ -0,0 +1,84 @@
+package datediffpb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+// Server is the reference implementation of DiffService of datediff.proto.
+// gRPC stubs are not generated in this package to keep gRPC out of the
+// module dependencies, they are provided by the datediffgrpc module.
+//
+// Errors are returned as they are, datediffgrpc.Server converts them to
+// InvalidArgument status.
+type Server struct {
+	// Options configure the calculation, i.e time zone. Locale and calendar
+	// of the request override options.
+	Options []datediff.Option
+}
+
+// Diff calculates dates difference of the request.
+func (s Server) Diff(ctx context.Context, req *DiffRequest) (*DiffResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	start, err := parseDate("start", req.GetStart())
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseDate("end", req.GetEnd())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]datediff.Option(nil), s.Options...)
+	if name := req.GetLocale(); name != "" {
+		l, err := datediff.LookupLocale(name)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, datediff.WithLocale(l))
+	}
+	if name := req.GetCalendar(); name != "" {
+		c, err := datediff.LookupCalendar(name)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, datediff.WithCalendar(c))
+	}
+
+	mode, err := ModeFromProto(req.GetModes())
+	if err != nil {
+		return nil, err
+	}
+	var d datediff.Diff
+	switch {
+	case req.GetFormat() != "" || mode == 0:
+		format := req.GetFormat()
+		if format == "" {
+			format = "%Y %M %D"
+		}
+		d, err = datediff.NewDiff(start, end, format, opts...)
+	default:
+		d, err = datediff.NewDiffWithMode(start, end, mode, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &DiffResponse{Diff: ToProto(d), Text: d.String()}, nil
+}
+
+// parseDate parses the date field as datediff.ParseDate does.
+func parseDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("missing %s date", name)
+	}
+	t, err := datediff.ParseDate(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date %q", name, value)
+	}
+	return t, nil
+}
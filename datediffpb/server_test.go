@@ -0,0 +1,110 @@
+package datediffpb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antklim/datediff/datediffpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestServerDiff(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		req      *datediffpb.DiffRequest
+		expected *datediffpb.DiffResponse
+	}{
+		{
+			desc: "default format",
+			req:  &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21"},
+			expected: &datediffpb.DiffResponse{
+				Diff: &datediffpb.Diff{Years: 2, Months: 3, Days: 4, Format: "%Y %M %D", Modes: []datediffpb.DiffMode{
+					datediffpb.DiffMode_DIFF_MODE_YEARS, datediffpb.DiffMode_DIFF_MODE_MONTHS, datediffpb.DiffMode_DIFF_MODE_DAYS,
+				}, Start: "2000-04-17T00:00:00Z", End: "2002-07-21T00:00:00Z"},
+				Text: "2 years 3 months 4 days",
+			},
+		},
+		{
+			desc: "modes and locale",
+			req: &datediffpb.DiffRequest{
+				Start:  "2000-04-17T10:00:00Z",
+				End:    "2000-05-20T10:00:00Z",
+				Modes:  []datediffpb.DiffMode{datediffpb.DiffMode_DIFF_MODE_WEEKS, datediffpb.DiffMode_DIFF_MODE_DAYS},
+				Locale: "de",
+			},
+			expected: &datediffpb.DiffResponse{
+				Diff: &datediffpb.Diff{Weeks: 4, Days: 5, Modes: []datediffpb.DiffMode{
+					datediffpb.DiffMode_DIFF_MODE_WEEKS, datediffpb.DiffMode_DIFF_MODE_DAYS,
+				}, Start: "2000-04-17T10:00:00Z", End: "2000-05-20T10:00:00Z"},
+				Text: "4 Wochen 5 Tage",
+			},
+		},
+		{
+			desc: "calendar",
+			req:  &datediffpb.DiffRequest{Start: "2021-03-21", End: "2022-03-21", Format: "%Y %D", Calendar: "persian"},
+			expected: &datediffpb.DiffResponse{
+				Diff: &datediffpb.Diff{Years: 1, Format: "%Y %D", Modes: []datediffpb.DiffMode{
+					datediffpb.DiffMode_DIFF_MODE_YEARS, datediffpb.DiffMode_DIFF_MODE_DAYS,
+				}, Start: "2021-03-21T00:00:00Z", End: "2022-03-21T00:00:00Z"},
+				Text: "1 year",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		got, err := datediffpb.Server{}.Diff(context.Background(), tC.req)
+		if err != nil {
+			t.Errorf("Diff() %s failed: %v", tC.desc, err)
+		} else if !proto.Equal(got, tC.expected) {
+			t.Errorf("Diff() %s = %v, want %v", tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestServerDiffFails(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		desc string
+		ctx  context.Context
+		req  *datediffpb.DiffRequest
+		err  string
+	}{
+		{desc: "canceled context", ctx: canceled, req: &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21"}, err: "context canceled"},
+		{desc: "missing start date", req: &datediffpb.DiffRequest{End: "2002-07-21"}, err: "missing start date"},
+		{desc: "invalid end date", req: &datediffpb.DiffRequest{Start: "2000-04-17", End: "21.07.2002"}, err: `invalid end date "21.07.2002"`},
+		{desc: "start after end", req: &datediffpb.DiffRequest{Start: "2002-07-21", End: "2000-04-17"}, err: "start date is after end date"},
+		{desc: "unknown locale", req: &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21", Locale: "xx"}, err: `unknown locale "xx"`},
+		{desc: "unknown calendar", req: &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21", Calendar: "mayan"}, err: `unknown calendar "mayan"`},
+		{desc: "unspecified mode", req: &datediffpb.DiffRequest{Start: "2000-04-17", End: "2002-07-21", Modes: []datediffpb.DiffMode{datediffpb.DiffMode_DIFF_MODE_UNSPECIFIED}}, err: "invalid dates difference mode DIFF_MODE_UNSPECIFIED"},
+	}
+	for _, tC := range testCases {
+		ctx := tC.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		_, err := datediffpb.Server{}.Diff(ctx, tC.req)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.err {
+			t.Errorf("Diff() %s error = %q, want %q", tC.desc, err.Error(), tC.err)
+		}
+	}
+}
+
+func TestDiffServiceDescriptor(t *testing.T) {
+	sd := datediffpb.File_datediff_proto.Services().ByName("DiffService")
+	if sd == nil {
+		t.Fatal("DiffService is not defined")
+	}
+	m := sd.Methods().ByName("Diff")
+	if m == nil {
+		t.Fatal("DiffService.Diff is not defined")
+	}
+	if got := m.Input().FullName(); got != "datediff.v1.DiffRequest" {
+		t.Errorf("DiffService.Diff input = %s, want datediff.v1.DiffRequest", got)
+	}
+	if got := m.Output().FullName(); got != "datediff.v1.DiffResponse" {
+		t.Errorf("DiffService.Diff output = %s, want datediff.v1.DiffResponse", got)
+	}
+}
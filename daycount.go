@@ -0,0 +1,121 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+// DayCount is the day count convention used to accrue interest.
+type DayCount uint8
+
+// These are supported day count conventions.
+const (
+	// Actual360 counts actual days in 360 days years, "ACT/360".
+	Actual360 DayCount = iota
+	// Actual365Fixed counts actual days in 365 days years, "ACT/365F".
+	Actual365Fixed
+	// ActualActualISDA counts actual days of every calendar year in its
+	// actual length, "ACT/ACT ISDA".
+	ActualActualISDA
+	// Thirty360 counts 30 days months and 360 days years by the 2006 ISDA
+	// definitions of the bond basis, "30/360". The 31st day of the start
+	// month is the 30th, and the 31st day of the end month is the 30th when
+	// the start day is the 30th or the 31st.
+	Thirty360
+	// Thirty360E counts 30 days months and 360 days years by the eurobond
+	// basis, "30E/360". The 31st day of any month is the 30th.
+	Thirty360E
+)
+
+var dayCountNames = map[DayCount]string{
+	Actual360:        "ACT/360",
+	Actual365Fixed:   "ACT/365F",
+	ActualActualISDA: "ACT/ACT ISDA",
+	Thirty360:        "30/360",
+	Thirty360E:       "30E/360",
+}
+
+// String returns the common name of the day count convention, i.e "ACT/360".
+func (c DayCount) String() string {
+	if name, ok := dayCountNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("DayCount(%d)", c)
+}
+
+// AccrualDays returns the number of days interest accrues for between dates
+// by the day count convention. Actual conventions count calendar days, and
+// 30/360 conventions count days of 30 days months. The time of the day is
+// ignored, and the end date is converted to the location of the start date.
+//
+// AccrualDays returns error in the following cases:
+//
+//	start date is after end date
+//	unknown day count convention
+func AccrualDays(start, end time.Time, c DayCount) (int, error) {
+	if start.After(end) {
+		return 0, ErrStartAfterEnd
+	}
+	s, e := newCivilDate(start), newCivilDate(end.In(start.Location()))
+	switch c {
+	case Actual360, Actual365Fixed, ActualActualISDA:
+		return int(e.days - s.days), nil
+	case Thirty360, Thirty360E:
+		return thirty360Days(s, e, c), nil
+	}
+	return 0, fmt.Errorf("unknown day count convention %d", c)
+}
+
+// YearFraction returns the fraction of the year interest accrues for between
+// dates by the day count convention, i.e 0.5 for the half of the year. It
+// accepts the same dates as AccrualDays does.
+//
+// YearFraction returns error in the following cases:
+//
+//	start date is after end date
+//	unknown day count convention
+func YearFraction(start, end time.Time, c DayCount) (float64, error) {
+	days, err := AccrualDays(start, end, c)
+	if err != nil {
+		return 0, err
+	}
+	switch c {
+	case Actual365Fixed:
+		return float64(days) / 365, nil
+	case ActualActualISDA:
+		return actualActualFraction(newCivilDate(start), newCivilDate(end.In(start.Location()))), nil
+	}
+	return float64(days) / 360, nil
+}
+
+// thirty360Days returns the number of days between dates of 30 days months.
+func thirty360Days(s, e civilDate, c DayCount) int {
+	d1, d2 := s.day, e.day
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && (c == Thirty360E || d1 == 30) {
+		d2 = 30
+	}
+	return 360*(e.year-s.year) + 30*(e.month-s.month) + d2 - d1
+}
+
+// actualActualFraction sums fractions of calendar years between dates, days
+// of leap years are counted in 366 days years.
+func actualActualFraction(s, e civilDate) float64 {
+	var fraction float64
+	from := s.days
+	for year := s.year; year <= e.year; year++ {
+		to := civilDays(year+1, time.January, 1)
+		if to > e.days {
+			to = e.days
+		}
+		length := 365.0
+		if isLeapYear(year) {
+			length = 366
+		}
+		fraction += float64(to-from) / length
+		from = to
+	}
+	return fraction
+}
@@ -0,0 +1,93 @@
+package datediff_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestAccrualDays(t *testing.T) {
+	testCases := []struct {
+		start    time.Time
+		end      time.Time
+		c        datediff.DayCount
+		expected int
+	}{
+		{start: date(2007, time.January, 15), end: date(2007, time.July, 15), c: datediff.Actual360, expected: 181},
+		{start: date(2007, time.January, 15), end: date(2007, time.July, 15), c: datediff.Actual365Fixed, expected: 181},
+		{start: date(2007, time.January, 15), end: date(2007, time.July, 15), c: datediff.Thirty360, expected: 180},
+		{start: date(2007, time.January, 31), end: date(2007, time.March, 31), c: datediff.Thirty360, expected: 60},
+		{start: date(2007, time.January, 15), end: date(2007, time.March, 31), c: datediff.Thirty360, expected: 76},
+		{start: date(2007, time.January, 15), end: date(2007, time.March, 31), c: datediff.Thirty360E, expected: 75},
+		{start: date(2007, time.February, 28), end: date(2007, time.March, 31), c: datediff.Thirty360, expected: 33},
+		{start: date(2007, time.February, 28), end: date(2007, time.March, 31), c: datediff.Thirty360E, expected: 32},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.AccrualDays(tC.start, tC.end, tC.c)
+		if err != nil {
+			t.Errorf("AccrualDays(%s) failed: %v", tC.c, err)
+		} else if got != tC.expected {
+			t.Errorf("AccrualDays(%s, %s, %s) = %d, want %d",
+				tC.start.Format("2006-01-02"), tC.end.Format("2006-01-02"), tC.c, got, tC.expected)
+		}
+	}
+}
+
+func TestYearFraction(t *testing.T) {
+	testCases := []struct {
+		start    time.Time
+		end      time.Time
+		c        datediff.DayCount
+		expected float64
+	}{
+		{start: date(2007, time.January, 15), end: date(2007, time.July, 15), c: datediff.Actual360, expected: 181.0 / 360},
+		{start: date(2007, time.January, 15), end: date(2007, time.July, 15), c: datediff.Actual365Fixed, expected: 181.0 / 365},
+		{start: date(2007, time.January, 15), end: date(2007, time.July, 15), c: datediff.Thirty360, expected: 0.5},
+		// ISDA example
+		{start: date(2003, time.November, 1), end: date(2004, time.May, 1), c: datediff.ActualActualISDA, expected: 61.0/365 + 121.0/366},
+		{start: date(2004, time.January, 1), end: date(2005, time.January, 1), c: datediff.ActualActualISDA, expected: 1},
+		{start: date(2004, time.May, 1), end: date(2004, time.May, 1), c: datediff.ActualActualISDA, expected: 0},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.YearFraction(tC.start, tC.end, tC.c)
+		if err != nil {
+			t.Errorf("YearFraction(%s) failed: %v", tC.c, err)
+		} else if math.Abs(got-tC.expected) > 1e-12 {
+			t.Errorf("YearFraction(%s, %s, %s) = %v, want %v",
+				tC.start.Format("2006-01-02"), tC.end.Format("2006-01-02"), tC.c, got, tC.expected)
+		}
+	}
+}
+
+func TestAccrualDaysFails(t *testing.T) {
+	start := date(2007, time.January, 15)
+
+	testCases := []struct {
+		desc     string
+		end      time.Time
+		c        datediff.DayCount
+		expected string
+	}{
+		{
+			desc:     "start date is after end date",
+			end:      start.AddDate(0, 0, -1),
+			expected: "start date is after end date",
+		},
+		{
+			desc:     "unknown day count convention",
+			end:      start,
+			c:        datediff.DayCount(100),
+			expected: "unknown day count convention 100",
+		},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.YearFraction(start, tC.end, tC.c)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("YearFraction() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
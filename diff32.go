@@ -0,0 +1,58 @@
+package datediff
+
+import (
+	"errors"
+	"math"
+)
+
+var errInt32Overflow = errors.New("dates difference overflows int32")
+
+// Diff32 is the compact value of dates difference with int32 time units
+// values and the mode, but without the format, dates and the formatter. It's
+// intended for huge slices of dates differences kept in memory, i.e by
+// analytics pipelines, where it takes a fraction of the memory of Diff.
+type Diff32 struct {
+	Years  int32
+	Months int32
+	Weeks  int32
+	Days   int32
+	mode   DiffMode
+}
+
+// Int32 returns the compact value of dates difference. It returns error when
+// any of time units values overflows int32.
+func (d Diff) Int32() (Diff32, error) {
+	diff := Diff32{mode: d.mode}
+	for _, v := range []struct {
+		n   int
+		dst *int32
+	}{
+		{n: d.Years, dst: &diff.Years},
+		{n: d.Months, dst: &diff.Months},
+		{n: d.Weeks, dst: &diff.Weeks},
+		{n: d.Days, dst: &diff.Days},
+	} {
+		if v.n > math.MaxInt32 || v.n < math.MinInt32 {
+			return Diff32{}, errInt32Overflow
+		}
+		*v.dst = int32(v.n)
+	}
+	return diff, nil
+}
+
+// Mode returns time units of the dates difference, see Diff.Mode.
+func (d Diff32) Mode() DiffMode {
+	return d.mode
+}
+
+// Diff converts the compact value to Diff with the same mode. Dates
+// difference is formatted by the mode, since the format is not kept.
+func (d Diff32) Diff() Diff {
+	return Diff{
+		Years:  int(d.Years),
+		Months: int(d.Months),
+		Weeks:  int(d.Weeks),
+		Days:   int(d.Days),
+		mode:   d.mode,
+	}
+}
@@ -0,0 +1,57 @@
+package datediff_test
+
+import (
+	"math"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/antklim/datediff"
+)
+
+func TestDiff32(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	d := mustNewDiff(t, start, start.AddDate(2, 0, 5), "%Y and %M and %D")
+
+	got, err := d.Int32()
+	if err != nil {
+		t.Fatalf("Int32() failed: %v", err)
+	}
+	if want := (datediff.Diff32{Years: 2, Days: 5}); got.Years != want.Years || got.Months != want.Months ||
+		got.Weeks != want.Weeks || got.Days != want.Days {
+		t.Errorf("Int32() = %+v, want %+v", got, want)
+	}
+	if got.Mode() != d.Mode() {
+		t.Errorf("Int32() mode = %s, want %s", got.Mode(), d.Mode())
+	}
+
+	back := got.Diff()
+	if !back.Equal(d) || back.Mode() != d.Mode() {
+		t.Errorf("Diff() = %#v, want %#v", back, d)
+	}
+	if got, want := back.StringWithZeros(), "2 years 0 months 5 days"; got != want {
+		t.Errorf("Diff() StringWithZeros() = %s, want %s", got, want)
+	}
+
+	if c, f := unsafe.Sizeof(datediff.Diff32{}), unsafe.Sizeof(datediff.Diff{}); c*2 > f {
+		t.Errorf("Diff32 takes %d bytes, want at most half of %d bytes of Diff", c, f)
+	}
+}
+
+func TestDiff32Overflow(t *testing.T) {
+	if math.MaxInt == math.MaxInt32 {
+		t.Skip("int is 32-bit")
+	}
+	// int64 values keep the test compiling on 32-bit platforms
+	over, under := int64(math.MaxInt32)+1, int64(math.MinInt32)-1
+	for _, d := range []datediff.Diff{
+		{Days: int(over)},
+		{Years: int(under)},
+	} {
+		if _, err := d.Int32(); err == nil {
+			t.Errorf("Int32(%#v) want to fail due to int32 overflow", d)
+		} else if err.Error() != "dates difference overflows int32" {
+			t.Errorf("Int32(%#v) error = %v, want dates difference overflows int32", d, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package datediff
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var errIntOverflow = errors.New("dates difference overflows int")
+
+// Diff64 describes dates difference with int64 time units values. It's
+// intended for astronomical or geological ranges of dates, when values of
+// Diff can overflow int on 32-bit platforms.
+type Diff64 struct {
+	Years  int64
+	Months int64
+	Weeks  int64
+	Days   int64
+}
+
+// NewDiff64 creates Diff64 according to the provided mode. Time units are
+// calculated as NewDiffWithMode does with integer arithmetic of day numbers,
+// so the calculation takes the same time for any range of dates.
+//
+// NewDiff64 returns error in the following cases:
+//
+//	start date is after end date
+//	undefined dates difference mode
+func NewDiff64(start, end time.Time, mode DiffMode) (Diff64, error) {
+	if start.After(end) {
+		return Diff64{}, ErrStartAfterEnd
+	}
+	if mode == 0 || !mode.valid() {
+		return Diff64{}, ErrUndefinedMode
+	}
+
+	return civilDiff(start, end, mode), nil
+}
+
+// Int64 returns dates difference with int64 time units values.
+func (d Diff) Int64() Diff64 {
+	return Diff64{
+		Years:  int64(d.Years),
+		Months: int64(d.Months),
+		Weeks:  int64(d.Weeks),
+		Days:   int64(d.Days),
+	}
+}
+
+// Diff converts dates difference to Diff. It returns error when any of time
+// units values overflows int.
+func (d Diff64) Diff() (Diff, error) {
+	var diff Diff
+	for _, v := range []struct {
+		n   int64
+		dst *int
+	}{
+		{n: d.Years, dst: &diff.Years},
+		{n: d.Months, dst: &diff.Months},
+		{n: d.Weeks, dst: &diff.Weeks},
+		{n: d.Days, dst: &diff.Days},
+	} {
+		if v.n > math.MaxInt || v.n < math.MinInt {
+			return Diff{}, errIntOverflow
+		}
+		*v.dst = int(v.n)
+	}
+	return diff, nil
+}
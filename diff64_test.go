@@ -0,0 +1,119 @@
+package datediff_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestNewDiff64(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	base := time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var ranges [][2]time.Time
+	for i := 0; i < 500; i++ {
+		start := base.Add(time.Duration(r.Int63n(int64(200 * 365 * datediff.Day))))
+		end := start.Add(time.Duration(r.Int63n(int64(30 * 365 * datediff.Day))))
+		ranges = append(ranges, [2]time.Time{start, end})
+	}
+	// the leap day start date is normalized when advanced by years
+	leapDay := time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)
+	for days := 360; days < 400; days++ {
+		ranges = append(ranges, [2]time.Time{leapDay, leapDay.AddDate(0, 0, days)})
+	}
+
+	for _, rng := range ranges {
+		start, end := rng[0], rng[1]
+		for m := 1; m < 16; m++ {
+			mode := datediff.DiffMode(m << 4)
+			want, err := datediff.NewDiffWithMode(start, end, mode)
+			if err != nil {
+				t.Fatalf("NewDiffWithMode() failed: %v", err)
+			}
+			got, err := datediff.NewDiff64(start, end, mode)
+			if err != nil {
+				t.Fatalf("NewDiff64() failed: %v", err)
+			}
+			if got != want.Int64() {
+				t.Fatalf("NewDiff64(%s, %s, %s) = %v, want %v", start, end, mode, got, want.Int64())
+			}
+		}
+	}
+}
+
+func TestNewDiff64LongRange(t *testing.T) {
+	start := time.Date(-1000000, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(1000000, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		mode     datediff.DiffMode
+		expected datediff.Diff64
+	}{
+		{mode: datediff.ModeDays, expected: datediff.Diff64{Days: 730485000}},
+		{mode: datediff.ModeWeeks | datediff.ModeDays, expected: datediff.Diff64{Weeks: 104355000}},
+		{mode: datediff.ModeMonths, expected: datediff.Diff64{Months: 24000000}},
+		{mode: datediff.ModeAll, expected: datediff.Diff64{Years: 2000000}},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.NewDiff64(start, end, tC.mode)
+		if err != nil {
+			t.Errorf("NewDiff64(%s) failed: %v", tC.mode, err)
+		} else if got != tC.expected {
+			t.Errorf("NewDiff64(%s) = %v, want %v", tC.mode, got, tC.expected)
+		}
+	}
+}
+
+func TestNewDiff64Fails(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		mode     datediff.DiffMode
+		expected string
+	}{
+		{
+			desc:     "start date is after end date",
+			start:    now.Add(time.Hour),
+			end:      now,
+			mode:     datediff.ModeDays,
+			expected: "start date is after end date",
+		},
+		{
+			desc:     "undefined mode",
+			start:    now,
+			end:      now,
+			expected: "undefined dates difference mode",
+		},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.NewDiff64(tC.start, tC.end, tC.mode)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("NewDiff64() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
+
+func TestDiff64Diff(t *testing.T) {
+	d := datediff.Diff64{Years: 1, Months: 2, Weeks: 3, Days: 4}
+	got, err := d.Diff()
+	if err != nil {
+		t.Fatalf("Diff() failed: %v", err)
+	}
+	if want := (datediff.Diff{Years: 1, Months: 2, Weeks: 3, Days: 4}); !got.Equal(want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+
+	if math.MaxInt == math.MaxInt64 {
+		t.Skip("int is 64-bit")
+	}
+	if _, err := (datediff.Diff64{Days: math.MaxInt64}).Diff(); err == nil {
+		t.Errorf("Diff() want to fail due to int overflow")
+	}
+}
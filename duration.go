@@ -0,0 +1,76 @@
+package datediff
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// These are average lengths of time units in the Gregorian calendar. The
+// average year is 365.2425 days, the average month is 1/12 of the average
+// year.
+const (
+	AverageYear  = 31556952 * time.Second
+	AverageMonth = AverageYear / monthsInYear
+	Week         = daysInWeek * Day
+	Day          = 24 * time.Hour
+)
+
+var errDurationOverflow = errors.New("dates difference overflows time.Duration")
+
+// Duration returns duration of dates difference. When dates difference was
+// calculated from dates, the exact duration between start and end dates is
+// returned. Otherwise the duration is approximate, years and months are
+// converted using AverageYear and AverageMonth, weeks and days are converted
+// using Week and Day. It returns error when the duration overflows
+// time.Duration.
+func (d Diff) Duration() (time.Duration, error) {
+	if d.hasDates() {
+		// time.Time.Sub saturates on overflow, the result is verified by
+		// adding it back to the start date
+		end := d.rangeEnd()
+		duration := end.Sub(d.start)
+		if !d.start.Add(duration).Equal(end) {
+			return 0, errDurationOverflow
+		}
+		return duration, nil
+	}
+
+	var total time.Duration
+	for _, u := range []struct {
+		n    int
+		unit time.Duration
+	}{
+		{n: d.Years, unit: AverageYear},
+		{n: d.Months, unit: AverageMonth},
+		{n: d.Weeks, unit: Week},
+		{n: d.Days, unit: Day},
+	} {
+		n := int64(u.n)
+		if n > math.MaxInt64/int64(u.unit) || n < math.MinInt64/int64(u.unit) {
+			return 0, errDurationOverflow
+		}
+		v := time.Duration(n) * u.unit
+		if (v > 0 && total > math.MaxInt64-v) || (v < 0 && total < math.MinInt64-v) {
+			return 0, errDurationOverflow
+		}
+		total += v
+	}
+	return total, nil
+}
+
+// FromDuration converts the duration to dates difference in the time units of
+// the mode. The duration is anchored at the date, i.e it's the difference
+// between anchor and anchor.Add(d). Negative duration counts back from the
+// anchor and produces negative time units values. Time units are calculated
+// with calendar accuracy as NewDiffWithMode does.
+func FromDuration(d time.Duration, mode DiffMode, anchor time.Time) (Diff, error) {
+	if d >= 0 {
+		return NewDiffWithMode(anchor, anchor.Add(d), mode)
+	}
+	diff, err := NewDiffWithMode(anchor.Add(d), anchor, mode)
+	if err != nil {
+		return Diff{}, err
+	}
+	return diff.negated(), nil
+}
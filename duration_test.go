@@ -0,0 +1,96 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestDuration(t *testing.T) {
+	testCases := []struct {
+		diff     datediff.Diff
+		expected time.Duration
+	}{
+		{diff: datediff.Diff{}, expected: 0},
+		{diff: datediff.Diff{Days: 3}, expected: 72 * time.Hour},
+		{diff: datediff.Diff{Weeks: 2, Days: 1}, expected: 15 * 24 * time.Hour},
+		{diff: datediff.Diff{Years: 1}, expected: 8765*time.Hour + 49*time.Minute + 12*time.Second},
+		{diff: datediff.Diff{Months: 12}, expected: datediff.AverageYear},
+		{diff: datediff.Diff{Years: 1, Days: -1}, expected: datediff.AverageYear - 24*time.Hour},
+	}
+	for _, tC := range testCases {
+		got, err := tC.diff.Duration()
+		if err != nil {
+			t.Errorf("Duration(%#v) failed: %v", tC.diff, err)
+		} else if got != tC.expected {
+			t.Errorf("Duration(%#v) = %v, want %v", tC.diff, got, tC.expected)
+		}
+	}
+}
+
+func TestDurationExact(t *testing.T) {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiffWithMode(start, end, datediff.ModeYears)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	// 2000 is a leap year
+	expected := 366 * 24 * time.Hour
+	got, err := diff.Duration()
+	if err != nil {
+		t.Errorf("Duration() failed: %v", err)
+	} else if got != expected {
+		t.Errorf("Duration() = %v, want %v", got, expected)
+	}
+
+	diff, err = datediff.NewDiffWithMode(start, start.AddDate(300, 0, 0), datediff.ModeYears)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	if got, err := diff.Duration(); err == nil {
+		t.Errorf("Duration() = %v, want to fail due to overflow", got)
+	}
+}
+
+func TestDurationFails(t *testing.T) {
+	testCases := []datediff.Diff{
+		{Years: 300},
+		{Days: -110000},
+		{Years: 200, Months: 12 * 100},
+	}
+	for _, tC := range testCases {
+		got, err := tC.Duration()
+		if err == nil {
+			t.Errorf("Duration(%#v) = %v, want to fail due to overflow", tC, got)
+		} else if err.Error() != "dates difference overflows time.Duration" {
+			t.Errorf("Duration(%#v) failed: %v, want to fail due to overflow", tC, err)
+		}
+	}
+}
+
+func TestFromDuration(t *testing.T) {
+	anchor := time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		d        time.Duration
+		mode     datediff.DiffMode
+		expected datediff.Diff
+	}{
+		{d: 0, mode: datediff.ModeDays, expected: datediff.Diff{}},
+		{d: 36 * time.Hour, mode: datediff.ModeDays, expected: datediff.Diff{Days: 1}},
+		{d: 30 * 24 * time.Hour, mode: datediff.ModeMonths | datediff.ModeDays, expected: datediff.Diff{Days: 30}},
+		{d: 31 * 24 * time.Hour, mode: datediff.ModeMonths | datediff.ModeDays, expected: datediff.Diff{Months: 1}},
+		{d: 366 * 24 * time.Hour, mode: datediff.ModeYears | datediff.ModeDays, expected: datediff.Diff{Years: 1}},
+		{d: 400 * 24 * time.Hour, mode: datediff.ModeWeeks, expected: datediff.Diff{Weeks: 57}},
+		{d: -31 * 24 * time.Hour, mode: datediff.ModeMonths | datediff.ModeDays, expected: datediff.Diff{Months: -1}},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.FromDuration(tC.d, tC.mode, anchor)
+		if err != nil {
+			t.Errorf("FromDuration(%v, %s) failed: %v", tC.d, tC.mode, err)
+		} else if !got.Equal(tC.expected) {
+			t.Errorf("FromDuration(%v, %s) = %#v, want %#v", tC.d, tC.mode, got, tC.expected)
+		}
+	}
+}
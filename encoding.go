@@ -0,0 +1,449 @@
+package datediff
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// binaryVersion is the version of the binary encoding layout.
+const binaryVersion = 2
+
+var errBinaryLength = errors.New("invalid binary encoding length")
+
+// isoDesignators are ISO 8601 duration designators of time units in the order
+// of significance.
+var isoDesignators = []struct {
+	designator byte
+	mode       DiffMode
+}{
+	{designator: 'Y', mode: ModeYears},
+	{designator: 'M', mode: ModeMonths},
+	{designator: 'W', mode: ModeWeeks},
+	{designator: 'D', mode: ModeDays},
+}
+
+// diffObject is the JSON object and YAML mapping schema of dates difference.
+type diffObject struct {
+	Years  int        `json:"years" yaml:"years"`
+	Months int        `json:"months" yaml:"months"`
+	Weeks  int        `json:"weeks" yaml:"weeks"`
+	Days   int        `json:"days" yaml:"days"`
+	Format string     `json:"format,omitempty" yaml:"format,omitempty"`
+	Mode   DiffMode   `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Start  *time.Time `json:"start,omitempty" yaml:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty" yaml:"end,omitempty"`
+	// InclusiveEnd is set when the end date is included.
+	InclusiveEnd bool `json:"inclusiveEnd,omitempty" yaml:"inclusiveEnd,omitempty"`
+}
+
+// diff returns dates difference defined by the object.
+func (o diffObject) diff() (Diff, error) {
+	mode, err := decodeMode(o.Format, o.Mode)
+	if err != nil {
+		return Diff{}, err
+	}
+	diff := Diff{
+		Years:     o.Years,
+		Months:    o.Months,
+		Weeks:     o.Weeks,
+		Days:      o.Days,
+		rawFormat: o.Format,
+		mode:      mode,
+	}
+	if o.Start != nil {
+		diff.start = *o.Start
+	}
+	if o.End != nil {
+		diff.end = *o.End
+	}
+	diff.inclusive = o.InclusiveEnd
+	if diff.start.After(diff.end) && !diff.end.IsZero() {
+		return Diff{}, ErrStartAfterEnd
+	}
+	return diff, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Dates difference is
+// encoded as an object with the following schema:
+//
+//	{"years":2,"months":3,"weeks":0,"days":4,"format":"%Y %M %D","mode":"years|months|days",
+//	 "start":"2000-04-17T00:00:00Z","end":"2002-07-21T00:00:00Z"}
+//
+// Format is omitted when dates difference was created by NewDiffWithMode.
+// Start and end dates are encoded in RFC 3339 format, they are omitted when
+// dates difference was not calculated from dates. "inclusiveEnd":true is
+// added when the end date is included.
+func (d Diff) MarshalJSON() ([]byte, error) {
+	return json.Marshal(diffObject{
+		Years:        d.Years,
+		Months:       d.Months,
+		Weeks:        d.Weeks,
+		Days:         d.Days,
+		Format:       d.rawFormat,
+		Mode:         d.mode,
+		Start:        optionalTime(d.start),
+		End:          optionalTime(d.end),
+		InclusiveEnd: d.inclusive,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It expects the
+// schema produced by MarshalJSON, or the canonical string produced by
+// MarshalText. When format is provided the mode is derived from it, the
+// provided mode should match the format.
+func (d *Diff) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(s))
+	}
+	var o diffObject
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+	diff, err := o.diff()
+	if err != nil {
+		return err
+	}
+	*d = diff
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. It accepts the
+// canonical string produced by MarshalText, which is used by YAML encoders to
+// marshal dates difference, ISO 8601 duration, or the mapping with the JSON
+// object schema:
+//
+//	retention: P1Y6M
+//	probation:
+//	  months: 3
+//	  format: "%M"
+func (d *Diff) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return d.UnmarshalText([]byte(value.Value))
+	}
+	var o diffObject
+	if err := value.Decode(&o); err != nil {
+		return err
+	}
+	diff, err := o.diff()
+	if err != nil {
+		return err
+	}
+	*d = diff
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. Dates
+// difference is encoded as the canonical string, see Diff.Canonical.
+func (d Diff) MarshalText() ([]byte, error) {
+	return []byte(d.Canonical()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the canonical string produced by MarshalText, or ISO 8601 duration produced
+// by ISOString.
+func (d *Diff) UnmarshalText(text []byte) error {
+	s := string(text)
+	var (
+		diff Diff
+		err  error
+	)
+	if strings.HasPrefix(s, "v") {
+		diff, err = ParseCanonical(s)
+	} else {
+		diff, err = parseISO(s)
+	}
+	if err != nil {
+		return err
+	}
+	*d = diff
+	return nil
+}
+
+// ISOString returns ISO 8601 duration that contains time units of the dates
+// difference mode, i.e "P2Y0M4D" for 2 years 4 days difference calculated in
+// years, months and days. Weeks can be combined with other time units, i.e
+// "P1M2W". Negative values are prefixed by minus sign, i.e "P-2Y3M". Time
+// units out of the mode, i.e of a struct literal, are included when they have
+// non-zero values, as Canonical does. The format of dates difference is not
+// encoded.
+func (d Diff) ISOString() string {
+	b := []byte{'P'}
+	for _, u := range isoDesignators {
+		if d.mode&u.mode == 0 && d.value(u.mode) == 0 {
+			continue
+		}
+		b = strconv.AppendInt(b, int64(d.value(u.mode)), 10)
+		b = append(b, u.designator)
+	}
+	if len(b) == 1 {
+		b = append(b, '0', 'D')
+	}
+	return string(b)
+}
+
+// parseISO parses ISO 8601 duration produced by ISOString. The mode of dates
+// difference is defined by time units of the duration.
+func parseISO(s string) (Diff, error) {
+	if !strings.HasPrefix(s, "P") || len(s) == 1 {
+		return Diff{}, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+
+	var diff Diff
+	rest := s[1:]
+	next := 0 // index of the next allowed designator
+	for rest != "" {
+		i := strings.IndexFunc(rest[1:], func(r rune) bool { return r < '0' || r > '9' }) + 1
+		if i == 0 || rest[:i] == "-" {
+			return Diff{}, fmt.Errorf("invalid ISO 8601 duration %q", s)
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return Diff{}, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, errors.Unwrap(err))
+		}
+		j := next
+		for j < len(isoDesignators) && isoDesignators[j].designator != rest[i] {
+			j++
+		}
+		if j == len(isoDesignators) {
+			return Diff{}, fmt.Errorf("invalid ISO 8601 duration %q: unexpected designator %c", s, rest[i])
+		}
+		diff.mode |= isoDesignators[j].mode
+		diff.setValue(isoDesignators[j].mode, n)
+		next = j + 1
+		rest = rest[i+1:]
+	}
+
+	return diff, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. Dates
+// difference is encoded with the following layout:
+//
+//	version byte (2)
+//	mode byte
+//	years, months, weeks, days as signed varints
+//	format length as unsigned varint followed by format bytes
+//	flags byte, see binaryStart, binaryEnd and binaryInclusive
+//	start and end dates, when they are known, see appendBinaryTime
+//
+// Version 1 layout without flags and dates is accepted by UnmarshalBinary.
+func (d Diff) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 2, 3+4*binary.MaxVarintLen64+binary.MaxVarintLen64+len(d.rawFormat)+2*binaryTimeLen)
+	b[0] = binaryVersion
+	b[1] = byte(d.mode)
+
+	var buf [binary.MaxVarintLen64]byte
+	for _, v := range []int{d.Years, d.Months, d.Weeks, d.Days} {
+		n := binary.PutVarint(buf[:], int64(v))
+		b = append(b, buf[:n]...)
+	}
+	n := binary.PutUvarint(buf[:], uint64(len(d.rawFormat)))
+	b = append(b, buf[:n]...)
+	b = append(b, d.rawFormat...)
+
+	flags := d.binaryFlags()
+	b = append(b, flags)
+	if flags&binaryStart != 0 {
+		b = appendBinaryTime(b, d.start)
+	}
+	if flags&binaryEnd != 0 {
+		b = appendBinaryTime(b, d.end)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// expects the layout produced by MarshalBinary.
+func (d *Diff) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errBinaryLength
+	}
+	version := data[0]
+	if version != 1 && version != binaryVersion {
+		return fmt.Errorf("unsupported binary encoding version %d", version)
+	}
+	mode := DiffMode(data[1])
+	data = data[2:]
+
+	var values [4]int
+	for i := range values {
+		v, n := binary.Varint(data)
+		if n <= 0 || int64(int(v)) != v {
+			return errBinaryLength
+		}
+		values[i] = int(v)
+		data = data[n:]
+	}
+	l, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < l || (version == 1 && uint64(len(data)-n) != l) {
+		return errBinaryLength
+	}
+	rawFormat := string(data[n : n+int(l)])
+	data = data[n+int(l):]
+
+	mode, err := decodeMode(rawFormat, mode)
+	if err != nil {
+		return err
+	}
+	diff := Diff{
+		Years:     values[0],
+		Months:    values[1],
+		Weeks:     values[2],
+		Days:      values[3],
+		rawFormat: rawFormat,
+		mode:      mode,
+	}
+	if version > 1 {
+		if len(data) == 0 {
+			return errBinaryLength
+		}
+		flags := data[0]
+		if flags&^binaryFlagsMask != 0 {
+			return fmt.Errorf("invalid binary encoding flags 0x%02x", flags)
+		}
+		if data, err = diff.readBinaryDates(flags, data[1:]); err != nil {
+			return err
+		}
+		if len(data) != 0 {
+			return errBinaryLength
+		}
+	}
+	*d = diff
+	return nil
+}
+
+// These are flags of the binary encoding of dates difference.
+const (
+	binaryStart     byte = 1 << iota // start date is encoded
+	binaryEnd                        // end date is encoded
+	binaryInclusive                  // end date is included
+
+	binaryFlagsMask = binaryStart | binaryEnd | binaryInclusive
+)
+
+// binaryTimeLen is the maximum length of the date encoded by
+// appendBinaryTime.
+const binaryTimeLen = 3 * binary.MaxVarintLen64
+
+// binaryFlags returns flags of the binary encoding of dates difference.
+func (d Diff) binaryFlags() byte {
+	var flags byte
+	if !d.start.IsZero() {
+		flags |= binaryStart
+	}
+	if !d.end.IsZero() {
+		flags |= binaryEnd
+	}
+	if d.inclusive {
+		flags |= binaryInclusive
+	}
+	return flags
+}
+
+// readBinaryDates reads dates marked by the flags, that are encoded by
+// appendBinaryTime, and returns the rest of data.
+func (d *Diff) readBinaryDates(flags byte, data []byte) ([]byte, error) {
+	var err error
+	if flags&binaryStart != 0 {
+		if d.start, data, err = readBinaryTime(data); err != nil {
+			return nil, err
+		}
+	}
+	if flags&binaryEnd != 0 {
+		if d.end, data, err = readBinaryTime(data); err != nil {
+			return nil, err
+		}
+	}
+	if d.start.After(d.end) && !d.end.IsZero() {
+		return nil, ErrStartAfterEnd
+	}
+	d.inclusive = flags&binaryInclusive != 0
+	return data, nil
+}
+
+// appendBinaryTime appends the date as Unix seconds and zone offset seconds
+// as signed varints followed by nanoseconds as unsigned varint. The zone
+// name is not encoded, the decoded date has the fixed zone of the offset.
+func appendBinaryTime(b []byte, t time.Time) []byte {
+	_, offset := t.Zone()
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], t.Unix())
+	b = append(b, buf[:n]...)
+	n = binary.PutVarint(buf[:], int64(offset))
+	b = append(b, buf[:n]...)
+	n = binary.PutUvarint(buf[:], uint64(t.Nanosecond()))
+	return append(b, buf[:n]...)
+}
+
+// readBinaryTime reads the date encoded by appendBinaryTime and returns the
+// rest of data.
+func readBinaryTime(data []byte) (time.Time, []byte, error) {
+	sec, n := binary.Varint(data)
+	if n <= 0 {
+		return time.Time{}, nil, errBinaryLength
+	}
+	data = data[n:]
+	offset, n := binary.Varint(data)
+	if n <= 0 || int64(int(offset)) != offset {
+		return time.Time{}, nil, errBinaryLength
+	}
+	data = data[n:]
+	nsec, n := binary.Uvarint(data)
+	if n <= 0 || nsec >= uint64(time.Second) {
+		return time.Time{}, nil, errBinaryLength
+	}
+	t := time.Unix(sec, int64(nsec)).UTC()
+	if offset != 0 {
+		t = t.In(time.FixedZone("", int(offset)))
+	}
+	return t, data[n:], nil
+}
+
+// GobEncode implements the gob.GobEncoder interface. It uses the layout of
+// MarshalBinary, so the format, mode and dates survive gob round trips.
+func (d Diff) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (d *Diff) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+// optionalTime returns nil for zero time.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// decodeMode validates the decoded format and mode of dates difference. It
+// returns the mode derived from the format when format is not empty.
+func decodeMode(rawFormat string, mode DiffMode) (DiffMode, error) {
+	if !mode.valid() {
+		return 0, fmt.Errorf("invalid dates difference mode %d", mode)
+	}
+	if rawFormat == "" {
+		return mode, nil
+	}
+	formatMode, err := unmarshal(rawFormat)
+	if err != nil {
+		return 0, err
+	}
+	if mode != 0 && mode != formatMode {
+		return 0, fmt.Errorf("mode %d does not match format %q", mode, rawFormat)
+	}
+	return formatMode, nil
+}
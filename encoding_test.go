@@ -0,0 +1,609 @@
+package datediff_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+	"gopkg.in/yaml.v3"
+)
+
+func TestJSON(t *testing.T) {
+	testCases, err := loadDatediffRecordsForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tC := range testCases {
+		desc := fmt.Sprintf("NewDiff(%s, %s, %s)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.format)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiff(tC.start, tC.end, tC.format)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testJSONRoundTrip(t, diff)
+		})
+
+		desc = fmt.Sprintf("NewDiffWithMode(%s, %s, %d)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.mode)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiffWithMode(tC.start, tC.end, tC.mode)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testJSONRoundTrip(t, diff)
+		})
+	}
+}
+
+func testJSONRoundTrip(t *testing.T, diff datediff.Diff) {
+	t.Helper()
+	data, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var got datediff.Diff
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) failed: %v", data, err)
+	}
+	if !got.Equal(diff) {
+		t.Errorf("json.Unmarshal(%s) = %#v, want %#v", data, got, diff)
+	}
+	if got.String() != diff.String() {
+		t.Errorf("json.Unmarshal(%s) String() = %s, want %s", data, got.String(), diff.String())
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	diff, err := datediff.NewDiff(start, end, "%Y %M %D")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	{
+		expected := `{"years":3,"months":1,"weeks":0,"days":1,"format":"%Y %M %D","mode":"years|months|days","start":"2000-04-17T00:00:00Z","end":"2003-05-18T00:00:00Z"}`
+		got, err := json.Marshal(diff)
+		if err != nil {
+			t.Errorf("json.Marshal() failed: %v", err)
+		} else if string(got) != expected {
+			t.Errorf("json.Marshal() = %s, want %s", got, expected)
+		}
+	}
+
+	diff, err = datediff.NewDiffWithMode(start, end, datediff.ModeWeeks)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	{
+		expected := `{"years":0,"months":0,"weeks":160,"days":0,"mode":"weeks","start":"2000-04-17T00:00:00Z","end":"2003-05-18T00:00:00Z"}`
+		got, err := json.Marshal(diff)
+		if err != nil {
+			t.Errorf("json.Marshal() failed: %v", err)
+		} else if string(got) != expected {
+			t.Errorf("json.Marshal() = %s, want %s", got, expected)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	testCases := []string{
+		`{"years":2,"months":3,"days":4,"format":"%Y and %M"}`,
+		`"v1;mode=YM;y=2;m=3;d=4;fmt=%Y and %M"`,
+	}
+	for _, data := range testCases {
+		var got datediff.Diff
+		if err := json.Unmarshal([]byte(data), &got); err != nil {
+			t.Fatalf("json.Unmarshal(%s) failed: %v", data, err)
+		}
+		expected := "2 years and 3 months"
+		if got.String() != expected {
+			t.Errorf("json.Unmarshal(%s) String() = %s, want %s", data, got.String(), expected)
+		}
+	}
+}
+
+func TestUnmarshalJSONFails(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected string
+	}{
+		{
+			data:     `{"years":2,"format":"%X"}`,
+			expected: `format "%X" has unknown verb X`,
+		},
+		{
+			data:     `{"years":2,"format":"%Y","mode":64}`,
+			expected: `mode 64 does not match format "%Y"`,
+		},
+		{
+			data:     `{"years":2,"mode":3}`,
+			expected: "invalid dates difference mode 3",
+		},
+		{
+			data:     `{"years":2,"mode":"years","start":"2003-01-01T00:00:00Z","end":"2001-01-01T00:00:00Z"}`,
+			expected: "start date is after end date",
+		},
+	}
+	for _, tC := range testCases {
+		var got datediff.Diff
+		err := json.Unmarshal([]byte(tC.data), &got)
+		if err == nil {
+			t.Errorf("json.Unmarshal(%s) = %#v, want to fail due to %s", tC.data, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("json.Unmarshal(%s) failed: %v, want to fail due to %s", tC.data, err, tC.expected)
+		}
+	}
+
+	{
+		data := `{"years":"two"}`
+		var got datediff.Diff
+		if err := json.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("json.Unmarshal(%s) = %#v, want to fail due to invalid years", data, got)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	testCases, err := loadDatediffRecordsForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tC := range testCases {
+		desc := fmt.Sprintf("NewDiffWithMode(%s, %s, %d)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.mode)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiffWithMode(tC.start, tC.end, tC.mode)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			text, err := diff.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() failed: %v", err)
+			}
+			var got datediff.Diff
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText(%s) failed: %v", text, err)
+			}
+			if !got.Equal(diff) {
+				t.Errorf("UnmarshalText(%s) = %#v, want %#v", text, got, diff)
+			}
+			if got.StringWithZeros() != diff.StringWithZeros() {
+				t.Errorf("UnmarshalText(%s) StringWithZeros() = %s, want %s", text, got.StringWithZeros(), diff.StringWithZeros())
+			}
+		})
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		diff     datediff.Diff
+		expected string
+	}{
+		{diff: mustNewDiff(t, start, end, "%Y %M %D"), expected: "v1;mode=YMD;y=3;m=1;d=1;start=2000-04-17T00:00:00Z;end=2003-05-18T00:00:00Z;fmt=%Y %M %D"},
+		{
+			diff:     mustNewDiff(t, start, start.AddDate(3, 0, 0), "%Y and %W"),
+			expected: "v1;mode=YW;y=3;w=0;start=2000-04-17T00:00:00Z;end=2003-04-17T00:00:00Z;fmt=%Y and %W",
+		},
+		{diff: datediff.Diff{Years: 2, Days: -1}, expected: "v1;mode=;y=2;d=-1"},
+		{diff: datediff.Diff{}, expected: "v1;mode="},
+	}
+	for _, tC := range testCases {
+		got, err := tC.diff.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText() failed: %v", err)
+		} else if string(got) != tC.expected {
+			t.Errorf("MarshalText() = %s, want %s", got, tC.expected)
+		}
+	}
+
+	{
+		m := map[datediff.Diff]string{mustNewDiff(t, start, end, "%Y %M"): "tenure"}
+		expected := `{"v1;mode=YM;y=3;m=1;start=2000-04-17T00:00:00Z;end=2003-05-18T00:00:00Z;fmt=%Y %M":"tenure"}`
+		got, err := json.Marshal(m)
+		if err != nil {
+			t.Errorf("json.Marshal() failed: %v", err)
+		} else if string(got) != expected {
+			t.Errorf("json.Marshal() = %s, want %s", got, expected)
+		}
+	}
+}
+
+func TestISOString(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		diff     datediff.Diff
+		expected string
+	}{
+		{diff: mustNewDiff(t, start, end, "%Y %M %D"), expected: "P3Y1M1D"},
+		{diff: mustNewDiff(t, start, end, "%Y %W %D"), expected: "P3Y4W3D"},
+		{diff: mustNewDiff(t, start, start.AddDate(3, 0, 0), "%Y %M %D"), expected: "P3Y0M0D"},
+		{diff: mustNewDiff(t, start, end, "%D"), expected: "P1126D"},
+		{diff: datediff.Diff{}, expected: "P0D"},
+		{diff: datediff.Diff{Years: 2, Days: -4}, expected: "P2Y-4D"},
+		{diff: mustNewDiff(t, start, end, "%Y %M").Add(datediff.Diff{Weeks: 1}), expected: "P3Y1M1W"},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.ISOString(); got != tC.expected {
+			t.Errorf("ISOString() = %s, want %s", got, tC.expected)
+		}
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{text: "P2Y3M", expected: "2 years 3 months"},
+		{text: "P2Y0M4D", expected: "2 years 0 months 4 days"},
+		{text: "P1M2W", expected: "1 month 2 weeks"},
+		{text: "P-2Y3M", expected: "-2 years 3 months"},
+		{text: "P10D", expected: "10 days"},
+		{text: "v1;mode=YD;y=2;d=4;fmt=%Y and %D", expected: "2 years and 4 days"},
+	}
+	for _, tC := range testCases {
+		var got datediff.Diff
+		if err := got.UnmarshalText([]byte(tC.text)); err != nil {
+			t.Errorf("UnmarshalText(%s) failed: %v", tC.text, err)
+		} else if got.StringWithZeros() != tC.expected {
+			t.Errorf("UnmarshalText(%s) StringWithZeros() = %s, want %s", tC.text, got.StringWithZeros(), tC.expected)
+		}
+	}
+}
+
+func TestUnmarshalTextFails(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{text: "", expected: `invalid ISO 8601 duration ""`},
+		{text: "P", expected: `invalid ISO 8601 duration "P"`},
+		{text: "2Y", expected: `invalid ISO 8601 duration "2Y"`},
+		{text: "PY", expected: `invalid ISO 8601 duration "PY"`},
+		{text: "P2", expected: `invalid ISO 8601 duration "P2"`},
+		{text: "P-Y", expected: `invalid ISO 8601 duration "P-Y"`},
+		{text: "P2D3M", expected: `invalid ISO 8601 duration "P2D3M": unexpected designator M`},
+		{text: "P2Y2Y", expected: `invalid ISO 8601 duration "P2Y2Y": unexpected designator Y`},
+		{text: "P2H", expected: `invalid ISO 8601 duration "P2H": unexpected designator H`},
+		{text: "P99999999999999999999Y", expected: `invalid ISO 8601 duration "P99999999999999999999Y": value out of range`},
+	}
+	for _, tC := range testCases {
+		var got datediff.Diff
+		err := got.UnmarshalText([]byte(tC.text))
+		if err == nil {
+			t.Errorf("UnmarshalText(%s) = %#v, want to fail due to %s", tC.text, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("UnmarshalText(%s) failed: %v, want to fail due to %s", tC.text, err, tC.expected)
+		}
+	}
+}
+
+func mustNewDiff(t *testing.T, start, end time.Time, format string) datediff.Diff {
+	t.Helper()
+	diff, err := datediff.NewDiff(start, end, format)
+	if err != nil {
+		t.Fatalf("NewDiff(%s, %s, %s) failed: %v", start.Format(dateFmt), end.Format(dateFmt), format, err)
+	}
+	return diff
+}
+
+func TestBinary(t *testing.T) {
+	testCases, err := loadDatediffRecordsForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tC := range testCases {
+		desc := fmt.Sprintf("NewDiff(%s, %s, %s)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.format)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiff(tC.start, tC.end, tC.format)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testBinaryRoundTrip(t, diff)
+		})
+
+		desc = fmt.Sprintf("NewDiffWithMode(%s, %s, %d)", tC.start.Format(dateFmt), tC.end.Format(dateFmt), tC.mode)
+		t.Run(desc, func(t *testing.T) {
+			diff, err := datediff.NewDiffWithMode(tC.start, tC.end, tC.mode)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			testBinaryRoundTrip(t, diff)
+		})
+	}
+
+	testBinaryRoundTrip(t, datediff.Diff{Years: -2, Months: 1 << 40})
+}
+
+func testBinaryRoundTrip(t *testing.T, diff datediff.Diff) {
+	t.Helper()
+	data, err := diff.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() failed: %v", err)
+	}
+	var got datediff.Diff
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(%v) failed: %v", data, err)
+	}
+	if !got.Equal(diff) {
+		t.Errorf("UnmarshalBinary(%v) = %#v, want %#v", data, got, diff)
+	}
+	if got.String() != diff.String() {
+		t.Errorf("UnmarshalBinary(%v) String() = %s, want %s", data, got.String(), diff.String())
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	diff := mustNewDiff(t, start, end, "%Y %D")
+	expected := []byte{
+		2, 144, 6, 0, 0, 62, 5, '%', 'Y', ' ', '%', 'D',
+		3,                           // start and end dates
+		128, 208, 210, 143, 7, 0, 0, // 2000-04-17T00:00:00Z
+		128, 180, 182, 236, 7, 0, 0, // 2003-05-18T00:00:00Z
+	}
+	got, err := diff.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() failed: %v", err)
+	}
+	if string(got) != string(expected) {
+		t.Errorf("MarshalBinary() = %v, want %v", got, expected)
+	}
+}
+
+func TestUnmarshalBinaryVersion1(t *testing.T) {
+	data := []byte{1, 144, 6, 0, 0, 62, 5, '%', 'Y', ' ', '%', 'D'}
+	var got datediff.Diff
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(%v) failed: %v", data, err)
+	}
+	if want := "3 years 31 days"; got.String() != want || !got.Start().IsZero() {
+		t.Errorf("UnmarshalBinary(%v) = %#v, want %s without dates", data, got, want)
+	}
+}
+
+func TestUnmarshalBinaryFails(t *testing.T) {
+	testCases := []struct {
+		data     []byte
+		expected string
+	}{
+		{data: nil, expected: "invalid binary encoding length"},
+		{data: []byte{3, 128, 2, 0, 0, 0, 0}, expected: "unsupported binary encoding version 3"},
+		{data: []byte{2, 128, 2, 0, 0, 0, 0}, expected: "invalid binary encoding length"},
+		{data: []byte{2, 128, 2, 0, 0, 0, 0, 8}, expected: "invalid binary encoding flags 0x08"},
+		{data: []byte{2, 128, 2, 0, 0, 0, 0, 1, 2}, expected: "invalid binary encoding length"},
+		{data: []byte{2, 128, 2, 0, 0, 0, 0, 3, 2, 0, 0, 0, 0, 0}, expected: "start date is after end date"},
+		{data: []byte{2, 128, 2, 0, 0, 0, 0, 0, 0}, expected: "invalid binary encoding length"},
+		{data: []byte{1, 128, 2, 0, 0}, expected: "invalid binary encoding length"},
+		{data: []byte{1, 128, 2, 0, 0, 0, 2, '%'}, expected: "invalid binary encoding length"},
+		{data: []byte{1, 128, 2, 0, 0, 0, 1, '%', 'Y'}, expected: "invalid binary encoding length"},
+		{data: []byte{1, 128, 2, 0, 0, 0, 2, '%', 'X'}, expected: `format "%X" has unknown verb X`},
+		{data: []byte{1, 64, 2, 0, 0, 0, 2, '%', 'Y'}, expected: `mode 64 does not match format "%Y"`},
+		{data: []byte{1, 3, 2, 0, 0, 0, 0}, expected: "invalid dates difference mode 3"},
+	}
+	for _, tC := range testCases {
+		var got datediff.Diff
+		err := got.UnmarshalBinary(tC.data)
+		if err == nil {
+			t.Errorf("UnmarshalBinary(%v) = %#v, want to fail due to %s", tC.data, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("UnmarshalBinary(%v) failed: %v, want to fail due to %s", tC.data, err, tC.expected)
+		}
+	}
+}
+
+type report struct {
+	Name   string
+	Uptime datediff.Diff
+}
+
+func TestGob(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	withMode, err := datediff.NewDiffWithMode(start, end, datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	for _, diff := range []datediff.Diff{mustNewDiff(t, start, end, "%Y and %W"), withMode} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(report{Name: "api", Uptime: diff}); err != nil {
+			t.Fatalf("Encode() failed: %v", err)
+		}
+		var got report
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatalf("Decode() failed: %v", err)
+		}
+		if !got.Uptime.Equal(diff) {
+			t.Errorf("Decode() = %#v, want %#v", got.Uptime, diff)
+		}
+		if got.Uptime.String() != diff.String() {
+			t.Errorf("Decode() String() = %s, want %s", got.Uptime.String(), diff.String())
+		}
+	}
+}
+
+// TestEncodingsKeepDates verifies that encodings keep start and end dates of
+// dates difference, so the decoded one has the exact duration.
+func TestEncodingsKeepDates(t *testing.T) {
+	zone := time.FixedZone("AEST", 10*60*60)
+	start := time.Date(2021, time.February, 1, 0, 0, 0, 0, zone)
+	end := time.Date(2021, time.March, 1, 0, 0, 0, 0, zone)
+	month := mustNewDiff(t, start, end, "%M")
+	inclusive, err := datediff.NewDiff(start, end.AddDate(0, 0, -1), "%M %D", datediff.WithInclusiveEnd())
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	// 4 weeks is as long as February 2021, but it's shorter by time units
+	weeks := mustNewDiff(t, start, end, "%W")
+
+	encodings := []struct {
+		name   string
+		encode func(datediff.Diff) ([]byte, error)
+		decode func([]byte) (datediff.Diff, error)
+	}{
+		{
+			name:   "binary",
+			encode: datediff.Diff.MarshalBinary,
+			decode: func(data []byte) (d datediff.Diff, err error) { return d, d.UnmarshalBinary(data) },
+		},
+		{
+			name: "gob",
+			encode: func(d datediff.Diff) ([]byte, error) {
+				var buf bytes.Buffer
+				err := gob.NewEncoder(&buf).Encode(report{Name: "api", Uptime: d})
+				return buf.Bytes(), err
+			},
+			decode: func(data []byte) (datediff.Diff, error) {
+				var r report
+				err := gob.NewDecoder(bytes.NewReader(data)).Decode(&r)
+				return r.Uptime, err
+			},
+		},
+		{
+			name:   "text",
+			encode: datediff.Diff.MarshalText,
+			decode: func(data []byte) (d datediff.Diff, err error) { return d, d.UnmarshalText(data) },
+		},
+		{
+			name:   "JSON",
+			encode: datediff.Diff.MarshalJSON,
+			decode: func(data []byte) (d datediff.Diff, err error) { return d, d.UnmarshalJSON(data) },
+		},
+		{
+			name: "BSON",
+			encode: func(d datediff.Diff) ([]byte, error) {
+				_, data, err := d.MarshalBSONValue()
+				return data, err
+			},
+			decode: func(data []byte) (d datediff.Diff, err error) { return d, d.UnmarshalBSONValue(0x03, data) },
+		},
+		{
+			name:   "compact",
+			encode: func(d datediff.Diff) ([]byte, error) { return d.EncodeCompact(), nil },
+			decode: datediff.DecodeCompact,
+		},
+	}
+	for _, e := range encodings {
+		for _, diff := range []datediff.Diff{month, inclusive} {
+			data, err := e.encode(diff)
+			if err != nil {
+				t.Fatalf("%s encoding of %s failed: %v", e.name, diff, err)
+			}
+			got, err := e.decode(data)
+			if err != nil {
+				t.Fatalf("%s decoding of %v failed: %v", e.name, data, err)
+			}
+			testDecodedDates(t, e.name, got, diff, weeks)
+		}
+	}
+}
+
+// testDecodedDates verifies that the decoded dates difference has dates of
+// the original one. Other is compared with both of them.
+func testDecodedDates(t *testing.T, name string, got, diff, other datediff.Diff) {
+	t.Helper()
+	if !got.Equal(diff) || got.String() != diff.String() {
+		t.Errorf("%s decoded %#v, want %#v", name, got, diff)
+	}
+	if !got.Start().Equal(diff.Start()) || !got.End().Equal(diff.End()) || got.InclusiveEnd() != diff.InclusiveEnd() {
+		t.Errorf("%s decoded dates %v - %v (inclusive %t), want %v - %v (inclusive %t)", name,
+			got.Start(), got.End(), got.InclusiveEnd(), diff.Start(), diff.End(), diff.InclusiveEnd())
+	}
+	if _, offset := got.End().Zone(); offset != 10*60*60 {
+		t.Errorf("%s decoded end date %v, want zone offset +10:00", name, got.End())
+	}
+	if a, b := got.Compare(other), diff.Compare(other); a != b {
+		t.Errorf("%s decoded %s Compare(%s) = %d, want %d", name, got, other, a, b)
+	}
+	gotDuration, err := got.Duration()
+	if err != nil {
+		t.Fatalf("%s decoded Duration() failed: %v", name, err)
+	}
+	if want, _ := diff.Duration(); gotDuration != want {
+		t.Errorf("%s decoded %s Duration() = %v, want %v", name, got, gotDuration, want)
+	}
+}
+
+type retentionPolicy struct {
+	Name      string        `yaml:"name"`
+	Retention datediff.Diff `yaml:"retention"`
+}
+
+func TestYAML(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2001, time.October, 20, 0, 0, 0, 0, time.UTC)
+
+	policy := retentionPolicy{Name: "logs", Retention: mustNewDiff(t, start, end, "%Y %M")}
+	data, err := yaml.Marshal(policy)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() failed: %v", err)
+	}
+	expected := "name: logs\nretention: v1;mode=YM;y=1;m=6;start=2000-04-17T00:00:00Z;end=2001-10-20T00:00:00Z;fmt=%Y %M\n"
+	if string(data) != expected {
+		t.Errorf("yaml.Marshal() = %q, want %q", data, expected)
+	}
+
+	var got retentionPolicy
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("yaml.Unmarshal(%s) failed: %v", data, err)
+	}
+	if !got.Retention.Equal(policy.Retention) {
+		t.Errorf("yaml.Unmarshal(%s) = %#v, want %#v", data, got.Retention, policy.Retention)
+	}
+	if got.Retention.String() != policy.Retention.String() {
+		t.Errorf("yaml.Unmarshal(%s) String() = %s, want %s", data, got.Retention.String(), policy.Retention.String())
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected string
+	}{
+		{data: "retention: P2Y3M", expected: "2 years 3 months"},
+		{data: "retention: {years: 2, days: 4, format: '%Y and %D'}", expected: "2 years and 4 days"},
+		{data: "retention:\n  weeks: 3\n  mode: 48\n", expected: "3 weeks"},
+	}
+	for _, tC := range testCases {
+		var got retentionPolicy
+		if err := yaml.Unmarshal([]byte(tC.data), &got); err != nil {
+			t.Errorf("yaml.Unmarshal(%s) failed: %v", tC.data, err)
+		} else if got.Retention.String() != tC.expected {
+			t.Errorf("yaml.Unmarshal(%s) String() = %s, want %s", tC.data, got.Retention.String(), tC.expected)
+		}
+	}
+}
+
+func TestUnmarshalYAMLFails(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected string
+	}{
+		{data: "retention: 2 years", expected: `invalid ISO 8601 duration "2 years"`},
+		{data: "retention: {years: 2, format: '%X'}", expected: `format "%X" has unknown verb X`},
+		{data: "retention: [P2Y]", expected: "yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into datediff.diffObject"},
+	}
+	for _, tC := range testCases {
+		var got retentionPolicy
+		err := yaml.Unmarshal([]byte(tC.data), &got)
+		if err == nil {
+			t.Errorf("yaml.Unmarshal(%s) = %#v, want to fail due to %s", tC.data, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("yaml.Unmarshal(%s) failed: %v, want to fail due to %s", tC.data, err, tC.expected)
+		}
+	}
+}
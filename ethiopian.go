@@ -0,0 +1,83 @@
+package datediff
+
+import "time"
+
+const (
+	// ethiopianEpoch is the Julian day number of Meskerem 1 of the first
+	// year of the Ethiopian calendar, i.e August 29, 8 of the Julian
+	// calendar.
+	ethiopianEpoch = 1724221
+	// ethiopianMonths is the number of months in the Ethiopian year,
+	// including Pagume.
+	ethiopianMonths = 13
+)
+
+// EthiopianDate is the date of the Ethiopian calendar, i.e 2016-01-01 is
+// Enkutatash of September 12, 2023.
+type EthiopianDate struct {
+	Year  int
+	Month int // month of the year, 1 is Meskerem and 13 is Pagume
+	Day   int
+}
+
+// EthiopianDateOf returns the Ethiopian calendar date of the date in its
+// location.
+func EthiopianDateOf(t time.Time) EthiopianDate {
+	year, month, day := EthiopianCalendar{}.date(jdnOf(t))
+	return EthiopianDate{Year: year, Month: month, Day: day}
+}
+
+// EthiopianCalendar is the Ethiopian calendar, the official calendar of
+// Ethiopia. It has 12 months of 30 days and Pagume, the 13th month of 5
+// days or 6 days in leap years. Every fourth year is the leap year, the
+// year before it's divisible by 4. Years are counted from the Incarnation
+// era, 7 or 8 years behind Gregorian years. The day overflow is normalized,
+// i.e Nehasse 30 plus 1 month is Pagume 5 plus 25 days, that's Meskerem 25
+// of the next year.
+type EthiopianCalendar struct{}
+
+// AddYears implements Calendar interface.
+func (c EthiopianCalendar) AddYears(t time.Time, n int) time.Time {
+	return addMonthsCalendar(c, t, n, 0)
+}
+
+// AddMonths implements Calendar interface.
+func (c EthiopianCalendar) AddMonths(t time.Time, n int) time.Time {
+	return addMonthsCalendar(c, t, 0, n)
+}
+
+// AddDays implements Calendar interface.
+func (EthiopianCalendar) AddDays(t time.Time, n int) time.Time {
+	return t.AddDate(0, 0, n)
+}
+
+// Compare implements Calendar interface.
+func (EthiopianCalendar) Compare(a, b time.Time) int {
+	return GregorianCalendar{}.Compare(a, b)
+}
+
+func (EthiopianCalendar) monthsInYear() int {
+	return ethiopianMonths
+}
+
+func (EthiopianCalendar) jdn(year, month, day int) int64 {
+	y := int64(year)
+	leaps := y / 4
+	if y < 0 && y%4 != 0 {
+		leaps--
+	}
+	return ethiopianEpoch + 365*(y-1) + leaps + int64(30*(month-1)+day-1)
+}
+
+func (EthiopianCalendar) date(jdn int64) (year, month, day int) {
+	// days since the start of the year 0, the leap day ends 4 years cycle
+	n := jdn - ethiopianEpoch + 365
+	cycle := n / 1461
+	if n < 0 && n%1461 != 0 {
+		cycle--
+	}
+	r := int(n - cycle*1461)
+	y := r/365 - r/1460
+	doy := r - y*365
+	return int(cycle)*4 + y, doy/30 + 1, doy%30 + 1
+}
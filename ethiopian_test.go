@@ -0,0 +1,63 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestEthiopianDateOf(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		expected datediff.EthiopianDate
+	}{
+		{date: date(2023, time.September, 11), expected: datediff.EthiopianDate{Year: 2015, Month: 13, Day: 6}},
+		{date: date(2023, time.September, 12), expected: datediff.EthiopianDate{Year: 2016, Month: 1, Day: 1}},
+		{date: date(2024, time.January, 7), expected: datediff.EthiopianDate{Year: 2016, Month: 4, Day: 28}},
+		{date: date(2024, time.September, 10), expected: datediff.EthiopianDate{Year: 2016, Month: 13, Day: 5}},
+		{date: date(2024, time.September, 11), expected: datediff.EthiopianDate{Year: 2017, Month: 1, Day: 1}},
+		{date: date(2025, time.January, 7), expected: datediff.EthiopianDate{Year: 2017, Month: 4, Day: 29}},
+		{date: date(1974, time.September, 11), expected: datediff.EthiopianDate{Year: 1967, Month: 1, Day: 1}},
+		{date: date(8, time.August, 27), expected: datediff.EthiopianDate{Year: 1, Month: 1, Day: 1}},
+		{date: date(8, time.August, 26), expected: datediff.EthiopianDate{Year: 0, Month: 13, Day: 5}},
+	}
+	for _, tC := range testCases {
+		if got := datediff.EthiopianDateOf(tC.date); got != tC.expected {
+			t.Errorf("EthiopianDateOf(%s) = %+v, want %+v", tC.date.Format("2006-01-02"), got, tC.expected)
+		}
+	}
+}
+
+func TestEthiopianCalendar(t *testing.T) {
+	c := datediff.EthiopianCalendar{}
+	testCases := []struct {
+		desc     string
+		got      time.Time
+		expected time.Time
+	}{
+		{desc: "add year", got: c.AddYears(date(2023, time.September, 12), 1), expected: date(2024, time.September, 11)},
+		{desc: "add year to leap day", got: c.AddYears(date(2023, time.September, 11), 1), expected: date(2024, time.September, 11)},
+		{desc: "add month", got: c.AddMonths(date(2023, time.September, 12), 1), expected: date(2023, time.October, 12)},
+		{desc: "add month to Pagume", got: c.AddMonths(date(2024, time.August, 15), 1), expected: date(2024, time.September, 14)},
+		{desc: "add months over year end", got: c.AddMonths(date(2024, time.September, 6), 2), expected: date(2024, time.October, 11)},
+		{desc: "subtract months", got: c.AddMonths(date(2024, time.October, 11), -2), expected: date(2024, time.September, 6)},
+	}
+	for _, tC := range testCases {
+		if !tC.got.Equal(tC.expected) {
+			t.Errorf("%s = %s, want %s", tC.desc, tC.got.Format("2006-01-02"), tC.expected.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestEthiopianCalendarDiff(t *testing.T) {
+	// 13 months are 1 year
+	start, end := date(2023, time.September, 12), date(2024, time.October, 11)
+	got, err := datediff.NewDiff(start, end, "%Y %M %D", datediff.WithCalendar(datediff.EthiopianCalendar{}))
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	if want := (datediff.Diff{Years: 1, Months: 1}); !got.Equal(want) {
+		t.Errorf("NewDiff() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package datediff_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/antklim/datediff"
@@ -94,3 +95,53 @@ func ExampleDiff_StringWithZeros() {
 	// Output:
 	// 10 years 0 months 29 days
 }
+
+func ExampleFormatter() {
+	d1, _ := time.Parse("2006-01-02", "2000-10-01")
+	d2, _ := time.Parse("2006-01-02", "2010-11-30")
+
+	diff, _ := datediff.NewDiff(d1, d2, "%Y %M %D")
+	de, _ := datediff.LookupLocale("de")
+	fmt.Println(datediff.Formatter{Locale: de}.String(diff))
+
+	// Output:
+	// 10 Jahre 1 Monat 29 Tage
+}
+
+func ExampleLoadLocale() {
+	def := `
+name: pt
+units:
+  year: {one: "{0} ano", other: "{0} anos"}
+  month: {one: "{0} mês", other: "{0} meses"}
+  week: {one: "{0} semana", other: "{0} semanas"}
+  day: {one: "{0} dia", other: "{0} dias"}
+`
+	pt, _ := datediff.LoadLocale(strings.NewReader(def))
+
+	d1, _ := time.Parse("2006-01-02", "2000-10-01")
+	d2, _ := time.Parse("2006-01-02", "2010-11-02")
+
+	diff, _ := datediff.NewDiff(d1, d2, "%Y %M %D")
+	fmt.Println(datediff.Formatter{Locale: pt}.String(diff))
+
+	// Output:
+	// 10 anos 1 mês 1 dia
+}
+
+func ExampleFormatter_Ago() {
+	d1, _ := time.Parse("2006-01-02", "2000-10-01")
+	d2, _ := time.Parse("2006-01-02", "2002-10-01")
+
+	diff, _ := datediff.NewDiff(d1, d2, "%Y")
+	for _, name := range []string{"en", "es", "de", "ja"} {
+		l, _ := datediff.LookupLocale(name)
+		fmt.Println(datediff.Formatter{Locale: l}.Ago(diff))
+	}
+
+	// Output:
+	// 2 years ago
+	// hace 2 años
+	// vor 2 Jahren
+	// 2年前
+}
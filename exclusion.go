@@ -0,0 +1,74 @@
+package datediff
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var errExclusionsMode = errors.New("exclusions require weeks or days dates difference mode")
+
+// WithExclusions excludes days of the periods from dates difference, i.e
+// maintenance windows or embargo periods. Periods can overlap, and only full
+// days of their overlap with the dates range are excluded. Weeks and days are
+// counted from full days that are not excluded, WithRounding is ignored.
+// Excluded days are reported by Diff.ExcludedDays. Dates differences in years
+// or months fail with exclusions.
+func WithExclusions(periods ...Period) Option {
+	return func(o *options) {
+		o.exclusions = append(o.exclusions, periods...)
+	}
+}
+
+// ExcludedDays returns the number of days excluded from dates difference by
+// WithExclusions.
+func (d Diff) ExcludedDays() int {
+	return d.excluded
+}
+
+// exclude counts weeks and days of dates difference from full days between
+// dates that are not excluded.
+func (o options) exclude(d Diff, start, end time.Time) Diff {
+	excluded := o.excludedDays(start, end)
+	days := fullDaysDiff(o.calendar, start, end) - excluded
+	d.Weeks, d.Days = 0, days
+	if d.mode&ModeWeeks != 0 {
+		d.Weeks, d.Days = days/daysInWeek, days%daysInWeek
+		if d.mode&ModeDays == 0 {
+			d.Days = 0
+		}
+	}
+	d.excluded = excluded
+	return d
+}
+
+// excludedDays returns the number of full days of exclusions between dates.
+// Overlapping exclusions are merged, so days are not excluded twice.
+func (o options) excludedDays(start, end time.Time) int {
+	periods := make([]Period, 0, len(o.exclusions))
+	for _, p := range o.exclusions {
+		from, to := p.Start.In(start.Location()), p.End.In(start.Location())
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if from.Before(to) {
+			periods = append(periods, Period{Start: from, End: to})
+		}
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+
+	var days int
+	for i := 0; i < len(periods); {
+		from, to := periods[i].Start, periods[i].End
+		for i++; i < len(periods) && !periods[i].Start.After(to); i++ {
+			if periods[i].End.After(to) {
+				to = periods[i].End
+			}
+		}
+		days += fullDaysDiff(o.calendar, from, to)
+	}
+	return days
+}
@@ -0,0 +1,92 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestWithExclusions(t *testing.T) {
+	start, end := date(2024, time.January, 1), date(2024, time.January, 31)
+	maintenance := datediff.Period{Start: date(2024, time.January, 10), End: date(2024, time.January, 15)}
+
+	testCases := []struct {
+		desc       string
+		end        time.Time
+		mode       datediff.DiffMode
+		exclusions []datediff.Period
+		expected   datediff.Diff
+		excluded   int
+	}{
+		{
+			desc:       "days",
+			end:        end,
+			mode:       datediff.ModeDays,
+			exclusions: []datediff.Period{maintenance},
+			expected:   datediff.Diff{Days: 25},
+			excluded:   5,
+		},
+		{
+			desc:       "weeks and days",
+			end:        end,
+			mode:       datediff.ModeWeeks | datediff.ModeDays,
+			exclusions: []datediff.Period{maintenance},
+			expected:   datediff.Diff{Weeks: 3, Days: 4},
+			excluded:   5,
+		},
+		{
+			desc: "overlapping exclusions",
+			end:  end,
+			mode: datediff.ModeDays,
+			exclusions: []datediff.Period{
+				{Start: date(2024, time.January, 12), End: date(2024, time.January, 20)},
+				maintenance,
+			},
+			expected: datediff.Diff{Days: 20},
+			excluded: 10,
+		},
+		{
+			desc:       "exclusion before start date",
+			end:        end,
+			mode:       datediff.ModeDays,
+			exclusions: []datediff.Period{{Start: date(2023, time.December, 25), End: date(2024, time.January, 3)}},
+			expected:   datediff.Diff{Days: 28},
+			excluded:   2,
+		},
+		{
+			desc:       "weeks",
+			end:        date(2024, time.January, 11),
+			mode:       datediff.ModeWeeks,
+			exclusions: []datediff.Period{{Start: date(2024, time.January, 2), End: date(2024, time.January, 4)}},
+			expected:   datediff.Diff{Weeks: 1},
+			excluded:   2,
+		},
+		{
+			desc:       "partial days",
+			end:        end,
+			mode:       datediff.ModeDays,
+			exclusions: []datediff.Period{{Start: date(2024, time.January, 10).Add(12 * time.Hour), End: date(2024, time.January, 12)}},
+			expected:   datediff.Diff{Days: 29},
+			excluded:   1,
+		},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.NewDiffWithMode(start, tC.end, tC.mode, datediff.WithExclusions(tC.exclusions...))
+		if err != nil {
+			t.Errorf("NewDiffWithMode() %s failed: %v", tC.desc, err)
+			continue
+		}
+		if !got.Equal(tC.expected) {
+			t.Errorf("NewDiffWithMode() %s = %v, want %v", tC.desc, got, tC.expected)
+		}
+		if got.ExcludedDays() != tC.excluded {
+			t.Errorf("ExcludedDays() %s = %d, want %d", tC.desc, got.ExcludedDays(), tC.excluded)
+		}
+	}
+
+	_, err := datediff.NewDiff(start, end, "%M %D", datediff.WithExclusions(maintenance))
+	if want := "exclusions require weeks or days dates difference mode"; err == nil || err.Error() != want {
+		t.Errorf("NewDiff() error = %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,172 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	quartersInYear    = 4
+	periodsInQuarter  = 3
+	maxFiscalStartDay = 28
+)
+
+// WeekPattern defines lengths in weeks of fiscal periods of the quarter.
+type WeekPattern uint8
+
+// These are supported week patterns.
+const (
+	// PatternMonths uses calendar months as fiscal periods.
+	PatternMonths WeekPattern = iota
+	// Pattern445 has two 4 weeks periods followed by 5 weeks period.
+	Pattern445
+	// Pattern454 has 4 weeks, 5 weeks and 4 weeks periods, i.e retail
+	// calendar of the National Retail Federation.
+	Pattern454
+	// Pattern544 has 5 weeks period followed by two 4 weeks periods.
+	Pattern544
+)
+
+var weekPatterns = map[WeekPattern][periodsInQuarter]int{
+	Pattern445: {4, 4, 5},
+	Pattern454: {4, 5, 4},
+	Pattern544: {5, 4, 4},
+}
+
+// FiscalCalendar defines fiscal years, quarters and periods. Fiscal years of
+// months based calendar start on the same date every year. Fiscal years of
+// weeks based calendar have 52 or 53 weeks and start on the day of the week
+// nearest to the start date, the extra week is added to the last period.
+// Fiscal year is named by the calendar year it ends in, i.e fiscal year of
+// the US government that starts on October 1, 2023 is 2024.
+//
+// The zero value of FiscalCalendar is the months based calendar with fiscal
+// years that match calendar years.
+type FiscalCalendar struct {
+	startMonth time.Month
+	startDay   int
+	weekday    time.Weekday
+	pattern    WeekPattern
+}
+
+// NewFiscalCalendar creates months based fiscal calendar with fiscal years
+// that start on the month and the day, i.e October 1.
+//
+// NewFiscalCalendar returns error when the start day does not exist in the
+// month, or it's after the 28th day.
+func NewFiscalCalendar(startMonth time.Month, startDay int) (FiscalCalendar, error) {
+	if startMonth < time.January || startMonth > time.December || startDay < 1 || startDay > maxFiscalStartDay {
+		return FiscalCalendar{}, fmt.Errorf("invalid fiscal year start %s %d", startMonth, startDay)
+	}
+	return FiscalCalendar{startMonth: startMonth, startDay: startDay}, nil
+}
+
+// NewWeekFiscalCalendar creates weeks based fiscal calendar with fiscal years
+// that start on the day of the week nearest to the month and the day, i.e
+// the Sunday nearest to February 1 for Pattern454 retail calendar.
+//
+// NewWeekFiscalCalendar returns error in the following cases:
+//
+//	start day does not exist in the month, or it's after the 28th day
+//	unknown week pattern
+func NewWeekFiscalCalendar(startMonth time.Month, startDay int, weekday time.Weekday, pattern WeekPattern) (FiscalCalendar, error) {
+	c, err := NewFiscalCalendar(startMonth, startDay)
+	if err != nil {
+		return FiscalCalendar{}, err
+	}
+	if _, ok := weekPatterns[pattern]; !ok {
+		return FiscalCalendar{}, fmt.Errorf("unknown week pattern %d", pattern)
+	}
+	c.weekday = weekday % daysInWeek
+	c.pattern = pattern
+	return c, nil
+}
+
+// FiscalPeriod is the fiscal period of the date.
+type FiscalPeriod struct {
+	Year    int
+	Quarter int // quarter of the fiscal year, from 1 to 4
+	Period  int // period of the fiscal year, from 1 to 12
+}
+
+// String returns the fiscal period in "FY2024 Q1 P2" format.
+func (p FiscalPeriod) String() string {
+	return fmt.Sprintf("FY%d Q%d P%d", p.Year, p.Quarter, p.Period)
+}
+
+// FiscalDiff describes dates difference in fiscal years, quarters and
+// periods. Time units are counted independently as boundaries of fiscal
+// years, quarters and periods between the dates, i.e there is 1 fiscal year,
+// 1 quarter and 1 period between the last and the first days of fiscal
+// years.
+type FiscalDiff struct {
+	Years    int
+	Quarters int
+	Periods  int
+}
+
+// YearStart returns the start date of the fiscal year in UTC.
+func (c FiscalCalendar) YearStart(year int) time.Time {
+	month, day := c.startMonth, c.startDay
+	if month == 0 {
+		month, day = time.January, 1
+	}
+	if month != time.January || day != 1 {
+		year--
+	}
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if c.pattern == PatternMonths {
+		return start
+	}
+	// the nearest day of the week is at most 3 days away
+	offset := (int(c.weekday) - int(start.Weekday()) + daysInWeek) % daysInWeek
+	if offset > daysInWeek/2 {
+		offset -= daysInWeek
+	}
+	return start.AddDate(0, 0, offset)
+}
+
+// PeriodOf returns the fiscal period of the date in its location.
+func (c FiscalCalendar) PeriodOf(t time.Time) FiscalPeriod {
+	date := DateOf(t).Time()
+	year := date.Year()
+	for date.Before(c.YearStart(year)) {
+		year--
+	}
+	for !date.Before(c.YearStart(year + 1)) {
+		year++
+	}
+	start := c.YearStart(year)
+
+	var period int
+	if c.pattern == PatternMonths {
+		period = (date.Year()-start.Year())*monthsInYear + int(date.Month()-start.Month())
+		if date.Day() < start.Day() {
+			period--
+		}
+	} else {
+		weeks := int(date.Sub(start) / (daysInWeek * Day))
+		lengths := weekPatterns[c.pattern]
+		for period < monthsInYear-1 && weeks >= lengths[period%periodsInQuarter] {
+			weeks -= lengths[period%periodsInQuarter]
+			period++
+		}
+	}
+	return FiscalPeriod{Year: year, Quarter: period/periodsInQuarter + 1, Period: period + 1}
+}
+
+// Diff calculates dates difference in fiscal years, quarters and periods.
+// The end date is converted to the location of the start date.
+//
+// Diff returns error when start date is after end date.
+func (c FiscalCalendar) Diff(start, end time.Time) (FiscalDiff, error) {
+	if start.After(end) {
+		return FiscalDiff{}, ErrStartAfterEnd
+	}
+	s, e := c.PeriodOf(start), c.PeriodOf(end.In(start.Location()))
+	return FiscalDiff{
+		Years:    e.Year - s.Year,
+		Quarters: (e.Year-s.Year)*quartersInYear + e.Quarter - s.Quarter,
+		Periods:  (e.Year-s.Year)*monthsInYear + e.Period - s.Period,
+	}, nil
+}
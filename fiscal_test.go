@@ -0,0 +1,113 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestFiscalCalendarPeriodOf(t *testing.T) {
+	federal, err := datediff.NewFiscalCalendar(time.October, 1)
+	if err != nil {
+		t.Fatalf("NewFiscalCalendar() failed: %v", err)
+	}
+	retail, err := datediff.NewWeekFiscalCalendar(time.February, 1, time.Sunday, datediff.Pattern454)
+	if err != nil {
+		t.Fatalf("NewWeekFiscalCalendar() failed: %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		cal      datediff.FiscalCalendar
+		date     time.Time
+		expected string
+	}{
+		{desc: "calendar years", cal: datediff.FiscalCalendar{}, date: date(2024, time.May, 15), expected: "FY2024 Q2 P5"},
+		{desc: "first day of federal fiscal year", cal: federal, date: date(2023, time.October, 1), expected: "FY2024 Q1 P1"},
+		{desc: "last day of federal fiscal year", cal: federal, date: date(2024, time.September, 30), expected: "FY2024 Q4 P12"},
+		{desc: "federal fiscal year", cal: federal, date: date(2024, time.February, 10), expected: "FY2024 Q2 P5"},
+		{desc: "first day of retail fiscal year", cal: retail, date: date(2023, time.January, 29), expected: "FY2024 Q1 P1"},
+		{desc: "day before retail fiscal year", cal: retail, date: date(2023, time.January, 28), expected: "FY2023 Q4 P12"},
+		{desc: "second period of 4-5-4 quarter", cal: retail, date: date(2023, time.February, 26), expected: "FY2024 Q1 P2"},
+		{desc: "last day of 5 weeks period", cal: retail, date: date(2023, time.April, 1), expected: "FY2024 Q1 P2"},
+		{desc: "third period of 4-5-4 quarter", cal: retail, date: date(2023, time.April, 2), expected: "FY2024 Q1 P3"},
+		{desc: "53rd week", cal: retail, date: date(2024, time.February, 3), expected: "FY2024 Q4 P12"},
+		{desc: "after 53 weeks year", cal: retail, date: date(2024, time.February, 4), expected: "FY2025 Q1 P1"},
+	}
+	for _, tC := range testCases {
+		if got := tC.cal.PeriodOf(tC.date).String(); got != tC.expected {
+			t.Errorf("PeriodOf(%s) %s = %s, want %s", tC.date.Format("2006-01-02"), tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestFiscalCalendarDiff(t *testing.T) {
+	federal, err := datediff.NewFiscalCalendar(time.October, 1)
+	if err != nil {
+		t.Fatalf("NewFiscalCalendar() failed: %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		expected datediff.FiscalDiff
+	}{
+		{desc: "same period", start: date(2023, time.October, 1), end: date(2023, time.October, 31), expected: datediff.FiscalDiff{}},
+		{desc: "fiscal years boundary", start: date(2023, time.September, 30), end: date(2023, time.October, 1), expected: datediff.FiscalDiff{Years: 1, Quarters: 1, Periods: 1}},
+		{desc: "quarters", start: date(2023, time.November, 15), end: date(2024, time.May, 1), expected: datediff.FiscalDiff{Quarters: 2, Periods: 6}},
+		{desc: "years", start: date(2020, time.March, 1), end: date(2024, time.November, 1), expected: datediff.FiscalDiff{Years: 5, Quarters: 19, Periods: 56}},
+	}
+	for _, tC := range testCases {
+		got, err := federal.Diff(tC.start, tC.end)
+		if err != nil {
+			t.Errorf("Diff() %s failed: %v", tC.desc, err)
+		} else if got != tC.expected {
+			t.Errorf("Diff() %s = %+v, want %+v", tC.desc, got, tC.expected)
+		}
+	}
+
+	if _, err := federal.Diff(date(2024, time.January, 2), date(2024, time.January, 1)); err != datediff.ErrStartAfterEnd {
+		t.Errorf("Diff() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+}
+
+func TestFiscalCalendarFails(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		create   func() error
+		expected string
+	}{
+		{
+			desc:     "start day after 28th",
+			create:   func() error { _, err := datediff.NewFiscalCalendar(time.March, 31); return err },
+			expected: "invalid fiscal year start March 31",
+		},
+		{
+			desc:     "zero start day",
+			create:   func() error { _, err := datediff.NewFiscalCalendar(time.March, 0); return err },
+			expected: "invalid fiscal year start March 0",
+		},
+		{
+			desc: "unknown week pattern",
+			create: func() error {
+				_, err := datediff.NewWeekFiscalCalendar(time.February, 1, time.Sunday, datediff.PatternMonths)
+				return err
+			},
+			expected: "unknown week pattern 0",
+		},
+	}
+	for _, tC := range testCases {
+		err := tC.create()
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("%s error = %v, want %s", tC.desc, err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package datediff
+
+import "strings"
+
+// DiffFlag is a command-line flag of dates difference, i.e
+// --max-age="1 year 6 months". It implements the flag.Value interface and
+// the pflag.Value interface:
+//
+//	var maxAge datediff.DiffFlag
+//	flag.Var(&maxAge, "max-age", "maximum age of the data")
+type DiffFlag struct {
+	Diff Diff
+}
+
+// String returns the flag value formatted as Diff.String does.
+func (f *DiffFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.Diff.String()
+}
+
+// Set parses the flag value. It accepts ISO 8601 duration, i.e "P1Y6M", the
+// canonical string, or dates difference written in English, i.e
+// "1 year 6 months".
+func (f *DiffFlag) Set(s string) error {
+	var d Diff
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, canonicalVersion+";") {
+		if err := d.UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if d, err = ParseDiff(s); err != nil {
+			return err
+		}
+	}
+	f.Diff = d
+	return nil
+}
+
+// Type returns the flag value type name, it's used by pflag in usage
+// messages.
+func (f *DiffFlag) Type() string {
+	return "diff"
+}
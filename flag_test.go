@@ -0,0 +1,63 @@
+package datediff_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/antklim/datediff"
+)
+
+func TestDiffFlag(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected datediff.Diff
+		print    string
+	}{
+		{
+			args:     []string{"--max-age", "1 year 6 months"},
+			expected: datediff.Diff{Years: 1, Months: 6},
+			print:    "1 year 6 months",
+		},
+		{
+			args:     []string{"--max-age=P2W3D"},
+			expected: datediff.Diff{Weeks: 2, Days: 3},
+			print:    "2 weeks 3 days",
+		},
+		{
+			args: nil,
+		},
+	}
+	for _, tC := range testCases {
+		var maxAge datediff.DiffFlag
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(&maxAge, "max-age", "maximum age")
+		if err := fs.Parse(tC.args); err != nil {
+			t.Errorf("Parse(%q) failed: %v", tC.args, err)
+			continue
+		}
+		if !maxAge.Diff.Equal(tC.expected) {
+			t.Errorf("Parse(%q) = %#v, want %#v", tC.args, maxAge.Diff, tC.expected)
+		}
+		if got := maxAge.String(); got != tC.print {
+			t.Errorf("Parse(%q) String() = %s, want %s", tC.args, got, tC.print)
+		}
+	}
+
+	var maxAge datediff.DiffFlag
+	if got := maxAge.Type(); got != "diff" {
+		t.Errorf("Type() = %s, want diff", got)
+	}
+}
+
+func TestDiffFlagFails(t *testing.T) {
+	for _, arg := range []string{"--max-age=2 hours", "--max-age=P2H"} {
+		var maxAge datediff.DiffFlag
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(&maxAge, "max-age", "maximum age")
+		if err := fs.Parse([]string{arg}); err == nil {
+			t.Errorf("Parse(%s) = %#v, want to fail", arg, maxAge.Diff)
+		}
+	}
+}
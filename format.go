@@ -2,105 +2,323 @@ package datediff
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 )
 
-var formatUnits = map[string]string{
-	"%Y": "year",
-	"%y": "year",
-	"%M": "month",
-	"%m": "month",
-	"%W": "week",
-	"%w": "week",
-	"%D": "day",
-	"%d": "day",
-}
-
-// format formats dates difference according to the provided format.
-// It trims time units with 0 values.
-func format(diff Diff, rawFormat string) string {
-	result := rawFormat
-
-	frmt(diff, rawFormat, func(n int, verb, unit string) {
-		if n == 0 {
-			result = zeroVerbReplace(result, verb)
-		} else {
-			result = verbReplace(result, n, verb, unit)
-		}
-	})
+// formatVerbs are format verbs of time units in the order of significance.
+// Upper case verbs are replaced by the number followed by the time unit name,
+// lower case verbs are replaced by the number only.
+var formatVerbs = []struct {
+	verb byte
+	unit string
+	mode DiffMode
+}{
+	{verb: 'Y', unit: "year", mode: ModeYears},
+	{verb: 'y', unit: "year", mode: ModeYears},
+	{verb: 'M', unit: "month", mode: ModeMonths},
+	{verb: 'm', unit: "month", mode: ModeMonths},
+	{verb: 'W', unit: "week", mode: ModeWeeks},
+	{verb: 'w', unit: "week", mode: ModeWeeks},
+	{verb: 'D', unit: "day", mode: ModeDays},
+	{verb: 'd', unit: "day", mode: ModeDays},
+}
 
-	return result
+// Formatter formats dates difference using the locale.
+type Formatter struct {
+	// Locale defines time units names. English is used when Locale is nil.
+	Locale *Locale
+	// WithZeros keeps time units that have 0 value.
+	WithZeros bool
+	// Compact uses abbreviated time units names of the locale, i.e "2y 3mo".
+	Compact bool
+	// Numbers overrides the numbering system of the locale. Format returns
+	// error when the numbering system is unknown, String and other methods
+	// that do not return errors fall back to Latin digits.
+	Numbers NumberingSystem
+	// Casing converts letter case of the formatted dates difference
+	// according to the locale language rules.
+	Casing Casing
+	// MaxUnits limits the number of the most significant time units with
+	// non 0 values, i.e 2 years 3 months 5 days is formatted as
+	// "2 years 3 months" when MaxUnits is 2. Less significant time units are
+	// omitted as ones that have 0 value. There is no limit when MaxUnits is
+	// not positive. MaxUnits is ignored when WithZeros is set.
+	MaxUnits int
+	// ShowZero formats dates difference that has 0 values of all time units
+	// as 0 of the smallest time unit, i.e "0 days", instead of empty string.
+	// It's ignored when WithZeros is set.
+	ShowZero bool
 }
 
-// format formats dates difference according to the provided format.
-// Since this function is private, it's assumed that format is valid.
-func formatWithZeros(diff Diff, rawFormat string) string {
-	result := rawFormat
-
-	frmt(diff, rawFormat, func(n int, verb, unit string) {
-		result = verbReplace(result, n, verb, unit)
-	})
-
-	return result
-}
-
-func frmt(diff Diff, rawFormat string, replace func(n int, verb, unit string)) {
-	for verb, unit := range formatUnits {
-		if strings.Contains(rawFormat, verb) {
-			var n int
-			switch unit {
-			case "year":
-				n = diff.Years
-			case "month":
-				n = diff.Months
-			case "week":
-				n = diff.Weeks
-			case "day":
-				n = diff.Days
-			}
-			replace(n, verb, unit)
+// Format formats dates difference according to the provided format. The
+// default format of the locale is used when format is empty.
+func (f Formatter) Format(d Diff, rawFormat string) (string, error) {
+	if !f.Numbers.valid() {
+		return "", fmt.Errorf("unknown numbering system %q", f.Numbers)
+	}
+	l := f.locale()
+	if rawFormat == "" {
+		rawFormat = l.Format
+	}
+	mode, err := unmarshal(rawFormat)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := f.zero(d, mode, l); ok {
+		return f.Casing.apply(s, l), nil
+	}
+	d = f.truncate(d)
+	buf := getBuffer()
+	*buf = appendFormat((*buf)[:0], d, rawFormat, f.WithZeros, l)
+	s := string(*buf)
+	putBuffer(buf)
+	return f.Casing.apply(s, l), nil
+}
+
+// String formats dates difference according to the format provided at
+// initialization of dates difference.
+func (f Formatter) String(d Diff) string {
+	return f.Casing.apply(f.string(d), f.baseLocale())
+}
+
+// AppendString appends dates difference formatted as String does to b and
+// returns the extended buffer. It does not allocate when b has enough
+// capacity and no Casing is set, that allows to format many dates
+// differences reusing the buffer.
+func (f Formatter) AppendString(b []byte, d Diff) []byte {
+	if f.Casing != CasingNone {
+		return append(b, f.String(d)...)
+	}
+	return f.appendString(b, d)
+}
+
+func (f Formatter) string(d Diff) string {
+	// the pooled buffer leaves the only allocation of the result string
+	buf := getBuffer()
+	*buf = f.appendString((*buf)[:0], d)
+	s := string(*buf)
+	putBuffer(buf)
+	return s
+}
+
+// maxPooledBuffer is the capacity of the largest buffer returned to the pool,
+// larger buffers are left to the garbage collector to not hold memory after
+// formatting of unusually long dates differences.
+const maxPooledBuffer = 1 << 10
+
+// bufferPool keeps buffers used to format dates differences, so that
+// sustained formatting does not allocate them.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 64)
+		return &b
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(b)
+}
+
+func (f Formatter) appendString(b []byte, d Diff) []byte {
+	l := f.locale()
+	mode := d.mode
+	if d.rawFormat != "" {
+		mode, _ = unmarshal(d.rawFormat)
+	}
+	if s, ok := f.zero(d, mode, l); ok {
+		return append(b, s...)
+	}
+	d = f.truncate(d)
+	if d.rawFormat == "" {
+		return appendMode(b, d, d.mode, f.WithZeros, l)
+	}
+	return appendFormat(b, d, d.rawFormat, f.WithZeros, l)
+}
+
+// Ago formats dates difference as the past relative phrase of the locale, i.e
+// "2 years ago". Dates difference is formatted as String does. When the locale
+// does not define the past phrase, the formatted dates difference is returned.
+func (f Formatter) Ago(d Diff) string {
+	l := f.baseLocale()
+	return f.relative(d, l.Relative.Past)
+}
+
+// In formats dates difference as the future relative phrase of the locale,
+// i.e "in 2 years". Dates difference is formatted as String does. When the
+// locale does not define the future phrase, the formatted dates difference is
+// returned.
+func (f Formatter) In(d Diff) string {
+	l := f.baseLocale()
+	return f.relative(d, l.Relative.Future)
+}
+
+func (f Formatter) relative(d Diff, pattern string) string {
+	rf := f
+	rf.Locale = f.baseLocale().relative()
+	s := rf.string(d)
+	if s != "" && pattern != "" {
+		s = strings.Replace(pattern, numberPlaceholder, s, 1)
+	}
+	return f.Casing.apply(s, rf.Locale)
+}
+
+// zero formats dates difference that has 0 values of all time units of the
+// mode. It returns false when dates difference has non 0 values or zeros are
+// formatted as any other value. All time units are checked when the mode is
+// not defined.
+func (f Formatter) zero(d Diff, mode DiffMode, l *Locale) (string, bool) {
+	if f.WithZeros {
+		return "", false
+	}
+	if mode == 0 {
+		mode = ModeAll
+	}
+	var smallest DiffMode
+	for _, u := range diffUnits {
+		if mode&u == 0 {
+			continue
+		}
+		if d.value(u) != 0 {
+			return "", false
+		}
+		smallest = u
+	}
+	if !f.ShowZero {
+		return "", true
+	}
+	return l.noun(0, unitNoun(smallest)), true
+}
+
+// unitNoun returns the locale unit name of the time unit.
+func unitNoun(unit DiffMode) string {
+	for i, u := range diffUnits {
+		if u == unit {
+			return localeUnits[i]
 		}
 	}
+	return ""
 }
 
-func formatMode(d Diff, mode DiffMode, withZeros bool) string {
-	var a []string
-	if mode&ModeYears != 0 && (withZeros || d.Years > 0) {
-		a = append(a, formatNoun(d.Years, "year"))
+// truncate sets 0 values of time units that follow MaxUnits most significant
+// time units with non 0 values.
+func (f Formatter) truncate(d Diff) Diff {
+	if f.MaxUnits <= 0 || f.WithZeros {
+		return d
+	}
+	n := 0
+	for _, u := range diffUnits {
+		if d.value(u) == 0 {
+			continue
+		}
+		if n < f.MaxUnits {
+			n++
+		} else {
+			d.setValue(u, 0)
+		}
 	}
-	if mode&ModeMonths != 0 && (withZeros || d.Months > 0) {
-		a = append(a, formatNoun(d.Months, "month"))
+	return d
+}
+
+// locale returns the locale used to format time units.
+func (f Formatter) locale() *Locale {
+	l := f.baseLocale()
+	if f.Compact {
+		return l.abbreviated()
 	}
-	if mode&ModeWeeks != 0 && (withZeros || d.Weeks > 0) {
-		a = append(a, formatNoun(d.Weeks, "week"))
+	return l
+}
+
+func (f Formatter) baseLocale() *Locale {
+	l := f.Locale
+	if l == nil {
+		l = english
 	}
-	if mode&ModeDays != 0 && (withZeros || d.Days > 0) {
-		a = append(a, formatNoun(d.Days, "day"))
+	if !f.Numbers.valid() {
+		return l.withNumbers(NumberingLatin)
 	}
-	return strings.Join(a, " ")
+	return l.withNumbers(f.Numbers)
 }
 
-// formatNoun takes a positive number n and noun s in singular form.
-// It returns a number and correct form of noun (singular or plural).
-func formatNoun(n int, s string) string {
-	f := "%d %s"
-	if n != 1 {
-		f += "s"
+// appendFormat appends dates difference formatted according to the provided
+// format in a single scan of the format. Verbs of time units with 0 values
+// are trimmed together with the preceding space of the format, or with the
+// following space when there is no preceding one, unless withZeros is set.
+// Since this function is private, it's assumed that format is valid.
+func appendFormat(b []byte, diff Diff, rawFormat string, withZeros bool, l *Locale) []byte {
+	// space is set when the last byte of b is the space of the format, and
+	// skipSpace is set when the following space of the format is trimmed
+	space, skipSpace := false, false
+	for i := 0; i < len(rawFormat); i++ {
+		c := rawFormat[i]
+		v := -1
+		if c == '%' && i+1 < len(rawFormat) {
+			v = formatVerb(rawFormat[i+1])
+		}
+		if v < 0 {
+			if !skipSpace || c != ' ' {
+				b = append(b, c)
+			}
+			space = c == ' ' && !skipSpace
+			skipSpace = false
+			continue
+		}
+		fv := formatVerbs[v]
+		n := diff.value(fv.mode)
+		switch {
+		case n == 0 && !withZeros && space:
+			b = b[:len(b)-1]
+		case n == 0 && !withZeros:
+			skipSpace = true
+		case unicode.IsUpper(rune(fv.verb)):
+			b = l.appendNoun(b, n, fv.unit)
+			skipSpace = false
+		default:
+			b = l.Numbers.appendFormat(b, n)
+			skipSpace = false
+		}
+		space = false
+		i++
 	}
-	return fmt.Sprintf(f, n, s)
+	return b
 }
 
-func verbReplace(s string, n int, verb, unit string) string {
-	replacement := strconv.Itoa(n)
-	if r := rune(verb[1]); unicode.IsUpper(r) {
-		replacement = formatNoun(n, unit)
+// formatVerb returns the index of the verb in formatVerbs, or -1 when the
+// verb is not supported.
+func formatVerb(c byte) int {
+	for i, v := range formatVerbs {
+		if v.verb == c {
+			return i
+		}
 	}
-	return strings.ReplaceAll(s, verb, replacement)
+	return -1
 }
 
-func zeroVerbReplace(s, verb string) string {
-	s = strings.ReplaceAll(s, " "+verb, "")
-	return strings.ReplaceAll(s, verb, "")
+// appendMode appends time units of the mode. All time units are formatted
+// when the mode is not defined, i.e dates difference is a struct literal.
+func appendMode(b []byte, d Diff, mode DiffMode, withZeros bool, l *Locale) []byte {
+	if mode == 0 {
+		mode = ModeAll
+	}
+	first := true
+	for i, u := range diffUnits {
+		n := d.value(u)
+		if mode&u == 0 || (!withZeros && n == 0) {
+			continue
+		}
+		if !first {
+			b = append(b, l.Separator...)
+		}
+		first = false
+		b = l.appendNoun(b, n, localeUnits[i])
+	}
+	return b
 }
@@ -1,3 +1,9 @@
 module github.com/antklim/datediff
 
 go 1.17
+
+require gopkg.in/yaml.v3 v3.0.1
+
+require golang.org/x/text v0.13.0
+
+require google.golang.org/protobuf v1.34.1
@@ -0,0 +1,43 @@
+package datediff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MarshalGQL implements the graphql.Marshaler interface of gqlgen. Dates
+// difference is written as a string scalar with the canonical string produced
+// by MarshalText, i.e "v1;mode=YMD;y=2;m=3;d=4".
+func (d Diff) MarshalGQL(w io.Writer) {
+	text, _ := d.MarshalText()
+	_, _ = io.WriteString(w, strconv.Quote(string(text)))
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen. It
+// accepts the canonical string, ISO 8601 duration, dates difference written
+// in English, i.e "1 year 6 months", or an input object with the JSON object
+// schema.
+func (d *Diff) UnmarshalGQL(v interface{}) error {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "P") || strings.HasPrefix(v, canonicalVersion+";") {
+			return d.UnmarshalText([]byte(v))
+		}
+		diff, err := ParseDiff(v)
+		if err != nil {
+			return err
+		}
+		*d = diff
+		return nil
+	case map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return d.UnmarshalJSON(data)
+	}
+	return fmt.Errorf("unsupported GraphQL value type %T", v)
+}
@@ -0,0 +1,69 @@
+package datediff_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestMarshalGQL(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	mustNewDiff(t, start, end, "%Y and %D").MarshalGQL(&buf)
+	expected := `"v1;mode=YD;y=3;d=31;start=2000-04-17T00:00:00Z;end=2003-05-18T00:00:00Z;fmt=%Y and %D"`
+	if got := buf.String(); got != expected {
+		t.Errorf("MarshalGQL() = %s, want %s", got, expected)
+	}
+}
+
+func TestUnmarshalGQL(t *testing.T) {
+	testCases := []struct {
+		v        interface{}
+		expected string
+	}{
+		{v: "P2Y3M", expected: "2 years 3 months"},
+		{v: "v1;mode=YM;y=2;m=3", expected: "2 years 3 months"},
+		{v: "1 year 6 months", expected: "1 year 6 months"},
+		{
+			v:        map[string]interface{}{"years": 2, "days": 4, "format": "%Y and %D"},
+			expected: "2 years and 4 days",
+		},
+		{
+			v:        map[string]interface{}{"weeks": 3, "mode": "weeks"},
+			expected: "3 weeks",
+		},
+	}
+	for _, tC := range testCases {
+		var got datediff.Diff
+		if err := got.UnmarshalGQL(tC.v); err != nil {
+			t.Errorf("UnmarshalGQL(%v) failed: %v", tC.v, err)
+		} else if got.String() != tC.expected {
+			t.Errorf("UnmarshalGQL(%v) String() = %s, want %s", tC.v, got.String(), tC.expected)
+		}
+	}
+}
+
+func TestUnmarshalGQLFails(t *testing.T) {
+	testCases := []struct {
+		v        interface{}
+		expected string
+	}{
+		{v: 42, expected: "unsupported GraphQL value type int"},
+		{v: "P2H", expected: `invalid ISO 8601 duration "P2H": unexpected designator H`},
+		{v: "soon", expected: `diff "soon" has no time units`},
+		{v: map[string]interface{}{"format": "%X"}, expected: `format "%X" has unknown verb X`},
+	}
+	for _, tC := range testCases {
+		var got datediff.Diff
+		err := got.UnmarshalGQL(tC.v)
+		if err == nil {
+			t.Errorf("UnmarshalGQL(%v) = %#v, want to fail due to %s", tC.v, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("UnmarshalGQL(%v) failed: %v, want to fail due to %s", tC.v, err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+// ISOWeek is the week of the ISO 8601 week-date, weeks start on Monday and
+// the first week of the week-year contains January 4.
+type ISOWeek struct {
+	Year int
+	Week int // week of the week-year, from 1 to 53
+}
+
+// ISOWeekOf returns the ISO week of the date in its location.
+func ISOWeekOf(t time.Time) ISOWeek {
+	year, week := t.ISOWeek()
+	return ISOWeek{Year: year, Week: week}
+}
+
+// String returns the ISO week in "2006-W01" format.
+func (w ISOWeek) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Week)
+}
+
+// ISOWeekSpan describes ISO weeks spanned by the range of dates.
+type ISOWeekSpan struct {
+	Start ISOWeek // week of the start date
+	End   ISOWeek // week of the end date
+	// Weeks is the number of ISO weeks spanned by the dates, including weeks
+	// of both start and end dates.
+	Weeks int
+	// WeekYears is the number of ISO week-years spanned by the dates, that
+	// can differ from calendar years near the year end.
+	WeekYears int
+}
+
+// String returns ISO weeks span in "14 ISO weeks across 2 week-years
+// (2021-W50 - 2022-W11)" format.
+func (s ISOWeekSpan) String() string {
+	weeks, years := "weeks", "week-years"
+	if s.Weeks == 1 {
+		weeks = "week"
+	}
+	if s.WeekYears == 1 {
+		years = "week-year"
+	}
+	return fmt.Sprintf("%d ISO %s across %d %s (%s - %s)", s.Weeks, weeks, s.WeekYears, years, s.Start, s.End)
+}
+
+// ISOWeeks returns ISO weeks spanned by the range of dates, i.e for
+// manufacturing or retail planning organized around ISO weeks. The end date
+// is converted to the location of the start date, options that adjust the
+// dates are applied. WithWeekStart option is ignored, since ISO weeks always
+// start on Monday.
+//
+// ISOWeeks returns error when start date is after end date.
+func ISOWeeks(start, end time.Time, opts ...Option) (ISOWeekSpan, error) {
+	if start.After(end) {
+		return ISOWeekSpan{}, ErrStartAfterEnd
+	}
+	o := newOptions(opts)
+	start, end = o.dates(start, end)
+	end = end.In(start.Location())
+
+	weeks, err := CalendarWeeks(start, end, WithWeekStart(time.Monday))
+	if err != nil {
+		return ISOWeekSpan{}, err
+	}
+	s, e := ISOWeekOf(start), ISOWeekOf(end)
+	return ISOWeekSpan{
+		Start:     s,
+		End:       e,
+		Weeks:     weeks + 1,
+		WeekYears: e.Year - s.Year + 1,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestISOWeekOf(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		expected string
+	}{
+		{date: date(2021, time.January, 3), expected: "2020-W53"},
+		{date: date(2021, time.January, 4), expected: "2021-W01"},
+		{date: date(2024, time.December, 30), expected: "2025-W01"},
+		{date: date(2023, time.June, 15), expected: "2023-W24"},
+	}
+	for _, tC := range testCases {
+		if got := datediff.ISOWeekOf(tC.date).String(); got != tC.expected {
+			t.Errorf("ISOWeekOf(%s) = %s, want %s", tC.date.Format("2006-01-02"), got, tC.expected)
+		}
+	}
+}
+
+func TestISOWeeks(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		opts     []datediff.Option
+		expected string
+	}{
+		{
+			desc:     "same day",
+			start:    date(2023, time.June, 15),
+			end:      date(2023, time.June, 15),
+			expected: "1 ISO week across 1 week-year (2023-W24 - 2023-W24)",
+		},
+		{
+			desc:     "across week-years",
+			start:    date(2021, time.December, 15),
+			end:      date(2022, time.March, 17),
+			expected: "14 ISO weeks across 2 week-years (2021-W50 - 2022-W11)",
+		},
+		{
+			desc:     "week-year differs from calendar year",
+			start:    date(2024, time.December, 23),
+			end:      date(2024, time.December, 31),
+			expected: "2 ISO weeks across 2 week-years (2024-W52 - 2025-W01)",
+		},
+		{
+			desc:     "Sunday to Monday",
+			start:    date(2023, time.June, 18),
+			end:      date(2023, time.June, 19),
+			opts:     []datediff.Option{datediff.WithWeekStart(time.Sunday)},
+			expected: "2 ISO weeks across 1 week-year (2023-W24 - 2023-W25)",
+		},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ISOWeeks(tC.start, tC.end, tC.opts...)
+		if err != nil {
+			t.Errorf("ISOWeeks() %s failed: %v", tC.desc, err)
+		} else if got.String() != tC.expected {
+			t.Errorf("ISOWeeks() %s = %s, want %s", tC.desc, got, tC.expected)
+		}
+	}
+
+	if _, err := datediff.ISOWeeks(date(2023, time.June, 19), date(2023, time.June, 18)); err != datediff.ErrStartAfterEnd {
+		t.Errorf("ISOWeeks() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+}
@@ -0,0 +1,356 @@
+package datediff
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// PluralCategory is a CLDR plural category, i.e "one" or "other".
+type PluralCategory string
+
+// These are CLDR plural categories.
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// numberPlaceholder is replaced by the number in the unit patterns.
+const numberPlaceholder = "{0}"
+
+var localeUnits = []string{"year", "month", "week", "day"}
+
+//go:embed locales/*.json
+var builtinLocalesFS embed.FS
+
+var builtinLocales = mustLoadBuiltinLocales()
+
+var english = builtinLocales["en"]
+
+// Locale describes how dates difference is presented in a particular language.
+// Locale should not be modified after it's passed to a Formatter.
+type Locale struct {
+	// Name is a language tag of the locale, i.e "en" or "pt-BR".
+	Name string
+	// Units contains patterns of time units by plural category. Units are
+	// "year", "month", "week", and "day". Every pattern has the {0}
+	// placeholder that is replaced by the number, i.e "{0} years".
+	Units map[string]map[PluralCategory]string
+	// Separator is put between time units when dates difference does not
+	// have the format, i.e it was created by NewDiffWithMode.
+	Separator string
+	// Format is the default format of the locale, i.e "%Y %M %D".
+	Format string
+	// Numbers is the numbering system used to write numbers. Latin digits
+	// are used when Numbers is not defined.
+	Numbers NumberingSystem
+	// CapitalizedNouns reports whether the language capitalizes nouns, i.e
+	// German. Casings keep letter case of such languages words.
+	CapitalizedNouns bool
+	// Abbreviations contains abbreviated patterns of time units by plural
+	// category, i.e "{0}y". They are used by the compact format. Units
+	// patterns are used when Abbreviations are not defined.
+	Abbreviations map[string]map[PluralCategory]string
+	// Relative contains patterns of relative phrases, i.e "2 years ago".
+	Relative RelativePatterns
+	// Pluralizer selects plural categories of numbers. CLDR plural rules of
+	// the locale language are used when Pluralizer is nil.
+	Pluralizer Pluralizer
+}
+
+// RelativePatterns describes relative phrases of the locale. Patterns have
+// the {0} placeholder that is replaced by the formatted dates difference.
+type RelativePatterns struct {
+	// Past is the pattern of the past phrase, i.e "{0} ago" or "vor {0}".
+	Past string `json:"past" yaml:"past"`
+	// Future is the pattern of the future phrase, i.e "in {0}" or "{0}後".
+	Future string `json:"future" yaml:"future"`
+	// Units overrides the locale units patterns in the relative phrases, it is
+	// required by languages where nouns change form, i.e "vor 2 Jahren".
+	Units map[string]map[PluralCategory]string `json:"units" yaml:"units"`
+}
+
+// localeFile is the schema of the locale definition file.
+type localeFile struct {
+	Name      string                               `json:"name" yaml:"name"`
+	Units     map[string]map[PluralCategory]string `json:"units" yaml:"units"`
+	Separator *string                              `json:"separator" yaml:"separator"`
+	Format    string                               `json:"format" yaml:"format"`
+	Numbers   NumberingSystem                      `json:"numbers" yaml:"numbers"`
+	Relative  RelativePatterns                     `json:"relative" yaml:"relative"`
+
+	Abbreviations    map[string]map[PluralCategory]string `json:"abbreviations" yaml:"abbreviations"`
+	CapitalizedNouns bool                                 `json:"capitalizedNouns" yaml:"capitalizedNouns"`
+}
+
+// LoadLocale reads the locale definition in JSON or YAML from r. Definition
+// has the following schema (YAML example):
+//
+//	name: de
+//	units:
+//	  year: {one: "{0} Jahr", other: "{0} Jahre"}
+//	  month: {one: "{0} Monat", other: "{0} Monate"}
+//	  week: {one: "{0} Woche", other: "{0} Wochen"}
+//	  day: {one: "{0} Tag", other: "{0} Tage"}
+//	separator: " "
+//	format: "%Y %M %D"
+//	numbers: latn
+//	capitalizedNouns: true
+//	abbreviations:
+//	  year: {other: "{0} J."}
+//	  month: {other: "{0} Mon."}
+//	  week: {other: "{0} Wo."}
+//	  day: {other: "{0} T."}
+//	relative:
+//	  past: "vor {0}"
+//	  future: "in {0}"
+//	  units:
+//	    year: {one: "{0} Jahr", other: "{0} Jahren"}
+//	    month: {one: "{0} Monat", other: "{0} Monaten"}
+//	    week: {one: "{0} Woche", other: "{0} Wochen"}
+//	    day: {one: "{0} Tag", other: "{0} Tagen"}
+//
+// Every unit requires the "other" plural category, it is used when the pattern
+// of the number's category is not defined. Plural categories of numbers are
+// selected by CLDR plural rules of the locale language, so the definition only
+// needs the words. Separator defaults to a space.
+func LoadLocale(r io.Reader) (*Locale, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var lf localeFile
+	if isJSON(data) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&lf)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&lf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("locale definition: %w", err)
+	}
+
+	l := &Locale{
+		Name:      lf.Name,
+		Units:     lf.Units,
+		Separator: " ",
+		Format:    lf.Format,
+		Numbers:   lf.Numbers,
+		Relative:  lf.Relative,
+
+		Abbreviations:    lf.Abbreviations,
+		CapitalizedNouns: lf.CapitalizedNouns,
+	}
+	if lf.Separator != nil {
+		l.Separator = *lf.Separator
+	}
+
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+	l.Pluralizer, err = NewCLDRPluralizer(l.Name)
+	if err != nil {
+		return nil, fmt.Errorf("locale %q: %w", l.Name, err)
+	}
+	return l, nil
+}
+
+// LookupLocale returns the built-in locale by its name. When there is no
+// locale for the region specific name, i.e "de-AT", the base language locale
+// is returned. The returned locale must not be modified.
+func LookupLocale(name string) (*Locale, error) {
+	if l, ok := builtinLocales[name]; ok {
+		return l, nil
+	}
+	if i := strings.IndexAny(name, "-_"); i > 0 {
+		if l, ok := builtinLocales[name[:i]]; ok {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown locale %q", name)
+}
+
+// Locales returns names of the built-in locales.
+func Locales() []string {
+	names := make([]string, 0, len(builtinLocales))
+	for name := range builtinLocales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (l *Locale) validate() error {
+	if l.Name == "" {
+		return errors.New("locale name is not defined")
+	}
+	if err := l.validateUnits(l.Units); err != nil {
+		return err
+	}
+	if len(l.Abbreviations) > 0 {
+		if err := l.validateUnits(l.Abbreviations); err != nil {
+			return fmt.Errorf("abbreviations: %w", err)
+		}
+	}
+	if len(l.Relative.Units) > 0 {
+		if err := l.validateUnits(l.Relative.Units); err != nil {
+			return fmt.Errorf("relative: %w", err)
+		}
+	}
+	for _, p := range []string{l.Relative.Past, l.Relative.Future} {
+		if p != "" && !strings.Contains(p, numberPlaceholder) {
+			return fmt.Errorf("locale %q relative pattern %q does not contain %s", l.Name, p, numberPlaceholder)
+		}
+	}
+	if !l.Numbers.valid() {
+		return fmt.Errorf("locale %q has unknown numbering system %q", l.Name, l.Numbers)
+	}
+	if l.Format != "" {
+		if _, err := unmarshal(l.Format); err != nil {
+			return fmt.Errorf("locale %q: %w", l.Name, err)
+		}
+	}
+	return nil
+}
+
+func (l *Locale) validateUnits(units map[string]map[PluralCategory]string) error {
+	for unit, patterns := range units {
+		if !isLocaleUnit(unit) {
+			return fmt.Errorf("locale %q has unknown unit %q", l.Name, unit)
+		}
+		for c, p := range patterns {
+			if !isPluralCategory(c) {
+				return fmt.Errorf("locale %q has unknown plural category %q of %s", l.Name, c, unit)
+			}
+			if !strings.Contains(p, numberPlaceholder) {
+				return fmt.Errorf("locale %q %s pattern %q does not contain %s", l.Name, unit, p, numberPlaceholder)
+			}
+		}
+	}
+	for _, unit := range localeUnits {
+		if _, ok := units[unit][PluralOther]; !ok {
+			return fmt.Errorf("locale %q does not define %q pattern of %s", l.Name, PluralOther, unit)
+		}
+	}
+	return nil
+}
+
+// withNumbers returns the locale that uses the numbering system.
+func (l *Locale) withNumbers(ns NumberingSystem) *Locale {
+	if ns == "" || ns == l.Numbers {
+		return l
+	}
+	nl := *l
+	nl.Numbers = ns
+	return &nl
+}
+
+// abbreviated returns the locale that uses abbreviated units patterns.
+func (l *Locale) abbreviated() *Locale {
+	if len(l.Abbreviations) == 0 {
+		return l
+	}
+	al := *l
+	al.Units = l.Abbreviations
+	return &al
+}
+
+// relative returns the locale that uses relative units patterns.
+func (l *Locale) relative() *Locale {
+	if len(l.Relative.Units) == 0 {
+		return l
+	}
+	rl := *l
+	rl.Units = l.Relative.Units
+	return &rl
+}
+
+// PluralCategory returns plural category of the number n in the locale.
+func (l *Locale) PluralCategory(n int) PluralCategory {
+	if l.Pluralizer != nil {
+		return l.Pluralizer.PluralCategory(n)
+	}
+	return cldrPluralizer{tag: language.Make(l.Name)}.PluralCategory(n)
+}
+
+// noun returns number n followed by the unit name in the correct plural form.
+func (l *Locale) noun(n int, unit string) string {
+	return string(l.appendNoun(nil, n, unit))
+}
+
+// appendNoun appends number n followed by the unit name in the correct plural
+// form to b.
+func (l *Locale) appendNoun(b []byte, n int, unit string) []byte {
+	patterns := l.Units[unit]
+	p, ok := patterns[l.PluralCategory(n)]
+	if !ok {
+		p = patterns[PluralOther]
+	}
+	i := strings.Index(p, numberPlaceholder)
+	if i < 0 {
+		return append(b, p...)
+	}
+	b = append(b, p[:i]...)
+	b = l.Numbers.appendFormat(b, n)
+	return append(b, p[i+len(numberPlaceholder):]...)
+}
+
+func isLocaleUnit(unit string) bool {
+	for _, u := range localeUnits {
+		if u == unit {
+			return true
+		}
+	}
+	return false
+}
+
+func isPluralCategory(c PluralCategory) bool {
+	switch c {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	}
+	return false
+}
+
+// isJSON reports whether data looks like a JSON object.
+func isJSON(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n\ufeff"), []byte("{"))
+}
+
+func mustLoadBuiltinLocales() map[string]*Locale {
+	files, err := builtinLocalesFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	locales := make(map[string]*Locale, len(files))
+	for _, file := range files {
+		f, err := builtinLocalesFS.Open(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		l, err := LoadLocale(f)
+		f.Close()
+		if err != nil {
+			panic(fmt.Errorf("built-in locale %s: %w", file.Name(), err))
+		}
+		locales[l.Name] = l
+	}
+	return locales
+}
@@ -0,0 +1,319 @@
+package datediff_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+const localeYAML = `
+name: pt
+units:
+  year: {one: "{0} ano", other: "{0} anos"}
+  month: {one: "{0} mês", other: "{0} meses"}
+  week: {one: "{0} semana", other: "{0} semanas"}
+  day: {one: "{0} dia", other: "{0} dias"}
+format: "%Y %M %D"
+`
+
+const localeJSON = `{
+  "name": "pt",
+  "units": {
+    "year": {"one": "{0} ano", "other": "{0} anos"},
+    "month": {"one": "{0} mês", "other": "{0} meses"},
+    "week": {"one": "{0} semana", "other": "{0} semanas"},
+    "day": {"one": "{0} dia", "other": "{0} dias"}
+  },
+  "separator": ", ",
+  "format": "%Y %M %D"
+}`
+
+func TestLoadLocale(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiffWithMode(start, end, datediff.ModeYears|datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		def      string
+		expected string
+	}{
+		{
+			desc:     "YAML",
+			def:      localeYAML,
+			expected: "3 anos 1 mês 1 dia",
+		},
+		{
+			desc:     "JSON",
+			def:      localeJSON,
+			expected: "3 anos, 1 mês, 1 dia",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			l, err := datediff.LoadLocale(strings.NewReader(tC.def))
+			if err != nil {
+				t.Fatalf("LoadLocale() failed: %v", err)
+			}
+			if l.Name != "pt" {
+				t.Errorf("LoadLocale() name = %s, want pt", l.Name)
+			}
+			got := datediff.Formatter{Locale: l}.String(diff)
+			if got != tC.expected {
+				t.Errorf("String() = %s, want %s", got, tC.expected)
+			}
+		})
+	}
+}
+
+func TestLoadLocaleFails(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		def      string
+		expected string
+	}{
+		{
+			desc:     "no name",
+			def:      `{"units": {}}`,
+			expected: "locale name is not defined",
+		},
+		{
+			desc:     "missing unit",
+			def:      `{"name": "xx", "units": {"year": {"other": "{0} y"}}}`,
+			expected: `locale "xx" does not define "other" pattern of month`,
+		},
+		{
+			desc:     "unknown unit",
+			def:      `{"name": "xx", "units": {"hour": {"other": "{0} h"}}}`,
+			expected: `locale "xx" has unknown unit "hour"`,
+		},
+		{
+			desc:     "unknown plural category",
+			def:      "name: xx\nunits:\n  year: {single: \"{0} y\"}\n",
+			expected: `locale "xx" has unknown plural category "single" of year`,
+		},
+		{
+			desc:     "pattern without placeholder",
+			def:      "name: xx\nunits:\n  year: {other: years}\n",
+			expected: `locale "xx" year pattern "years" does not contain {0}`,
+		},
+		{
+			desc: "invalid format",
+			def: `{"name": "xx", "units": {
+				"year": {"other": "{0} y"}, "month": {"other": "{0} m"},
+				"week": {"other": "{0} w"}, "day": {"other": "{0} d"}
+			}, "format": "%Y %H"}`,
+			expected: `locale "xx": format "%Y %H" has unknown verb H`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := datediff.LoadLocale(strings.NewReader(tC.def))
+			if err == nil {
+				t.Fatalf("LoadLocale() want to fail due to %s", tC.expected)
+			}
+			if err.Error() != tC.expected {
+				t.Errorf("LoadLocale() failed: %v, want to fail due to %s", err, tC.expected)
+			}
+		})
+	}
+
+	if _, err := datediff.LoadLocale(strings.NewReader(`{"name": "xx", "plural": "one"}`)); err == nil {
+		t.Errorf("LoadLocale() want to fail due to unknown field")
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiff(start, end, "%Y %M %D")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "en", expected: "3 years 1 month 1 day"},
+		{name: "de", expected: "3 Jahre 1 Monat 1 Tag"},
+		{name: "de-AT", expected: "3 Jahre 1 Monat 1 Tag"},
+		{name: "es", expected: "3 años 1 mes 1 día"},
+		{name: "ja", expected: "3年 1か月 1日"},
+		{name: "fr", expected: "3 ans 1 mois 1 jour"},
+		{name: "ru", expected: "3 года 1 месяц 1 день"},
+		{name: "pl", expected: "3 lata 1 miesiąc 1 dzień"},
+	}
+	for _, tC := range testCases {
+		l, err := datediff.LookupLocale(tC.name)
+		if err != nil {
+			t.Errorf("LookupLocale(%s) failed: %v", tC.name, err)
+			continue
+		}
+		got := datediff.Formatter{Locale: l}.String(diff)
+		if got != tC.expected {
+			t.Errorf("LookupLocale(%s) String() = %s, want %s", tC.name, got, tC.expected)
+		}
+	}
+
+	if _, err := datediff.LookupLocale("xx"); err == nil {
+		t.Errorf("LookupLocale(xx) want to fail due to unknown locale")
+	}
+}
+
+func TestFormatterFormat(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.April, 20, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiffWithMode(start, end, datediff.ModeYears|datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	ja, err := datediff.LookupLocale("ja")
+	if err != nil {
+		t.Fatalf("LookupLocale(ja) failed: %v", err)
+	}
+
+	testCases := []struct {
+		formatter datediff.Formatter
+		format    string
+		expected  string
+	}{
+		{formatter: datediff.Formatter{Locale: ja}, format: "", expected: "3年3日"},
+		{formatter: datediff.Formatter{Locale: ja, WithZeros: true}, format: "", expected: "3年0か月3日"},
+		{formatter: datediff.Formatter{Locale: ja}, format: "%Y", expected: "3年"},
+		{formatter: datediff.Formatter{}, format: "", expected: "3 years 3 days"},
+		{formatter: datediff.Formatter{WithZeros: true}, format: "%Y %M", expected: "3 years 0 months"},
+		{formatter: datediff.Formatter{MaxUnits: 1}, format: "", expected: "3 years"},
+		{formatter: datediff.Formatter{MaxUnits: 2}, format: "", expected: "3 years 3 days"},
+		{formatter: datediff.Formatter{MaxUnits: 1, WithZeros: true}, format: "", expected: "3 years 0 months 3 days"},
+	}
+	for _, tC := range testCases {
+		got, err := tC.formatter.Format(diff, tC.format)
+		if err != nil {
+			t.Errorf("Format(%s) failed: %v", tC.format, err)
+		} else if got != tC.expected {
+			t.Errorf("Format(%s) = %s, want %s", tC.format, got, tC.expected)
+		}
+	}
+
+	if got := (datediff.Formatter{Locale: ja}).String(diff); got != "3年3日" {
+		t.Errorf("String() = %s, want 3年3日", got)
+	}
+}
+
+func TestFormatterRelative(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2002, time.April, 24, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiff(start, end, "%Y %W")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+
+	testCases := []struct {
+		locale string
+		ago    string
+		in     string
+	}{
+		{locale: "en", ago: "2 years 1 week ago", in: "in 2 years 1 week"},
+		{locale: "de", ago: "vor 2 Jahren 1 Woche", in: "in 2 Jahren 1 Woche"},
+		{locale: "es", ago: "hace 2 años 1 semana", in: "dentro de 2 años 1 semana"},
+		{locale: "fr", ago: "il y a 2 ans 1 semaine", in: "dans 2 ans 1 semaine"},
+		{locale: "ja", ago: "2年 1週間前", in: "2年 1週間後"},
+		{locale: "pl", ago: "2 lata 1 tydzień temu", in: "za 2 lata 1 tydzień"},
+		{locale: "ru", ago: "2 года 1 неделю назад", in: "через 2 года 1 неделю"},
+	}
+	for _, tC := range testCases {
+		l, err := datediff.LookupLocale(tC.locale)
+		if err != nil {
+			t.Errorf("LookupLocale(%s) failed: %v", tC.locale, err)
+			continue
+		}
+		f := datediff.Formatter{Locale: l}
+		if got := f.Ago(diff); got != tC.ago {
+			t.Errorf("%s Ago() = %s, want %s", tC.locale, got, tC.ago)
+		}
+		if got := f.In(diff); got != tC.in {
+			t.Errorf("%s In() = %s, want %s", tC.locale, got, tC.in)
+		}
+	}
+
+	{
+		l := &datediff.Locale{Name: "pt", Units: map[string]map[datediff.PluralCategory]string{
+			"year":  {datediff.PluralOther: "{0} anos"},
+			"month": {datediff.PluralOther: "{0} meses"},
+			"week":  {datediff.PluralOther: "{0} semanas"},
+			"day":   {datediff.PluralOther: "{0} dias"},
+		}}
+		expected := "2 anos 1 semanas"
+		if got := (datediff.Formatter{Locale: l}).Ago(diff); got != expected {
+			t.Errorf("Ago() without relative patterns = %s, want %s", got, expected)
+		}
+	}
+
+	{
+		expected := ""
+		if got := (datediff.Formatter{}).Ago(datediff.Diff{}); got != expected {
+			t.Errorf("Ago() of zero dates difference = %q, want %q", got, expected)
+		}
+	}
+}
+
+func TestFormatterCompact(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2005, time.July, 19, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiffWithMode(start, end, datediff.ModeYears|datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+
+	testCases := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: "en", expected: "5y 3mo 2d"},
+		{locale: "de", expected: "5 J. 3 Mon. 2 T."},
+		{locale: "es", expected: "5 a 3 m 2 d"},
+		{locale: "fr", expected: "5 a 3 m. 2 j"},
+		{locale: "ja", expected: "5年3か月2日"},
+		{locale: "pl", expected: "5 l. 3 mies. 2 dni"},
+		{locale: "ru", expected: "5 л. 3 мес. 2 дн."},
+	}
+	for _, tC := range testCases {
+		l, err := datediff.LookupLocale(tC.locale)
+		if err != nil {
+			t.Errorf("LookupLocale(%s) failed: %v", tC.locale, err)
+			continue
+		}
+		f := datediff.Formatter{Locale: l, Compact: true}
+		if got := f.String(diff); got != tC.expected {
+			t.Errorf("%s compact String() = %s, want %s", tC.locale, got, tC.expected)
+		}
+	}
+
+	for _, name := range datediff.Locales() {
+		l, err := datediff.LookupLocale(name)
+		if err != nil {
+			t.Errorf("LookupLocale(%s) failed: %v", name, err)
+			continue
+		}
+		if len(l.Abbreviations) == 0 {
+			t.Errorf("built-in locale %s does not define abbreviations", name)
+		}
+	}
+
+	{
+		f := datediff.Formatter{Compact: true, WithZeros: true}
+		expected := "5y 3mo 0w 2d"
+		got, err := f.Format(diff, "%Y %M %W %D")
+		if err != nil {
+			t.Errorf("compact Format() failed: %v", err)
+		} else if got != expected {
+			t.Errorf("compact Format() = %s, want %s", got, expected)
+		}
+	}
+}
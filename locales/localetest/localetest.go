@@ -0,0 +1,208 @@
+// Package localetest implements the conformance test of datediff locales.
+//
+// Locale contributors can verify their definitions in a regular Go test:
+//
+//	func TestLocale(t *testing.T) {
+//		f, err := os.Open("testdata/xx.yaml")
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		defer f.Close()
+//
+//		l, err := datediff.LoadLocale(f)
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		localetest.Run(t, l)
+//	}
+package localetest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+	"golang.org/x/text/language"
+)
+
+// Numbers are the edge cases of plural rules the locale is tested against.
+// They cover special cases of zero, one and two, ranges of "few" and "many"
+// categories, teens, and numbers whose rules depend on the last digits.
+var Numbers = []int{
+	0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10,
+	11, 12, 13, 14, 15, 19, 20, 21, 22, 23, 24, 25, 29, 30, 31,
+	100, 101, 102, 103, 104, 105, 111, 112, 114, 121, 122, 125,
+	1000, 1001, 1002, 1005,
+}
+
+// start is the start date of the tested dates differences.
+var start = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var units = []struct {
+	name string
+	verb string
+	mode datediff.DiffMode
+	end  func(n int) time.Time
+}{
+	{name: "year", verb: "%Y", mode: datediff.ModeYears, end: func(n int) time.Time { return start.AddDate(n, 0, 0) }},
+	{name: "month", verb: "%M", mode: datediff.ModeMonths, end: func(n int) time.Time { return start.AddDate(0, n, 0) }},
+	{name: "week", verb: "%W", mode: datediff.ModeWeeks, end: func(n int) time.Time { return start.AddDate(0, 0, 7*n) }},
+	{name: "day", verb: "%D", mode: datediff.ModeDays, end: func(n int) time.Time { return start.AddDate(0, 0, n) }},
+}
+
+// Run runs the conformance test of the locale. It verifies that:
+//
+//	locale name is a well-formed BCP 47 language tag
+//	plural rules select a valid category for every number in Numbers
+//	units, abbreviations and relative units define a pattern for every
+//	plural category selected by the plural rules, unless the unit has
+//	the only "other" pattern
+//	every pattern contains exactly one number placeholder
+//	formatted time units, compact time units and relative phrases contain
+//	the number written in the locale numbering system
+//	the default format is valid
+func Run(t *testing.T, l *datediff.Locale) {
+	t.Helper()
+
+	t.Run("name", func(t *testing.T) {
+		if _, err := language.Parse(l.Name); err != nil {
+			t.Errorf("locale name %q is not a valid language tag: %v", l.Name, err)
+		}
+	})
+
+	t.Run("plural rules", func(t *testing.T) {
+		for _, n := range Numbers {
+			c := l.PluralCategory(n)
+			if !validCategory(c) {
+				t.Errorf("PluralCategory(%d) = %q, want one of CLDR plural categories", n, c)
+			}
+			if neg := l.PluralCategory(-n); neg != c {
+				t.Errorf("PluralCategory(%d) = %q, want %q as for %d", -n, neg, c, n)
+			}
+		}
+	})
+
+	t.Run("units", func(t *testing.T) {
+		checkPatterns(t, l, l.Units)
+	})
+
+	if len(l.Abbreviations) > 0 {
+		t.Run("abbreviations", func(t *testing.T) {
+			checkPatterns(t, l, l.Abbreviations)
+		})
+	}
+
+	if len(l.Relative.Units) > 0 {
+		t.Run("relative units", func(t *testing.T) {
+			checkPatterns(t, l, l.Relative.Units)
+		})
+	}
+
+	t.Run("format", func(t *testing.T) {
+		formatters := []datediff.Formatter{
+			{Locale: l, WithZeros: true},
+			{Locale: l, WithZeros: true, Compact: true},
+		}
+		for _, f := range formatters {
+			for _, u := range units {
+				for _, n := range Numbers {
+					checkFormat(t, f, diff(t, u.mode, u.end(n)), u.verb)
+				}
+			}
+		}
+		if l.Format != "" {
+			d := datediff.Diff{Years: 1, Months: 2, Weeks: 3, Days: 4}
+			if _, err := (datediff.Formatter{Locale: l}).Format(d, ""); err != nil {
+				t.Errorf("default format %q failed: %v", l.Format, err)
+			}
+		}
+	})
+
+	t.Run("relative", func(t *testing.T) {
+		f := datediff.Formatter{Locale: l}
+		for _, u := range units {
+			for _, n := range Numbers[1:] {
+				d := diff(t, u.mode, u.end(n))
+				num := number(t, f, n)
+				if got := f.Ago(d); !strings.Contains(got, num) {
+					t.Errorf("Ago(%d %s) = %q, want to contain %s", n, u.name, got, num)
+				}
+				if got := f.In(d); !strings.Contains(got, num) {
+					t.Errorf("In(%d %s) = %q, want to contain %s", n, u.name, got, num)
+				}
+			}
+		}
+	})
+}
+
+func checkPatterns(t *testing.T, l *datediff.Locale, patterns map[string]map[datediff.PluralCategory]string) {
+	t.Helper()
+	for _, u := range units {
+		p, ok := patterns[u.name]
+		if !ok {
+			t.Errorf("%s patterns are not defined", u.name)
+			continue
+		}
+		if _, ok := p[datediff.PluralOther]; !ok {
+			t.Errorf("%s does not define %q pattern", u.name, datediff.PluralOther)
+		}
+		for c, s := range p {
+			if n := strings.Count(s, "{0}"); n != 1 {
+				t.Errorf("%s %q pattern %q has %d placeholders, want 1", u.name, c, s, n)
+			}
+		}
+		if len(p) == 1 {
+			// the only "other" pattern is used for all numbers
+			continue
+		}
+		for _, n := range Numbers {
+			c := l.PluralCategory(n)
+			if _, ok := p[c]; !ok {
+				t.Errorf("%s does not define %q pattern required by %d", u.name, c, n)
+			}
+		}
+	}
+}
+
+func checkFormat(t *testing.T, f datediff.Formatter, d datediff.Diff, verb string) {
+	t.Helper()
+	got, err := f.Format(d, verb)
+	if err != nil {
+		t.Errorf("Format(%#v, %s) failed: %v", d, verb, err)
+		return
+	}
+	n := d.Years + d.Months + d.Weeks + d.Days
+	if num := number(t, f, n); !strings.Contains(got, num) {
+		t.Errorf("Format(%#v, %s) = %q, want to contain %s", d, verb, got, num)
+	}
+}
+
+func diff(t *testing.T, mode datediff.DiffMode, end time.Time) datediff.Diff {
+	t.Helper()
+	d, err := datediff.NewDiffWithMode(start, end, mode)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode(%s, %s, %d) failed: %v", start, end, mode, err)
+	}
+	return d
+}
+
+// number returns n written in the formatter numbering system.
+func number(t *testing.T, f datediff.Formatter, n int) string {
+	t.Helper()
+	f.WithZeros = true
+	s, err := f.Format(datediff.Diff{Days: n}, "%d")
+	if err != nil {
+		t.Fatalf("Format(%d) failed: %v", n, err)
+	}
+	return s
+}
+
+func validCategory(c datediff.PluralCategory) bool {
+	switch c {
+	case datediff.PluralZero, datediff.PluralOne, datediff.PluralTwo,
+		datediff.PluralFew, datediff.PluralMany, datediff.PluralOther:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package localetest_test
+
+import (
+	"testing"
+
+	"github.com/antklim/datediff"
+	"github.com/antklim/datediff/locales/localetest"
+)
+
+func TestBuiltinLocales(t *testing.T) {
+	for _, name := range datediff.Locales() {
+		l, err := datediff.LookupLocale(name)
+		if err != nil {
+			t.Fatalf("LookupLocale(%s) failed: %v", name, err)
+		}
+		t.Run(name, func(t *testing.T) {
+			localetest.Run(t, l)
+		})
+	}
+}
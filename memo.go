@@ -0,0 +1,30 @@
+package datediff
+
+// Rendered is dates difference with the string rendered once by String, i.e
+// for UI code that formats the same dates difference on every redraw. Methods
+// of the dates difference are promoted, copies derived by With* methods,
+// WithFormat and Normalize are Diff values that render their own strings.
+// Rendered should not be modified, setting time units fields of the embedded
+// dates difference does not update the rendered string. It's safe to call
+// String from concurrent goroutines.
+type Rendered struct {
+	Diff
+	s string
+}
+
+// Memoize renders dates difference as String does and returns it with the
+// rendered string.
+func (d Diff) Memoize() Rendered {
+	return Rendered{Diff: d, s: d.String()}
+}
+
+// String returns dates difference rendered by Memoize.
+func (r Rendered) String() string {
+	return r.s
+}
+
+// AppendString appends dates difference rendered by Memoize to b and returns
+// the extended buffer.
+func (r Rendered) AppendString(b []byte) []byte {
+	return append(b, r.s...)
+}
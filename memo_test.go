@@ -0,0 +1,72 @@
+package datediff_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestMemoize(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	d := mustNewDiff(t, start, start.AddDate(2, 3, 5), "%Y %M %D").Memoize()
+
+	want := "2 years 3 months 5 days"
+	if got := d.String(); got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _ = d.String() }); allocs > 0 {
+		t.Errorf("String() of memoized dates difference allocates %.0f times, want 0", allocs)
+	}
+	if got := string(d.AppendString([]byte("uptime: "))); got != "uptime: "+want {
+		t.Errorf("AppendString() = %s, want uptime: %s", got, want)
+	}
+	if got := fmt.Sprint(d); got != want {
+		t.Errorf("fmt.Sprint() = %s, want %s", got, want)
+	}
+
+	de, err := datediff.LookupLocale("de")
+	if err != nil {
+		t.Fatalf("LookupLocale(de) failed: %v", err)
+	}
+	withFormat, err := d.WithFormat("%D %M %Y")
+	if err != nil {
+		t.Fatalf("WithFormat() failed: %v", err)
+	}
+	testCases := []struct {
+		desc     string
+		diff     fmt.Stringer
+		expected string
+	}{
+		{desc: "WithMaxUnits", diff: d.WithMaxUnits(1), expected: "2 years"},
+		{desc: "WithLocale", diff: d.WithLocale(de), expected: "2 Jahre 3 Monate 5 Tage"},
+		{desc: "WithFormat", diff: withFormat, expected: "5 days 3 months 2 years"},
+		{desc: "WithFormatter", diff: d.WithFormatter(datediff.Formatter{Compact: true}), expected: "2y 3mo 5d"},
+		{desc: "Sub", diff: d.Sub(datediff.Diff{Days: 5}), expected: "2 years 3 months"},
+		{desc: "original", diff: d, expected: want},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.String(); got != tC.expected {
+			t.Errorf("%s String() = %s, want %s", tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	d := mustNewDiff(t, start, start.AddDate(2, 3, 5), "%Y %M %D").Memoize()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, want := d.String(), "2 years 3 months 5 days"; got != want {
+				t.Errorf("String() = %s, want %s", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
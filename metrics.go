@@ -0,0 +1,64 @@
+package datediff
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Gauge exposes dates difference between the start date and the current date
+// as metric values, i.e uptime of a service or age of the data. It
+// implements the expvar.Var interface:
+//
+//	expvar.Publish("uptime", datediff.Gauge{Start: startedAt, Mode: datediff.ModeYears | datediff.ModeDays})
+//
+// Values can be exported to Prometheus with a GaugeFunc per time unit.
+type Gauge struct {
+	// Start is the start date of the dates difference.
+	Start time.Time
+	// Mode defines time units of the dates difference.
+	Mode DiffMode
+	// Now returns the current date. time.Now is used when Now is nil.
+	Now func() time.Time
+}
+
+// Values returns values of years, months, weeks and days of the dates
+// difference, and the total number of days between the start date and the
+// current date under "total_days" key. Time units that are not in the mode
+// have 0 value.
+func (g Gauge) Values() (map[string]int, error) {
+	now := time.Now
+	if g.Now != nil {
+		now = g.Now
+	}
+	end := now()
+
+	d, err := NewDiffWithMode(g.Start, end, g.Mode)
+	if err != nil {
+		return nil, err
+	}
+	total, err := NewDiffWithMode(g.Start, end, ModeDays)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int{
+		"years":      d.Years,
+		"months":     d.Months,
+		"weeks":      d.Weeks,
+		"days":       d.Days,
+		"total_days": total.Days,
+	}, nil
+}
+
+// String returns gauge values as JSON object. It returns "null" when values
+// can't be calculated, i.e the start date is after the current date.
+func (g Gauge) String() string {
+	values, err := g.Values()
+	if err != nil {
+		return "null"
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
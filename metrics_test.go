@@ -0,0 +1,41 @@
+package datediff_test
+
+import (
+	"expvar"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestGauge(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC) }
+
+	g := datediff.Gauge{Start: start, Mode: datediff.ModeYears | datediff.ModeDays, Now: now}
+	expected := map[string]int{"years": 3, "months": 0, "weeks": 0, "days": 31, "total_days": 1126}
+	got, err := g.Values()
+	if err != nil {
+		t.Fatalf("Values() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Values() = %v, want %v", got, expected)
+	}
+
+	expvar.Publish("uptime", g)
+	expectedJSON := `{"days":31,"months":0,"total_days":1126,"weeks":0,"years":3}`
+	if got := expvar.Get("uptime").String(); got != expectedJSON {
+		t.Errorf("expvar.Get(uptime) = %s, want %s", got, expectedJSON)
+	}
+}
+
+func TestGaugeFails(t *testing.T) {
+	g := datediff.Gauge{Start: time.Now().AddDate(1, 0, 0), Mode: datediff.ModeDays}
+	if got, err := g.Values(); err == nil {
+		t.Errorf("Values() = %v, want to fail due to start date is after end date", got)
+	}
+	if got := g.String(); got != "null" {
+		t.Errorf("String() = %s, want null", got)
+	}
+}
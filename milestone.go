@@ -0,0 +1,32 @@
+package datediff
+
+import "time"
+
+// Milestones returns the iterator over milestones of the start date until
+// the end date inclusive, i.e every month-versary of the subscription when the
+// step is 1 month. It yields the number of the milestone and its date. The
+// n-th milestone is calculated by adding the step multiplied by n to the
+// start date, so the month overflow does not accumulate: milestones of
+// January 31 with 1 month step are March 3 (or 2), March 31, May 1, and so
+// on. The iterator yields nothing when the step has negative time units or
+// all of them are 0.
+//
+// The iterator has the signature of iter.Seq2[int, time.Time], in Go 1.23 and
+// later it can be used with range-over-func:
+//
+//	for n, t := range Milestones(start, end, Diff{Months: 1}) {
+//		fmt.Printf("%d month-versary is on %s\n", n, t.Format("2006-01-02"))
+//	}
+func Milestones(start, end time.Time, step Diff) func(yield func(int, time.Time) bool) {
+	return func(yield func(int, time.Time) bool) {
+		if step.IsZero() || step.Years < 0 || step.Months < 0 || step.Weeks < 0 || step.Days < 0 {
+			return
+		}
+		for n := 1; ; n++ {
+			t := addDiff(start, step, n)
+			if t.After(end) || !yield(n, t) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestMilestones(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		start    string
+		end      string
+		step     datediff.Diff
+		expected []string
+	}{
+		{
+			desc:     "monthly",
+			start:    "2020-01-31",
+			end:      "2020-06-01",
+			step:     datediff.Diff{Months: 1},
+			expected: []string{"2020-03-02", "2020-03-31", "2020-05-01", "2020-05-31"},
+		},
+		{
+			desc:     "yearly including end date",
+			start:    "2018-04-17",
+			end:      "2021-04-17",
+			step:     datediff.Diff{Years: 1},
+			expected: []string{"2019-04-17", "2020-04-17", "2021-04-17"},
+		},
+		{
+			desc:     "combined step",
+			start:    "2020-01-01",
+			end:      "2020-05-01",
+			step:     datediff.Diff{Months: 1, Weeks: 1},
+			expected: []string{"2020-02-08", "2020-03-15", "2020-04-22"},
+		},
+		{
+			desc:  "end before the first milestone",
+			start: "2020-01-01",
+			end:   "2020-01-20",
+			step:  datediff.Diff{Months: 1},
+		},
+		{
+			desc:  "zero step",
+			start: "2020-01-01",
+			end:   "2020-05-01",
+		},
+		{
+			desc:  "negative step",
+			start: "2020-01-01",
+			end:   "2020-05-01",
+			step:  datediff.Diff{Months: 1, Days: -1},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			start, _ := time.Parse(dateFmt, tC.start)
+			end, _ := time.Parse(dateFmt, tC.end)
+
+			var got []string
+			datediff.Milestones(start, end, tC.step)(func(n int, d time.Time) bool {
+				if n != len(got)+1 {
+					t.Errorf("Milestones() yielded milestone #%d, want #%d", n, len(got)+1)
+				}
+				got = append(got, d.Format(dateFmt))
+				return true
+			})
+			if len(got) != len(tC.expected) {
+				t.Fatalf("Milestones() = %v, want %v", got, tC.expected)
+			}
+			for i := range got {
+				if got[i] != tC.expected[i] {
+					t.Errorf("Milestones() = %v, want %v", got, tC.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestMilestonesStop(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(10, 0, 0)
+
+	var count int
+	datediff.Milestones(start, end, datediff.Diff{Days: 1})(func(int, time.Time) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("Milestones() yielded %d milestones after stop, want 3", count)
+	}
+}
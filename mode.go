@@ -0,0 +1,139 @@
+package datediff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// modeSeparator separates time unit names of the mode.
+	modeSeparator = "|"
+	// modeAllName is the name of the mode with all time units.
+	modeAllName = "all"
+)
+
+// modeNames are names of time units in the order of significance.
+var modeNames = []struct {
+	name string
+	mode DiffMode
+}{
+	{name: "years", mode: ModeYears},
+	{name: "months", mode: ModeMonths},
+	{name: "weeks", mode: ModeWeeks},
+	{name: "days", mode: ModeDays},
+}
+
+// String returns names of time units of the mode separated by "|", i.e
+// "years|months|days". Zero mode and modes with unknown bits are returned
+// as "DiffMode(n)".
+func (m DiffMode) String() string {
+	if m == 0 || !m.valid() {
+		return "DiffMode(" + strconv.Itoa(int(m)) + ")"
+	}
+	var names []string
+	for _, n := range modeNames {
+		if m&n.mode != 0 {
+			names = append(names, n.name)
+		}
+	}
+	return strings.Join(names, modeSeparator)
+}
+
+// valid returns true when the mode has only time unit bits set.
+func (m DiffMode) valid() bool {
+	return m&^ModeAll == 0
+}
+
+// Has returns true when the mode has all time units of the unit, i.e
+// mode.Has(ModeYears|ModeMonths) returns true when the mode has both years
+// and months. It returns false for zero unit.
+func (m DiffMode) Has(unit DiffMode) bool {
+	return unit != 0 && m&unit == unit
+}
+
+// ParseMode parses names of time units separated by "|" or ",", i.e
+// "years|months|days" or "years,months,days". Names are case insensitive, the
+// order of names does not matter. The name "all" stands for ModeAll.
+func ParseMode(s string) (DiffMode, error) {
+	var mode DiffMode
+	names := strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ',' })
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.EqualFold(name, modeAllName) {
+			mode |= ModeAll
+			continue
+		}
+		found := false
+		for _, n := range modeNames {
+			if strings.EqualFold(name, n.name) {
+				mode |= n.mode
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("mode %q has unknown time unit %q", s, name)
+		}
+	}
+	if mode == 0 {
+		return 0, ErrUndefinedMode
+	}
+	return mode, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The mode is
+// encoded as names of time units, zero mode is encoded as empty string.
+func (m DiffMode) MarshalText() ([]byte, error) {
+	if !m.valid() {
+		return nil, fmt.Errorf("invalid dates difference mode %d", m)
+	}
+	if m == 0 {
+		return []byte{}, nil
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// names of time units or the numeric value of the mode. Empty text is
+// decoded as zero mode.
+func (m *DiffMode) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s == "" {
+		*m = 0
+		return nil
+	}
+	if n, err := strconv.ParseUint(s, 10, 8); err == nil {
+		*m = DiffMode(n)
+		return nil
+	}
+	mode, err := ParseMode(s)
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts string
+// with names of time units, or number for compatibility with the numeric
+// encoding of the mode.
+func (m *DiffMode) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return m.UnmarshalText([]byte(s))
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*m = DiffMode(n)
+	return nil
+}
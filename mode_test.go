@@ -0,0 +1,125 @@
+package datediff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/antklim/datediff"
+)
+
+func TestModeString(t *testing.T) {
+	testCases := []struct {
+		mode     datediff.DiffMode
+		expected string
+	}{
+		{mode: datediff.ModeYears, expected: "years"},
+		{mode: datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays, expected: "years|months|days"},
+		{mode: datediff.ModeDays | datediff.ModeWeeks, expected: "weeks|days"},
+		{mode: 0, expected: "DiffMode(0)"},
+		{mode: 3, expected: "DiffMode(3)"},
+	}
+	for _, tC := range testCases {
+		if got := tC.mode.String(); got != tC.expected {
+			t.Errorf("DiffMode(%d).String() = %s, want %s", uint8(tC.mode), got, tC.expected)
+		}
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected datediff.DiffMode
+	}{
+		{s: "years", expected: datediff.ModeYears},
+		{s: "years|months|days", expected: datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays},
+		{s: "Days | Weeks", expected: datediff.ModeWeeks | datediff.ModeDays},
+		{s: "months|months", expected: datediff.ModeMonths},
+		{s: "years,months,days", expected: datediff.ModeYears | datediff.ModeMonths | datediff.ModeDays},
+		{s: "weeks, days|years", expected: datediff.ModeYears | datediff.ModeWeeks | datediff.ModeDays},
+		{s: "ALL", expected: datediff.ModeAll},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseMode(tC.s)
+		if err != nil {
+			t.Errorf("ParseMode(%s) failed: %v", tC.s, err)
+		} else if got != tC.expected {
+			t.Errorf("ParseMode(%s) = %s, want %s", tC.s, got, tC.expected)
+		}
+	}
+}
+
+func TestModeHas(t *testing.T) {
+	mode := datediff.ModeYears | datediff.ModeMonths
+	testCases := []struct {
+		unit     datediff.DiffMode
+		expected bool
+	}{
+		{unit: datediff.ModeYears, expected: true},
+		{unit: datediff.ModeYears | datediff.ModeMonths, expected: true},
+		{unit: datediff.ModeDays, expected: false},
+		{unit: datediff.ModeMonths | datediff.ModeDays, expected: false},
+		{unit: 0, expected: false},
+	}
+	for _, tC := range testCases {
+		if got := mode.Has(tC.unit); got != tC.expected {
+			t.Errorf("%s.Has(%s) = %t, want %t", mode, tC.unit, got, tC.expected)
+		}
+	}
+	if !datediff.ModeAll.Has(mode) {
+		t.Errorf("ModeAll.Has(%s) = false, want true", mode)
+	}
+}
+
+func TestParseModeFails(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected string
+	}{
+		{s: "", expected: "undefined dates difference mode"},
+		{s: " | ", expected: "undefined dates difference mode"},
+		{s: ",,", expected: "undefined dates difference mode"},
+		{s: "years|hours", expected: `mode "years|hours" has unknown time unit "hours"`},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseMode(tC.s)
+		if err == nil {
+			t.Errorf("ParseMode(%s) = %s, want to fail due to %s", tC.s, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParseMode(%s) failed: %v, want to fail due to %s", tC.s, err, tC.expected)
+		}
+	}
+}
+
+func TestModeJSON(t *testing.T) {
+	type config struct {
+		Mode datediff.DiffMode `json:"mode"`
+	}
+
+	got, err := json.Marshal(config{Mode: datediff.ModeMonths | datediff.ModeDays})
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	expected := `{"mode":"months|days"}`
+	if string(got) != expected {
+		t.Errorf("json.Marshal() = %s, want %s", got, expected)
+	}
+
+	if _, err := json.Marshal(config{Mode: 3}); err == nil {
+		t.Errorf("json.Marshal(DiffMode(3)) want to fail due to invalid mode")
+	}
+
+	for _, data := range []string{`{"mode":"months|days"}`, `{"mode":80}`, `{"mode":"80"}`} {
+		var c config
+		if err := json.Unmarshal([]byte(data), &c); err != nil {
+			t.Errorf("json.Unmarshal(%s) failed: %v", data, err)
+		} else if c.Mode != datediff.ModeMonths|datediff.ModeDays {
+			t.Errorf("json.Unmarshal(%s) = %s, want months|days", data, c.Mode)
+		}
+	}
+
+	data := `{"mode":"hours"}`
+	var c config
+	if err := json.Unmarshal([]byte(data), &c); err == nil {
+		t.Errorf("json.Unmarshal(%s) = %s, want to fail due to unknown time unit", data, c.Mode)
+	}
+}
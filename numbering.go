@@ -0,0 +1,60 @@
+package datediff
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// NumberingSystem is a CLDR numbering system identifier, i.e "latn" or "arab".
+type NumberingSystem string
+
+// These are supported numbering systems.
+const (
+	NumberingLatin               NumberingSystem = "latn"    // 0123456789
+	NumberingArabicIndic         NumberingSystem = "arab"    // ٠١٢٣٤٥٦٧٨٩
+	NumberingExtendedArabicIndic NumberingSystem = "arabext" // ۰۱۲۳۴۵۶۷۸۹
+	NumberingDevanagari          NumberingSystem = "deva"    // ०१२३४५६७८९
+	NumberingBengali             NumberingSystem = "beng"    // ০১২৩৪৫৬৭৮৯
+	NumberingThai                NumberingSystem = "thai"    // ๐๑๒๓๔๕๖๗๘๙
+)
+
+var numberingZeros = map[NumberingSystem]rune{
+	NumberingLatin:               '0',
+	NumberingArabicIndic:         '٠',
+	NumberingExtendedArabicIndic: '۰',
+	NumberingDevanagari:          '०',
+	NumberingBengali:             '০',
+	NumberingThai:                '๐',
+}
+
+func (ns NumberingSystem) valid() bool {
+	_, ok := numberingZeros[ns]
+	return ok || ns == ""
+}
+
+// format returns the number n written with digits of the numbering system.
+// Latin digits are used when the numbering system is not defined.
+func (ns NumberingSystem) format(n int) string {
+	return string(ns.appendFormat(nil, n))
+}
+
+// appendFormat appends number n written in digits of the numbering system to
+// b.
+func (ns NumberingSystem) appendFormat(b []byte, n int) []byte {
+	zero, ok := numberingZeros[ns]
+	if !ok || zero == '0' {
+		return strconv.AppendInt(b, int64(n), 10)
+	}
+
+	var digits [20]byte
+	var buf [utf8.UTFMax]byte
+	for _, c := range strconv.AppendInt(digits[:0], int64(n), 10) {
+		if c < '0' || c > '9' {
+			b = append(b, c)
+			continue
+		}
+		size := utf8.EncodeRune(buf[:], zero+rune(c-'0'))
+		b = append(b, buf[:size]...)
+	}
+	return b
+}
@@ -0,0 +1,105 @@
+package datediff_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestFormatterNumbers(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2012, time.July, 24, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiffWithMode(start, end, datediff.ModeYears|datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	fa, err := datediff.LookupLocale("fa")
+	if err != nil {
+		t.Fatalf("LookupLocale(fa) failed: %v", err)
+	}
+
+	testCases := []struct {
+		desc      string
+		formatter datediff.Formatter
+		expected  string
+	}{
+		{
+			desc:      "locale numbering system",
+			formatter: datediff.Formatter{Locale: fa},
+			expected:  "۱۲ سال و ۳ ماه و ۷ روز",
+		},
+		{
+			desc:      "latin override",
+			formatter: datediff.Formatter{Locale: fa, Numbers: datediff.NumberingLatin},
+			expected:  "12 سال و 3 ماه و 7 روز",
+		},
+		{
+			desc:      "arabic-indic override",
+			formatter: datediff.Formatter{Numbers: datediff.NumberingArabicIndic},
+			expected:  "١٢ years ٣ months ٧ days",
+		},
+		{
+			desc:      "devanagari override",
+			formatter: datediff.Formatter{Numbers: datediff.NumberingDevanagari, Compact: true},
+			expected:  "१२y ३mo ७d",
+		},
+		{
+			desc:      "thai override",
+			formatter: datediff.Formatter{Numbers: datediff.NumberingThai, WithZeros: true},
+			expected:  "๑๒ years ๓ months ๗ days",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := tC.formatter.String(diff); got != tC.expected {
+				t.Errorf("String() = %s, want %s", got, tC.expected)
+			}
+		})
+	}
+
+	{
+		f := datediff.Formatter{Numbers: datediff.NumberingBengali}
+		expected := "১২ years ৩"
+		got, err := f.Format(diff, "%Y %m")
+		if err != nil {
+			t.Errorf("Format() failed: %v", err)
+		} else if got != expected {
+			t.Errorf("Format() = %s, want %s", got, expected)
+		}
+	}
+
+	{
+		f := datediff.Formatter{Numbers: "roman"}
+		expected := `unknown numbering system "roman"`
+		_, err := f.Format(diff, "%Y")
+		if err == nil {
+			t.Errorf("Format() want to fail due to %s", expected)
+		} else if err.Error() != expected {
+			t.Errorf("Format() failed: %v, want to fail due to %s", err, expected)
+		}
+	}
+
+	{
+		f := datediff.Formatter{Locale: fa, Numbers: "roman"}
+		expected := "12 سال و 3 ماه و 7 روز"
+		if got := f.String(diff); got != expected {
+			t.Errorf("String() with unknown numbering system = %s, want %s", got, expected)
+		}
+		if got := string(f.AppendString(nil, diff)); got != expected {
+			t.Errorf("AppendString() with unknown numbering system = %s, want %s", got, expected)
+		}
+	}
+}
+
+func TestLoadLocaleNumbersFails(t *testing.T) {
+	def := strings.Replace(localeYAML, "format:", "numbers: roman\nformat:", 1)
+	expected := `locale "pt" has unknown numbering system "roman"`
+	_, err := datediff.LoadLocale(strings.NewReader(def))
+	if err == nil {
+		t.Errorf("LoadLocale() want to fail due to %s", expected)
+	} else if err.Error() != expected {
+		t.Errorf("LoadLocale() failed: %v, want to fail due to %s", err, expected)
+	}
+}
@@ -0,0 +1,241 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+// Rounding defines how the smallest time unit of dates difference is rounded
+// when the dates range does not end at the unit boundary.
+type Rounding uint8
+
+// These are supported roundings.
+const (
+	// RoundingFloor counts only full time units, it's the default rounding.
+	RoundingFloor Rounding = iota
+	// RoundingCeil counts partial time unit as a full one.
+	RoundingCeil
+	// RoundingHalfUp counts partial time unit as a full one when it's at
+	// least a half of the unit.
+	RoundingHalfUp
+)
+
+// LeapDayPolicy defines when anniversaries of February 29 happen in common
+// years.
+type LeapDayPolicy uint8
+
+// These are supported leap day policies.
+const (
+	// LeapDayMarch1 moves anniversaries of February 29 to March 1 in common
+	// years, as time.Time.AddDate does. It's the default policy.
+	LeapDayMarch1 LeapDayPolicy = iota
+	// LeapDayFeb28 moves anniversaries of February 29 to February 28 in
+	// common years.
+	LeapDayFeb28
+)
+
+// ZonePolicy defines the location whose calendar defines day boundaries when
+// start and end dates are in different locations.
+type ZonePolicy uint8
+
+// These are supported zone policies.
+const (
+	// ZoneStart uses the location of the start date, the end date is
+	// converted to it. It's the default policy.
+	ZoneStart ZonePolicy = iota
+	// ZoneEnd uses the location of the end date, the start date is
+	// converted to it.
+	ZoneEnd
+	// ZoneFixed uses the location of WithLocation option, both dates are
+	// converted to it. UTC is used when the location is not provided.
+	ZoneFixed
+)
+
+var zonePolicyNames = [...]string{
+	ZoneStart: "start",
+	ZoneEnd:   "end",
+	ZoneFixed: "fixed",
+}
+
+// String returns the name of the zone policy, i.e "start".
+func (p ZonePolicy) String() string {
+	if int(p) < len(zonePolicyNames) {
+		return zonePolicyNames[p]
+	}
+	return fmt.Sprintf("ZonePolicy(%d)", p)
+}
+
+// Option configures calculation of dates difference.
+type Option func(*options)
+
+type options struct {
+	inclusiveEnd bool
+	location     *time.Location
+	locale       *Locale
+	rounding     Rounding
+	leapDay      LeapDayPolicy
+	calendar     Calendar
+	weekStart    time.Weekday
+	exclusions   []Period
+	zone         ZonePolicy
+}
+
+// WithInclusiveEnd includes the end date in dates difference, i.e dates
+// difference between January 1 and January 31 is 31 days instead of 30.
+// Diff.End returns the provided end date, Duration and Compare of dates
+// difference include the end date.
+func WithInclusiveEnd() Option {
+	return func(o *options) {
+		o.inclusiveEnd = true
+	}
+}
+
+// WithLocation converts start and end dates to the location before the
+// calculation, so that dates boundaries of the location are used. It sets
+// ZoneFixed zone policy.
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+		o.zone = ZoneFixed
+	}
+}
+
+// WithZonePolicy sets the location whose calendar defines day boundaries
+// when start and end dates are in different locations, i.e 20:00 of January
+// 31 in London is February 1 in Sydney, so there is 1 month to March 1 in
+// Sydney only when Sydney midnight is used. The policy is reported by
+// Diff.ZonePolicy.
+func WithZonePolicy(p ZonePolicy) Option {
+	return func(o *options) {
+		o.zone = p
+	}
+}
+
+// WithLocale sets the locale used by String and StringWithZeros of dates
+// difference.
+func WithLocale(l *Locale) Option {
+	return func(o *options) {
+		o.locale = l
+	}
+}
+
+// WithRounding sets rounding of the smallest time unit of dates difference.
+func WithRounding(r Rounding) Option {
+	return func(o *options) {
+		o.rounding = r
+	}
+}
+
+// WithLeapDayPolicy sets when anniversaries of February 29 start date happen
+// in common years. The policy affects years and months, days are counted as
+// they are.
+func WithLeapDayPolicy(p LeapDayPolicy) Option {
+	return func(o *options) {
+		o.leapDay = p
+	}
+}
+
+// WithWeekStart sets the first day of the week used by calendar weeks, i.e
+// Sunday in the US. Weeks start on Monday by default, as ISO 8601 defines.
+// It affects CalendarWeeks and EachWeek, dates differences in weeks are
+// counted from the start date regardless of the day of the week.
+func WithWeekStart(d time.Weekday) Option {
+	return func(o *options) {
+		o.weekStart = d % daysInWeek
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{weekStart: time.Monday}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.calendar == nil {
+		o.calendar = GregorianCalendar{}
+	}
+	return o
+}
+
+// dates returns start and end dates adjusted according to the options.
+func (o options) dates(start, end time.Time) (time.Time, time.Time) {
+	start, end = o.zoneDates(start, end)
+	return start, o.rangeEnd(end)
+}
+
+// zoneDates returns start and end dates converted according to the zone
+// policy.
+func (o options) zoneDates(start, end time.Time) (time.Time, time.Time) {
+	switch o.zone {
+	case ZoneEnd:
+		start = start.In(end.Location())
+	case ZoneFixed:
+		loc := o.location
+		if loc == nil {
+			loc = time.UTC
+		}
+		start, end = start.In(loc), end.In(loc)
+	}
+	return start, end
+}
+
+// rangeEnd returns the end of the range of dates, it's the day after the end
+// date when the end date is included.
+func (o options) rangeEnd(end time.Time) time.Time {
+	if o.inclusiveEnd {
+		return end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// leapDayEnd returns the end date used to calculate dates difference in the
+// mode according to the leap day policy. With LeapDayFeb28 policy February 28
+// of a common year is the anniversary of February 29, so it's calculated as
+// March 1, the anniversary of the default policy.
+func (o options) leapDayEnd(start, end time.Time, mode DiffMode) time.Time {
+	if o.leapDay != LeapDayFeb28 || mode&(ModeYears|ModeMonths) == 0 {
+		return end
+	}
+	if start.Month() != time.February || start.Day() != 29 {
+		return end
+	}
+	if end.Month() != time.February || end.Day() != 28 || isLeapYear(end.Year()) {
+		return end
+	}
+	return end.AddDate(0, 0, 1)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// round rounds the smallest time unit of the mode of dates difference
+// calculated between start and end dates. Overflowed time units are
+// normalized after rounding.
+func (o options) round(d Diff, start, end time.Time) Diff {
+	if o.rounding == RoundingFloor {
+		return d
+	}
+	var unit DiffMode
+	for _, u := range diffUnits {
+		if d.mode&u != 0 {
+			unit = u
+		}
+	}
+	if unit == 0 {
+		return d
+	}
+
+	from := addCalendarDiff(o.calendar, start, d, 1)
+	if !from.Before(end) {
+		return d
+	}
+	next := d
+	next.setValue(unit, d.value(unit)+1)
+	if o.rounding == RoundingHalfUp {
+		to := addCalendarDiff(o.calendar, start, next, 1)
+		if end.Sub(from) < to.Sub(end) {
+			return d
+		}
+	}
+	return next.Normalize()
+}
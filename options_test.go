@@ -0,0 +1,170 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestNewDiffWithOptions(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	de, err := datediff.LookupLocale("de")
+	if err != nil {
+		t.Fatalf("LookupLocale(de) failed: %v", err)
+	}
+	east := time.FixedZone("UTC+2", 2*60*60)
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		format   string
+		opts     []datediff.Option
+		expected string
+	}{
+		{
+			desc:     "no options",
+			start:    date(2000, time.January, 1),
+			end:      date(2000, time.January, 31),
+			format:   "%D",
+			expected: "30 days",
+		},
+		{
+			desc:     "inclusive end",
+			start:    date(2000, time.January, 1),
+			end:      date(2000, time.January, 31),
+			format:   "%D",
+			opts:     []datediff.Option{datediff.WithInclusiveEnd()},
+			expected: "31 days",
+		},
+		{
+			desc:     "ceil rounding",
+			start:    date(2000, time.January, 1),
+			end:      date(2000, time.February, 10),
+			format:   "%M",
+			opts:     []datediff.Option{datediff.WithRounding(datediff.RoundingCeil)},
+			expected: "2 months",
+		},
+		{
+			desc:     "ceil rounding of full units",
+			start:    date(2000, time.January, 1),
+			end:      date(2000, time.March, 1),
+			format:   "%M",
+			opts:     []datediff.Option{datediff.WithRounding(datediff.RoundingCeil)},
+			expected: "2 months",
+		},
+		{
+			desc:     "ceil rounding with normalization",
+			start:    date(2000, time.January, 1),
+			end:      date(2000, time.December, 15),
+			format:   "%Y %M",
+			opts:     []datediff.Option{datediff.WithRounding(datediff.RoundingCeil)},
+			expected: "1 year",
+		},
+		{
+			desc:     "half up rounding down",
+			start:    date(2000, time.January, 1),
+			end:      date(2000, time.February, 10),
+			format:   "%M",
+			opts:     []datediff.Option{datediff.WithRounding(datediff.RoundingHalfUp)},
+			expected: "1 month",
+		},
+		{
+			desc:     "half up rounding up",
+			start:    date(2000, time.January, 1),
+			end:      date(2000, time.February, 20),
+			format:   "%M",
+			opts:     []datediff.Option{datediff.WithRounding(datediff.RoundingHalfUp)},
+			expected: "2 months",
+		},
+		{
+			desc:     "location",
+			start:    time.Date(2000, time.January, 31, 22, 0, 0, 0, time.UTC),
+			end:      time.Date(2000, time.February, 29, 22, 0, 0, 0, time.UTC),
+			format:   "%M",
+			opts:     []datediff.Option{datediff.WithLocation(east)},
+			expected: "1 month",
+		},
+		{
+			desc:     "locale",
+			start:    date(2000, time.January, 1),
+			end:      date(2003, time.January, 1),
+			format:   "%Y",
+			opts:     []datediff.Option{datediff.WithLocale(de)},
+			expected: "3 Jahre",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := datediff.NewDiff(tC.start, tC.end, tC.format, tC.opts...)
+			if err != nil {
+				t.Fatalf("NewDiff() failed: %v", err)
+			}
+			if got.String() != tC.expected {
+				t.Errorf("NewDiff() String() = %s, want %s", got.String(), tC.expected)
+			}
+		})
+	}
+}
+
+func TestNewDiffWithModeOptions(t *testing.T) {
+	start := time.Date(2000, time.January, 31, 22, 0, 0, 0, time.UTC)
+	end := time.Date(2000, time.February, 29, 22, 0, 0, 0, time.UTC)
+
+	got, err := datediff.NewDiffWithMode(start, end, datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	if expected := "29 days"; got.String() != expected {
+		t.Errorf("NewDiffWithMode() String() = %s, want %s", got.String(), expected)
+	}
+
+	east := time.FixedZone("UTC+2", 2*60*60)
+	got, err = datediff.NewDiffWithMode(start, end, datediff.ModeMonths|datediff.ModeDays, datediff.WithLocation(east))
+	if err != nil {
+		t.Fatalf("NewDiffWithMode() failed: %v", err)
+	}
+	if expected := "1 month"; got.String() != expected {
+		t.Errorf("NewDiffWithMode() String() = %s, want %s", got.String(), expected)
+	}
+	if got.Start().Location() != east {
+		t.Errorf("NewDiffWithMode() Start() location = %s, want %s", got.Start().Location(), east)
+	}
+}
+
+func TestWithZonePolicy(t *testing.T) {
+	london := time.UTC
+	sydney := time.FixedZone("AEDT", 11*60*60)
+	// February 1, 07:00 and March 1, 07:00 in Sydney
+	start := time.Date(2024, time.January, 31, 20, 0, 0, 0, london)
+	end := time.Date(2024, time.March, 1, 7, 0, 0, 0, sydney)
+
+	testCases := []struct {
+		desc     string
+		opts     []datediff.Option
+		expected datediff.Diff
+		policy   datediff.ZonePolicy
+	}{
+		{desc: "default", expected: datediff.Diff{Days: 29}, policy: datediff.ZoneStart},
+		{desc: "start", opts: []datediff.Option{datediff.WithZonePolicy(datediff.ZoneStart)}, expected: datediff.Diff{Days: 29}, policy: datediff.ZoneStart},
+		{desc: "end", opts: []datediff.Option{datediff.WithZonePolicy(datediff.ZoneEnd)}, expected: datediff.Diff{Months: 1}, policy: datediff.ZoneEnd},
+		{desc: "fixed", opts: []datediff.Option{datediff.WithLocation(sydney)}, expected: datediff.Diff{Months: 1}, policy: datediff.ZoneFixed},
+		{desc: "fixed without location", opts: []datediff.Option{datediff.WithZonePolicy(datediff.ZoneFixed)}, expected: datediff.Diff{Days: 29}, policy: datediff.ZoneFixed},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.NewDiffWithMode(start, end, datediff.ModeMonths|datediff.ModeDays, tC.opts...)
+		if err != nil {
+			t.Errorf("NewDiffWithMode() %s failed: %v", tC.desc, err)
+			continue
+		}
+		if !got.Equal(tC.expected) {
+			t.Errorf("NewDiffWithMode() %s = %v, want %v", tC.desc, got, tC.expected)
+		}
+		if got.ZonePolicy() != tC.policy {
+			t.Errorf("ZonePolicy() %s = %s, want %s", tC.desc, got.ZonePolicy(), tC.policy)
+		}
+	}
+}
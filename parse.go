@@ -0,0 +1,160 @@
+package datediff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// unitModes maps locale units to dates difference modes.
+var unitModes = map[string]DiffMode{
+	"year":  ModeYears,
+	"month": ModeMonths,
+	"week":  ModeWeeks,
+	"day":   ModeDays,
+}
+
+// unitWord is the time unit name that follows the number in locale patterns.
+type unitWord struct {
+	word string
+	unit string
+}
+
+// ParseDiff parses dates difference written in English, i.e
+// "2 years 3 months and 4 days" or "remind me in 2 weeks 3 days". See
+// Locale.ParseDiff for details.
+func ParseDiff(s string) (Diff, error) {
+	return english.ParseDiff(s)
+}
+
+// ParseDiff parses dates difference written in the locale language. Numbers
+// followed by names of time units from units, abbreviations or relative
+// units patterns of the locale are recognized, the rest of the text is
+// ignored. Time unit names are case insensitive. Numbers can be written in
+// Latin digits or digits of the locale numbering system. The mode of the
+// parsed dates difference is defined by the found time units.
+func (l *Locale) ParseDiff(s string) (Diff, error) {
+	words := l.unitWords()
+
+	var diff Diff
+	for i := 0; i < len(s); {
+		n, size := parseNumber(s[i:], l.Numbers)
+		if size == 0 {
+			_, rs := utf8.DecodeRuneInString(s[i:])
+			i += rs
+			continue
+		}
+		i += size
+
+		rest := strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+		w, ok := matchUnitWord(rest, words)
+		if !ok {
+			return Diff{}, fmt.Errorf("diff %q has number without time unit", s)
+		}
+		mode := unitModes[w.unit]
+		if diff.mode&mode != 0 {
+			return Diff{}, fmt.Errorf("diff %q has repeated time unit %s", s, w.unit)
+		}
+		num, err := strconv.Atoi(n)
+		if err != nil {
+			return Diff{}, fmt.Errorf("diff %q has invalid number %s", s, n)
+		}
+		diff.mode |= mode
+		diff.setValue(mode, num)
+		i = len(s) - len(rest) + len(w.word)
+	}
+
+	if diff.mode == 0 {
+		return Diff{}, fmt.Errorf("diff %q has no time units", s)
+	}
+	return diff, nil
+}
+
+// unitWords returns lower cased names of time units that follow the number
+// placeholder in the locale patterns. Longer names go first, so that "months"
+// is matched before "mo".
+func (l *Locale) unitWords() []unitWord {
+	seen := make(map[string]bool)
+	var words []unitWord
+	for _, units := range []map[string]map[PluralCategory]string{l.Units, l.Abbreviations, l.Relative.Units} {
+		// units are processed in the order of significance, so that the
+		// word shared by time units always belongs to the same one
+		for _, unit := range localeUnits {
+			for _, p := range units[unit] {
+				if !strings.HasPrefix(p, numberPlaceholder) {
+					continue
+				}
+				w := strings.ToLower(strings.TrimSpace(p[len(numberPlaceholder):]))
+				if w == "" || seen[w] {
+					continue
+				}
+				seen[w] = true
+				words = append(words, unitWord{word: w, unit: unit})
+			}
+		}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i].word) != len(words[j].word) {
+			return len(words[i].word) > len(words[j].word)
+		}
+		return words[i].word < words[j].word
+	})
+	return words
+}
+
+// matchUnitWord returns the time unit name s starts with. The name should
+// not be followed by a letter.
+func matchUnitWord(s string, words []unitWord) (unitWord, bool) {
+	for _, w := range words {
+		if len(s) < len(w.word) || !strings.EqualFold(s[:len(w.word)], w.word) {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(s[len(w.word):]); unicode.IsLetter(r) {
+			continue
+		}
+		return w, true
+	}
+	return unitWord{}, false
+}
+
+// parseNumber returns the number s starts with written in Latin digits, and
+// the number of bytes it takes in s. The number can have leading minus sign
+// and can be written in digits of the numbering system.
+func parseNumber(s string, ns NumberingSystem) (string, int) {
+	var b strings.Builder
+	i := 0
+	if strings.HasPrefix(s, "-") {
+		b.WriteByte('-')
+		i++
+	}
+	digits := 0
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		d, ok := digitValue(r, ns)
+		if !ok {
+			break
+		}
+		b.WriteByte('0' + d)
+		i += size
+		digits++
+	}
+	if digits == 0 {
+		return "", 0
+	}
+	return b.String(), i
+}
+
+// digitValue returns the value of Latin digit or digit of the numbering
+// system.
+func digitValue(r rune, ns NumberingSystem) (byte, bool) {
+	if r >= '0' && r <= '9' {
+		return byte(r - '0'), true
+	}
+	if zero, ok := numberingZeros[ns]; ok && r >= zero && r <= zero+9 {
+		return byte(r - zero), true
+	}
+	return 0, false
+}
@@ -0,0 +1,120 @@
+package datediff_test
+
+import (
+	"testing"
+
+	"github.com/antklim/datediff"
+)
+
+func TestParseDiff(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected datediff.Diff
+		print    string
+	}{
+		{
+			s:        "2 years 3 months and 4 days",
+			expected: datediff.Diff{Years: 2, Months: 3, Days: 4},
+			print:    "2 years 3 months 4 days",
+		},
+		{
+			s:        "remind me in 2 weeks 3 days",
+			expected: datediff.Diff{Weeks: 2, Days: 3},
+			print:    "2 weeks 3 days",
+		},
+		{
+			s:        "1 Year, 0 months",
+			expected: datediff.Diff{Years: 1},
+			print:    "1 year",
+		},
+		{
+			s:        "2y 3mo 1w",
+			expected: datediff.Diff{Years: 2, Months: 3, Weeks: 1},
+			print:    "2 years 3 months 1 week",
+		},
+		{
+			s:        "-5 days",
+			expected: datediff.Diff{Days: -5},
+			print:    "-5 days",
+		},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseDiff(tC.s)
+		if err != nil {
+			t.Errorf("ParseDiff(%s) failed: %v", tC.s, err)
+			continue
+		}
+		if !got.Equal(tC.expected) {
+			t.Errorf("ParseDiff(%s) = %#v, want %#v", tC.s, got, tC.expected)
+		}
+		if got.String() != tC.print {
+			t.Errorf("ParseDiff(%s) String() = %s, want %s", tC.s, got.String(), tC.print)
+		}
+	}
+}
+
+func TestLocaleParseDiff(t *testing.T) {
+	testCases := []struct {
+		locale   string
+		s        string
+		expected datediff.Diff
+	}{
+		{locale: "de", s: "vor 2 Jahren und 1 Monat", expected: datediff.Diff{Years: 2, Months: 1}},
+		{locale: "ja", s: "2年3か月", expected: datediff.Diff{Years: 2, Months: 3}},
+		{locale: "fa", s: "۲ سال و ۳ روز", expected: datediff.Diff{Years: 2, Days: 3}},
+		{locale: "ru", s: "5 лет 2 месяца", expected: datediff.Diff{Years: 5, Months: 2}},
+	}
+	for _, tC := range testCases {
+		l, err := datediff.LookupLocale(tC.locale)
+		if err != nil {
+			t.Fatalf("LookupLocale(%s) failed: %v", tC.locale, err)
+		}
+		got, err := l.ParseDiff(tC.s)
+		if err != nil {
+			t.Errorf("ParseDiff(%s) failed: %v", tC.s, err)
+		} else if !got.Equal(tC.expected) {
+			t.Errorf("ParseDiff(%s) = %#v, want %#v", tC.s, got, tC.expected)
+		}
+	}
+}
+
+func TestParseDiffFails(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected string
+	}{
+		{s: "", expected: `diff "" has no time units`},
+		{s: "soon", expected: `diff "soon" has no time units`},
+		{s: "2 hours", expected: `diff "2 hours" has number without time unit`},
+		{s: "2 days 3 days", expected: `diff "2 days 3 days" has repeated time unit day`},
+		{s: "2 dayz", expected: `diff "2 dayz" has number without time unit`},
+		{s: "99999999999999999999 days", expected: `diff "99999999999999999999 days" has invalid number 99999999999999999999`},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParseDiff(tC.s)
+		if err == nil {
+			t.Errorf("ParseDiff(%s) = %#v, want to fail due to %s", tC.s, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParseDiff(%s) failed: %v, want to fail due to %s", tC.s, err, tC.expected)
+		}
+	}
+}
+
+func TestParseDiffSharedUnitWord(t *testing.T) {
+	l := &datediff.Locale{Name: "xx", Units: map[string]map[datediff.PluralCategory]string{
+		"year":  {datediff.PluralOther: "{0} y"},
+		"month": {datediff.PluralOther: "{0} m"},
+		"week":  {datediff.PluralOther: "{0} t"},
+		"day":   {datediff.PluralOther: "{0} t"},
+	}}
+	// the shared word belongs to the most significant time unit
+	for i := 0; i < 20; i++ {
+		got, err := l.ParseDiff("3 t")
+		if err != nil {
+			t.Fatalf("ParseDiff(3 t) failed: %v", err)
+		}
+		if want := (datediff.Diff{Weeks: 3}); !got.Equal(want) || got.Mode() != datediff.ModeWeeks {
+			t.Fatalf("ParseDiff(3 t) = %#v, want %#v", got, want)
+		}
+	}
+}
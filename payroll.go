@@ -0,0 +1,127 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+// semiMonthlyDays is the number of days from the first pay period of the
+// month to the second one of semi-monthly pay schedules.
+const semiMonthlyDays = 15
+
+// PayFrequency defines how often pay periods start.
+type PayFrequency uint8
+
+// These are supported pay frequencies.
+const (
+	// PayWeekly starts pay periods every week.
+	PayWeekly PayFrequency = iota
+	// PayFortnightly starts pay periods every two weeks.
+	PayFortnightly
+	// PaySemiMonthly starts pay periods twice a month, on the day of the
+	// anchor date and 15 days later, i.e on the 1st and the 16th.
+	PaySemiMonthly
+	// PayMonthly starts pay periods every month on the day of the anchor
+	// date.
+	PayMonthly
+)
+
+// PaySchedule defines pay periods by the frequency and the anchor date, that
+// is the start of any pay period of the schedule.
+type PaySchedule struct {
+	frequency PayFrequency
+	anchor    civilDate
+}
+
+// NewPaySchedule creates pay schedule with pay periods that start on the
+// anchor date, i.e the first pay day of the year. Pay periods are whole days,
+// the time of the anchor date is ignored. Monthly periods are calculated by
+// adding months to the anchor date, so the day overflow is normalized as
+// time.Time.AddDate does, i.e monthly periods of January 31 start on March 3
+// (or 2), March 31, May 1, and so on.
+//
+// NewPaySchedule returns error when the pay frequency is unknown.
+func NewPaySchedule(frequency PayFrequency, anchor time.Time) (PaySchedule, error) {
+	if frequency > PayMonthly {
+		return PaySchedule{}, fmt.Errorf("unknown pay frequency %d", frequency)
+	}
+	return PaySchedule{frequency: frequency, anchor: newCivilDate(anchor)}, nil
+}
+
+// Periods returns the number of complete pay periods between dates and the
+// remainder in days, that are days before the first complete period and after
+// the last one. Dates are converted to days as AccrualDays does.
+//
+// Periods returns error when start date is after end date.
+func (s PaySchedule) Periods(start, end time.Time) (int, Diff, error) {
+	if start.After(end) {
+		return 0, Diff{}, ErrStartAfterEnd
+	}
+	from := newCivilDate(start).days
+	to := newCivilDate(end.In(start.Location())).days
+	periods, remainder := countPeriods(from, to, s.estimate(from), s.periodStart)
+	return periods, Diff{Days: int(remainder), mode: ModeDays}, nil
+}
+
+// countPeriods returns the number of complete periods between day numbers
+// and the number of remaining days before the first complete period and after
+// the last one. Periods start on days returned by periodStart, k is the number
+// of the period that starts close to the first day.
+func countPeriods(from, to int64, k int, periodStart func(k int) int64) (int, int64) {
+	for periodStart(k) > from {
+		k--
+	}
+	for periodStart(k) < from {
+		k++
+	}
+	first, last, periods := periodStart(k), periodStart(k), 0
+	for next := periodStart(k + 1); next <= to; next = periodStart(k + 1) {
+		last = next
+		periods++
+		k++
+	}
+
+	remainder := to - from
+	if periods > 0 {
+		remainder -= last - first
+	}
+	return periods, remainder
+}
+
+// estimate returns the number of the pay period close to the day.
+func (s PaySchedule) estimate(day int64) int {
+	switch s.frequency {
+	case PayWeekly:
+		return int((day - s.anchor.days) / daysInWeek)
+	case PayFortnightly:
+		return int((day - s.anchor.days) / (2 * daysInWeek))
+	}
+	year, month, _ := civilFromDays(day)
+	months := (year-s.anchor.year)*monthsInYear + int(month) - s.anchor.month
+	if s.frequency == PaySemiMonthly {
+		return 2 * months
+	}
+	return months
+}
+
+// periodStart returns the day the pay period starts on, the period 0 starts
+// on the anchor date.
+func (s PaySchedule) periodStart(k int) int64 {
+	switch s.frequency {
+	case PayWeekly:
+		return s.anchor.days + int64(k)*daysInWeek
+	case PayFortnightly:
+		return s.anchor.days + int64(k)*2*daysInWeek
+	}
+	months, days := k, 0
+	if s.frequency == PaySemiMonthly {
+		months, days = k/2, k%2
+		if days < 0 {
+			months--
+			days += 2
+		}
+		days *= semiMonthlyDays
+	}
+	t := time.Date(s.anchor.year, time.Month(s.anchor.month+months), s.anchor.day, 0, 0, 0, 0, time.UTC)
+	return newCivilDate(t).days + int64(days)
+}
@@ -0,0 +1,100 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestPaySchedulePeriods(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		frequency datediff.PayFrequency
+		anchor    time.Time
+		start     time.Time
+		end       time.Time
+		periods   int
+		remainder int
+	}{
+		{
+			desc:      "weekly",
+			frequency: datediff.PayWeekly,
+			anchor:    date(2024, time.January, 1), // Monday
+			start:     date(2024, time.January, 3),
+			end:       date(2024, time.January, 31),
+			periods:   3,
+			remainder: 7,
+		},
+		{
+			desc:      "weekly with anchor after dates",
+			frequency: datediff.PayWeekly,
+			anchor:    date(2030, time.January, 7), // Monday
+			start:     date(2024, time.January, 3),
+			end:       date(2024, time.January, 31),
+			periods:   3,
+			remainder: 7,
+		},
+		{
+			desc:      "fortnightly",
+			frequency: datediff.PayFortnightly,
+			anchor:    date(2024, time.January, 1),
+			start:     date(2024, time.January, 3),
+			end:       date(2024, time.February, 12),
+			periods:   2,
+			remainder: 12,
+		},
+		{
+			desc:      "semi-monthly",
+			frequency: datediff.PaySemiMonthly,
+			anchor:    date(2024, time.January, 1),
+			start:     date(2024, time.January, 10),
+			end:       date(2024, time.March, 5),
+			periods:   3,
+			remainder: 10,
+		},
+		{
+			desc:      "monthly",
+			frequency: datediff.PayMonthly,
+			anchor:    date(2023, time.January, 15),
+			start:     date(2024, time.January, 15),
+			end:       date(2024, time.April, 15),
+			periods:   3,
+		},
+		{
+			desc:      "no complete periods",
+			frequency: datediff.PayMonthly,
+			anchor:    date(2024, time.January, 15),
+			start:     date(2024, time.January, 1),
+			end:       date(2024, time.January, 31),
+			remainder: 30,
+		},
+	}
+	for _, tC := range testCases {
+		s, err := datediff.NewPaySchedule(tC.frequency, tC.anchor)
+		if err != nil {
+			t.Fatalf("NewPaySchedule() %s failed: %v", tC.desc, err)
+		}
+		periods, remainder, err := s.Periods(tC.start, tC.end)
+		if err != nil {
+			t.Errorf("Periods() %s failed: %v", tC.desc, err)
+			continue
+		}
+		if periods != tC.periods || !remainder.Equal(datediff.Diff{Days: tC.remainder}) {
+			t.Errorf("Periods() %s = %d, %v, want %d, %d days", tC.desc, periods, remainder, tC.periods, tC.remainder)
+		}
+	}
+}
+
+func TestPayScheduleFails(t *testing.T) {
+	if _, err := datediff.NewPaySchedule(datediff.PayFrequency(10), time.Now()); err == nil {
+		t.Errorf("want to fail due to unknown pay frequency")
+	} else if want := "unknown pay frequency 10"; err.Error() != want {
+		t.Errorf("NewPaySchedule() error = %v, want %s", err, want)
+	}
+
+	s, _ := datediff.NewPaySchedule(datediff.PayWeekly, time.Now())
+	if _, _, err := s.Periods(time.Now(), time.Now().Add(-time.Hour)); err != datediff.ErrStartAfterEnd {
+		t.Errorf("Periods() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+}
@@ -0,0 +1,67 @@
+package datediff
+
+import "time"
+
+// Period is the range of dates with the dates difference between them.
+type Period struct {
+	Start time.Time
+	End   time.Time
+	Diff  Diff
+}
+
+// NewPeriod creates Period of the dates. The dates difference is calculated
+// according to the provided format as NewDiff does. Start and end dates of the
+// period are adjusted by options, i.e WithInclusiveEnd moves the end date.
+func NewPeriod(start, end time.Time, rawFormat string, opts ...Option) (Period, error) {
+	diff, err := NewDiff(start, end, rawFormat, opts...)
+	if err != nil {
+		return Period{}, err
+	}
+	return Period{Start: diff.start, End: diff.rangeEnd(), Diff: diff}, nil
+}
+
+// NewPeriodWithMode creates Period of the dates. The dates difference is
+// calculated according to the provided mode as NewDiffWithMode does.
+func NewPeriodWithMode(start, end time.Time, mode DiffMode, opts ...Option) (Period, error) {
+	diff, err := NewDiffWithMode(start, end, mode, opts...)
+	if err != nil {
+		return Period{}, err
+	}
+	return Period{Start: diff.start, End: diff.rangeEnd(), Diff: diff}, nil
+}
+
+// Contains returns true when the date is within the period. The start date
+// is included in the period, the end date is not.
+func (p Period) Contains(t time.Time) bool {
+	return contains(p.Start, p.End, t, false)
+}
+
+// Duration returns the exact duration of the period.
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// Shift moves start and end dates of the period by the dates difference. The
+// dates difference of the shifted period is recalculated in the same time
+// units and keeps the format, since months and years have different lengths.
+// Shift returns ErrStartAfterEnd when the days overflowing the month move the
+// start date after the end date, i.e January 31 - February 1 shifted by a month.
+func (p Period) Shift(d Diff) (Period, error) {
+	start := addDiff(p.Start, d, 1)
+	end := addDiff(p.End, d, 1)
+	if start.After(end) {
+		return Period{}, ErrStartAfterEnd
+	}
+	diff := newDiff(start, end, p.Diff.mode)
+	diff.rawFormat = p.Diff.rawFormat
+	return Period{Start: start, End: end, Diff: diff}, nil
+}
+
+// contains returns true when the date is within the range of start and end
+// dates. The end date is included when inclusive is true.
+func contains(start, end, t time.Time, inclusive bool) bool {
+	if t.Before(start) {
+		return false
+	}
+	return t.Before(end) || (inclusive && t.Equal(end))
+}
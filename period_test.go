@@ -0,0 +1,94 @@
+package datediff_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestPeriod(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	p, err := datediff.NewPeriod(start, end, "%Y and %M")
+	if err != nil {
+		t.Fatalf("NewPeriod() failed: %v", err)
+	}
+	if expected := "3 years and 1 month"; p.Diff.String() != expected {
+		t.Errorf("NewPeriod() Diff = %s, want %s", p.Diff, expected)
+	}
+	if expected := 1126 * 24 * time.Hour; p.Duration() != expected {
+		t.Errorf("Duration() = %v, want %v", p.Duration(), expected)
+	}
+
+	testCases := []struct {
+		date     time.Time
+		expected bool
+	}{
+		{date: start.AddDate(0, 0, -1), expected: false},
+		{date: start, expected: true},
+		{date: start.AddDate(1, 0, 0), expected: true},
+		{date: end.Add(-time.Nanosecond), expected: true},
+		{date: end, expected: false},
+	}
+	for _, tC := range testCases {
+		if got := p.Contains(tC.date); got != tC.expected {
+			t.Errorf("Contains(%s) = %t, want %t", tC.date, got, tC.expected)
+		}
+	}
+}
+
+func TestPeriodShift(t *testing.T) {
+	start := time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2000, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	p, err := datediff.NewPeriodWithMode(start, end, datediff.ModeMonths|datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewPeriodWithMode() failed: %v", err)
+	}
+
+	got, err := p.Shift(datediff.Diff{Months: 1})
+	if err != nil {
+		t.Fatalf("Shift() failed: %v", err)
+	}
+	expectedStart := time.Date(2000, time.March, 2, 0, 0, 0, 0, time.UTC)
+	expectedEnd := time.Date(2000, time.May, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Start.Equal(expectedStart) || !got.End.Equal(expectedEnd) {
+		t.Errorf("Shift() = %s - %s, want %s - %s", got.Start, got.End, expectedStart, expectedEnd)
+	}
+	if expected := "1 month 29 days"; got.Diff.String() != expected {
+		t.Errorf("Shift() Diff = %s, want %s", got.Diff, expected)
+	}
+}
+
+func TestPeriodShiftFails(t *testing.T) {
+	start := time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2000, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	p, err := datediff.NewPeriodWithMode(start, end, datediff.ModeDays)
+	if err != nil {
+		t.Fatalf("NewPeriodWithMode() failed: %v", err)
+	}
+
+	// January 31 + 1 month is March 2, that is after March 1.
+	if got, err := p.Shift(datediff.Diff{Months: 1}); !errors.Is(err, datediff.ErrStartAfterEnd) {
+		t.Errorf("Shift() = %s - %s, %v, want to fail due to %s", got.Start, got.End, err, datediff.ErrStartAfterEnd)
+	}
+}
+
+func TestNewPeriodFails(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	if _, err := datediff.NewPeriod(end, start, "%Y"); err == nil || err.Error() != "start date is after end date" {
+		t.Errorf("NewPeriod() error = %v, want to fail due to start date is after end date", err)
+	}
+	if _, err := datediff.NewPeriod(start, end, "%X"); err == nil || err.Error() != `format "%X" has unknown verb X` {
+		t.Errorf("NewPeriod() error = %v, want to fail due to unknown verb", err)
+	}
+	if _, err := datediff.NewPeriodWithMode(end, start, datediff.ModeDays); err == nil {
+		t.Errorf("NewPeriodWithMode() want to fail due to start date is after end date")
+	}
+}
@@ -0,0 +1,116 @@
+package datediff
+
+import "time"
+
+// persianBreaks are years of the Persian calendar when the 33 years leap
+// cycle is broken, by the algorithm of Kazimierz Borkowski.
+var persianBreaks = []int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+// PersianDate is the date of the Persian (Jalali) calendar, i.e 1403-01-01
+// is Nowruz of March 20, 2024.
+type PersianDate struct {
+	Year  int
+	Month int // month of the year, 1 is Farvardin and 12 is Esfand
+	Day   int
+}
+
+// PersianDateOf returns the Persian calendar date of the date in its
+// location.
+func PersianDateOf(t time.Time) PersianDate {
+	year, month, day := PersianCalendar{}.date(jdnOf(t))
+	return PersianDate{Year: year, Month: month, Day: day}
+}
+
+// PersianCalendar is the Persian (Jalali) solar calendar, the official
+// calendar of Iran and Afghanistan. Years start on Nowruz, the first day of
+// Farvardin near the March equinox. The first 6 months have 31 days, the
+// next 5 months have 30 days, and Esfand has 29 days or 30 days in leap
+// years. Leap years are calculated by the arithmetic algorithm of Kazimierz
+// Borkowski that matches the astronomical calendar for years from 1 to 3177.
+// The day overflow is normalized, i.e Shahrivar 31 plus 1 month is Mehr 30
+// plus 1 day.
+type PersianCalendar struct{}
+
+// AddYears implements Calendar interface.
+func (c PersianCalendar) AddYears(t time.Time, n int) time.Time {
+	return addMonthsCalendar(c, t, n, 0)
+}
+
+// AddMonths implements Calendar interface.
+func (c PersianCalendar) AddMonths(t time.Time, n int) time.Time {
+	return addMonthsCalendar(c, t, 0, n)
+}
+
+// AddDays implements Calendar interface.
+func (PersianCalendar) AddDays(t time.Time, n int) time.Time {
+	return t.AddDate(0, 0, n)
+}
+
+// Compare implements Calendar interface.
+func (PersianCalendar) Compare(a, b time.Time) int {
+	return GregorianCalendar{}.Compare(a, b)
+}
+
+func (PersianCalendar) monthsInYear() int {
+	return monthsInYear
+}
+
+func (PersianCalendar) jdn(year, month, day int) int64 {
+	_, march := persianYear(year)
+	days := (month-1)*31 - month/7*(month-7) + day - 1
+	return civilDays(year+621, time.March, march) + jdnOffset + int64(days)
+}
+
+func (PersianCalendar) date(jdn int64) (year, month, day int) {
+	gy, _, _ := civilFromDays(jdn - jdnOffset)
+	year = gy - 621
+	leap, march := persianYear(year)
+	k := int(jdn - civilDays(gy, time.March, march) - jdnOffset)
+	if k >= 0 {
+		if k <= 185 {
+			return year, 1 + k/31, k%31 + 1
+		}
+		k -= 186
+	} else {
+		year--
+		k += 179
+		if leap == 1 {
+			k++
+		}
+	}
+	return year, 7 + k/30, k%30 + 1
+}
+
+// persianYear returns the number of years since the last leap year, 0 for
+// leap years, and the day of March of the Gregorian year the Persian year
+// starts on.
+func persianYear(year int) (leap, march int) {
+	gy := year + 621
+	leapJ, jp, jump := -14, persianBreaks[0], 0
+	for _, jm := range persianBreaks[1:] {
+		jump = jm - jp
+		if year < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+	n := year - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gy/4 - (gy/100+1)*3/4 - 150
+	march = 20 + leapJ - leapG
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	leap = ((n+1)%33 - 1) % 4
+	if leap == -1 {
+		leap = 4
+	}
+	return leap, march
+}
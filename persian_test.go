@@ -0,0 +1,65 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestPersianDateOf(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		expected datediff.PersianDate
+	}{
+		{date: date(2020, time.March, 20), expected: datediff.PersianDate{Year: 1399, Month: 1, Day: 1}},
+		{date: date(2021, time.March, 20), expected: datediff.PersianDate{Year: 1399, Month: 12, Day: 30}},
+		{date: date(2021, time.March, 21), expected: datediff.PersianDate{Year: 1400, Month: 1, Day: 1}},
+		{date: date(2023, time.March, 20), expected: datediff.PersianDate{Year: 1401, Month: 12, Day: 29}},
+		{date: date(2023, time.March, 21), expected: datediff.PersianDate{Year: 1402, Month: 1, Day: 1}},
+		{date: date(2023, time.September, 22), expected: datediff.PersianDate{Year: 1402, Month: 6, Day: 31}},
+		{date: date(2023, time.September, 23), expected: datediff.PersianDate{Year: 1402, Month: 7, Day: 1}},
+		{date: date(2024, time.March, 20), expected: datediff.PersianDate{Year: 1403, Month: 1, Day: 1}},
+		{date: date(2025, time.March, 20), expected: datediff.PersianDate{Year: 1403, Month: 12, Day: 30}},
+		{date: date(1979, time.February, 11), expected: datediff.PersianDate{Year: 1357, Month: 11, Day: 22}},
+	}
+	for _, tC := range testCases {
+		if got := datediff.PersianDateOf(tC.date); got != tC.expected {
+			t.Errorf("PersianDateOf(%s) = %+v, want %+v", tC.date.Format("2006-01-02"), got, tC.expected)
+		}
+	}
+}
+
+func TestPersianCalendar(t *testing.T) {
+	c := datediff.PersianCalendar{}
+	testCases := []struct {
+		desc     string
+		got      time.Time
+		expected time.Time
+	}{
+		{desc: "add year", got: c.AddYears(date(2023, time.March, 21), 1), expected: date(2024, time.March, 20)},
+		{desc: "add year to leap day", got: c.AddYears(date(2021, time.March, 20), 1), expected: date(2022, time.March, 21)},
+		{desc: "add month", got: c.AddMonths(date(2023, time.March, 21), 1), expected: date(2023, time.April, 21)},
+		{desc: "add month with day overflow", got: c.AddMonths(date(2023, time.September, 22), 1), expected: date(2023, time.October, 23)},
+		{desc: "add months over year end", got: c.AddMonths(date(2023, time.February, 20), 2), expected: date(2023, time.April, 21)},
+		{desc: "subtract months", got: c.AddMonths(date(2023, time.April, 21), -2), expected: date(2023, time.February, 20)},
+	}
+	for _, tC := range testCases {
+		if !tC.got.Equal(tC.expected) {
+			t.Errorf("%s = %s, want %s", tC.desc, tC.got.Format("2006-01-02"), tC.expected.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestPersianCalendarDiff(t *testing.T) {
+	// Nowruz to Nowruz is 1 year, while it's 1 day less than a Gregorian
+	// year
+	start, end := date(2023, time.March, 21), date(2024, time.March, 20)
+	got, err := datediff.NewDiff(start, end, "%Y %M %D", datediff.WithCalendar(datediff.PersianCalendar{}))
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	if want := (datediff.Diff{Years: 1}); !got.Equal(want) {
+		t.Errorf("NewDiff() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package datediff
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// maxPluralOperand is the limit of the plural rules operands, bigger values
+// are passed modulo this limit.
+const maxPluralOperand = 10_000_000
+
+// Pluralizer selects the plural category of a number.
+type Pluralizer interface {
+	PluralCategory(n int) PluralCategory
+}
+
+// PluralizerFunc is an adapter to allow the use of ordinary functions as
+// Pluralizer.
+type PluralizerFunc func(n int) PluralCategory
+
+// PluralCategory calls f(n).
+func (f PluralizerFunc) PluralCategory(n int) PluralCategory {
+	return f(n)
+}
+
+// cldrPluralizer selects plural categories according to CLDR cardinal rules
+// of the language.
+type cldrPluralizer struct {
+	tag language.Tag
+}
+
+// NewCLDRPluralizer creates Pluralizer that selects plural categories
+// according to CLDR cardinal plural rules of the language. Language is
+// a BCP 47 tag, i.e "ru" or "pt-BR".
+//
+// NewCLDRPluralizer returns error when language tag is malformed.
+func NewCLDRPluralizer(lang string) (Pluralizer, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return nil, err
+	}
+	return cldrPluralizer{tag: tag}, nil
+}
+
+func (p cldrPluralizer) PluralCategory(n int) PluralCategory {
+	if n < 0 {
+		n = -n
+	}
+	form := plural.Cardinal.MatchPlural(p.tag, n%maxPluralOperand, 0, 0, 0, 0)
+	switch form {
+	case plural.Zero:
+		return PluralZero
+	case plural.One:
+		return PluralOne
+	case plural.Two:
+		return PluralTwo
+	case plural.Few:
+		return PluralFew
+	case plural.Many:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
@@ -0,0 +1,94 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestCLDRPluralizer(t *testing.T) {
+	testCases := []struct {
+		lang     string
+		n        int
+		expected datediff.PluralCategory
+	}{
+		{lang: "en", n: 0, expected: datediff.PluralOther},
+		{lang: "en", n: 1, expected: datediff.PluralOne},
+		{lang: "en", n: 2, expected: datediff.PluralOther},
+		{lang: "fr", n: 0, expected: datediff.PluralOne},
+		{lang: "fr", n: 1, expected: datediff.PluralOne},
+		{lang: "fr", n: 2, expected: datediff.PluralOther},
+		{lang: "ru", n: 1, expected: datediff.PluralOne},
+		{lang: "ru", n: 3, expected: datediff.PluralFew},
+		{lang: "ru", n: 5, expected: datediff.PluralMany},
+		{lang: "ru", n: 11, expected: datediff.PluralMany},
+		{lang: "ru", n: 21, expected: datediff.PluralOne},
+		{lang: "ru", n: 22, expected: datediff.PluralFew},
+		{lang: "ru", n: 112, expected: datediff.PluralMany},
+		{lang: "ru", n: -21, expected: datediff.PluralOne},
+		{lang: "pl", n: 1, expected: datediff.PluralOne},
+		{lang: "pl", n: 21, expected: datediff.PluralMany},
+		{lang: "pl", n: 24, expected: datediff.PluralFew},
+		{lang: "ja", n: 1, expected: datediff.PluralOther},
+		{lang: "ar", n: 0, expected: datediff.PluralZero},
+		{lang: "ar", n: 2, expected: datediff.PluralTwo},
+	}
+	for _, tC := range testCases {
+		p, err := datediff.NewCLDRPluralizer(tC.lang)
+		if err != nil {
+			t.Errorf("NewCLDRPluralizer(%s) failed: %v", tC.lang, err)
+			continue
+		}
+		if got := p.PluralCategory(tC.n); got != tC.expected {
+			t.Errorf("NewCLDRPluralizer(%s).PluralCategory(%d) = %s, want %s", tC.lang, tC.n, got, tC.expected)
+		}
+	}
+
+	if _, err := datediff.NewCLDRPluralizer("not a tag"); err == nil {
+		t.Errorf("NewCLDRPluralizer(not a tag) want to fail due to malformed tag")
+	}
+}
+
+func TestLocalePluralizer(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2002, time.April, 18, 0, 0, 0, 0, time.UTC)
+	diff, err := datediff.NewDiff(start, end, "%Y %D")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+
+	units := map[string]map[datediff.PluralCategory]string{
+		"year":  {datediff.PluralOne: "{0} godina", datediff.PluralFew: "{0} godine", datediff.PluralOther: "{0} godina"},
+		"month": {datediff.PluralOther: "{0} mj."},
+		"week":  {datediff.PluralOther: "{0} tj."},
+		"day":   {datediff.PluralOne: "{0} dan", datediff.PluralOther: "{0} dana"},
+	}
+
+	testCases := []struct {
+		desc     string
+		locale   *datediff.Locale
+		expected string
+	}{
+		{
+			desc:     "CLDR rules of the locale language",
+			locale:   &datediff.Locale{Name: "hr", Units: units, Separator: " "},
+			expected: "2 godine 1 dan",
+		},
+		{
+			desc: "custom pluralizer",
+			locale: &datediff.Locale{Name: "hr", Units: units, Separator: " ",
+				Pluralizer: datediff.PluralizerFunc(func(int) datediff.PluralCategory { return datediff.PluralOther }),
+			},
+			expected: "2 godina 1 dana",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := datediff.Formatter{Locale: tC.locale}.String(diff)
+			if got != tC.expected {
+				t.Errorf("String() = %s, want %s", got, tC.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package datediff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// postgresUnits maps PostgreSQL interval units to dates difference modes.
+var postgresUnits = map[string]DiffMode{
+	"year":   ModeYears,
+	"years":  ModeYears,
+	"mon":    ModeMonths,
+	"mons":   ModeMonths,
+	"month":  ModeMonths,
+	"months": ModeMonths,
+	"week":   ModeWeeks,
+	"weeks":  ModeWeeks,
+	"day":    ModeDays,
+	"days":   ModeDays,
+}
+
+// PostgresIntervalString formats dates difference as PostgreSQL interval in
+// the default "postgres" output style, i.e "2 years 3 mons 4 days". PostgreSQL
+// stores intervals in months and days, so months overflow is converted to
+// years and weeks are converted to days. Zero dates difference is formatted
+// as "00:00:00".
+func (d Diff) PostgresIntervalString() string {
+	months := d.Years*monthsInYear + d.Months
+	fields := []struct {
+		n    int
+		unit string
+	}{
+		{n: months / monthsInYear, unit: "year"},
+		{n: months % monthsInYear, unit: "mon"},
+		{n: d.Weeks*daysInWeek + d.Days, unit: "day"},
+	}
+
+	var b strings.Builder
+	negative := false
+	for _, f := range fields {
+		if f.n == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+			// PostgreSQL marks positive field that follows negative one
+			if negative && f.n > 0 {
+				b.WriteByte('+')
+			}
+		}
+		b.WriteString(strconv.Itoa(f.n))
+		b.WriteByte(' ')
+		b.WriteString(f.unit)
+		if f.n != 1 {
+			b.WriteByte('s')
+		}
+		negative = f.n < 0
+	}
+
+	if b.Len() == 0 {
+		return "00:00:00"
+	}
+	return b.String()
+}
+
+// ParsePostgresInterval parses PostgreSQL interval in "postgres",
+// "postgres_verbose" ("@ 2 years 3 mons ago"), or "iso_8601" ("P2Y3M4D")
+// output styles. The interval should not have time part other than
+// "00:00:00", or zero values of "iso_8601" time part, i.e "PT0S", since
+// dates difference does not support time units. Weeks
+// are converted to days and months overflow is converted to years, as
+// PostgreSQL does. The returned dates difference has years, months and
+// days mode.
+func ParsePostgresInterval(s string) (Diff, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "P") {
+		return parsePostgresISO(s)
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(s, "@"))
+	ago := len(fields) > 0 && fields[len(fields)-1] == "ago"
+	if ago {
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return Diff{}, fmt.Errorf("invalid interval %q", s)
+	}
+
+	var months, days int
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			if strings.Trim(field, "+-0:.") != "" {
+				return Diff{}, fmt.Errorf("interval %q has time part, it is not supported", s)
+			}
+			continue
+		}
+
+		n, err := strconv.Atoi(field)
+		if err != nil || i+1 == len(fields) {
+			return Diff{}, fmt.Errorf("invalid interval %q", s)
+		}
+		i++
+		switch postgresUnits[strings.ToLower(fields[i])] {
+		case ModeYears:
+			months += n * monthsInYear
+		case ModeMonths:
+			months += n
+		case ModeWeeks:
+			days += n * daysInWeek
+		case ModeDays:
+			days += n
+		default:
+			return Diff{}, fmt.Errorf("invalid interval %q: unknown unit %q", s, fields[i])
+		}
+	}
+
+	if ago {
+		months, days = -months, -days
+	}
+	return postgresDiff(months, days), nil
+}
+
+// parsePostgresISO parses PostgreSQL interval in "iso_8601" output style.
+// Values of the time part following "T" designator should be zero.
+func parsePostgresISO(s string) (Diff, error) {
+	date, tm := s, ""
+	if i := strings.IndexByte(s, 'T'); i >= 0 {
+		date, tm = s[:i], s[i+1:]
+		if err := checkZeroISOTime(s, tm); err != nil {
+			return Diff{}, err
+		}
+	}
+	if date == "P" && tm != "" {
+		return postgresDiff(0, 0), nil
+	}
+	iso, err := parseISO(date)
+	if err != nil {
+		return Diff{}, err
+	}
+	return postgresDiff(iso.Years*monthsInYear+iso.Months, iso.Weeks*daysInWeek+iso.Days), nil
+}
+
+// checkZeroISOTime checks that ISO 8601 duration time part, i.e "0H0M0.5S",
+// has hours, minutes and seconds designators in order and zero values.
+func checkZeroISOTime(s, tm string) error {
+	if tm == "" {
+		return fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	const designators = "HMS"
+	next := 0 // index of the next allowed designator
+	for tm != "" {
+		i := strings.IndexAny(tm, designators)
+		if i <= 0 {
+			return fmt.Errorf("invalid ISO 8601 duration %q", s)
+		}
+		j := strings.IndexByte(designators[next:], tm[i])
+		if j < 0 {
+			return fmt.Errorf("invalid ISO 8601 duration %q: unexpected designator %c", s, tm[i])
+		}
+		v, err := strconv.ParseFloat(tm[:i], 64)
+		if err != nil {
+			return fmt.Errorf("invalid ISO 8601 duration %q", s)
+		}
+		if v != 0 {
+			return fmt.Errorf("interval %q has time part, it is not supported", s)
+		}
+		next += j + 1
+		tm = tm[i+1:]
+	}
+	return nil
+}
+
+func postgresDiff(months, days int) Diff {
+	return Diff{
+		Years:  months / monthsInYear,
+		Months: months % monthsInYear,
+		Days:   days,
+		mode:   ModeYears | ModeMonths | ModeDays,
+	}
+}
@@ -0,0 +1,99 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestPostgresIntervalString(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		diff     datediff.Diff
+		expected string
+	}{
+		{diff: mustNewDiff(t, start, time.Date(2002, time.July, 21, 0, 0, 0, 0, time.UTC), "%Y %M %D"), expected: "2 years 3 mons 4 days"},
+		{diff: mustNewDiff(t, start, time.Date(2001, time.May, 18, 0, 0, 0, 0, time.UTC), "%Y %M %D"), expected: "1 year 1 mon 1 day"},
+		{diff: mustNewDiff(t, start, time.Date(2002, time.July, 21, 0, 0, 0, 0, time.UTC), "%M %W %D"), expected: "2 years 3 mons 4 days"},
+		{diff: mustNewDiff(t, start, time.Date(2002, time.April, 17, 0, 0, 0, 0, time.UTC), "%Y %M %D"), expected: "2 years"},
+		{diff: mustNewDiff(t, start, start, "%Y %M %D"), expected: "00:00:00"},
+		{diff: datediff.Diff{Years: -1, Months: -2, Days: 3}, expected: "-1 years -2 mons +3 days"},
+	}
+	for _, tC := range testCases {
+		if got := tC.diff.PostgresIntervalString(); got != tC.expected {
+			t.Errorf("PostgresIntervalString() = %s, want %s", got, tC.expected)
+		}
+	}
+}
+
+func TestParsePostgresInterval(t *testing.T) {
+	testCases := []struct {
+		interval string
+		expected datediff.Diff
+	}{
+		{interval: "2 years 3 mons 4 days", expected: datediff.Diff{Years: 2, Months: 3, Days: 4}},
+		{interval: "1 year 1 mon 1 day", expected: datediff.Diff{Years: 1, Months: 1, Days: 1}},
+		{interval: "14 mons", expected: datediff.Diff{Years: 1, Months: 2}},
+		{interval: "2 weeks 1 day", expected: datediff.Diff{Days: 15}},
+		{interval: "3 days 00:00:00", expected: datediff.Diff{Days: 3}},
+		{interval: "00:00:00", expected: datediff.Diff{}},
+		{interval: "-1 years -2 mons +3 days", expected: datediff.Diff{Years: -1, Months: -2, Days: 3}},
+		{interval: "@ 2 years 3 mons ago", expected: datediff.Diff{Years: -2, Months: -3}},
+		{interval: "P2Y3M4D", expected: datediff.Diff{Years: 2, Months: 3, Days: 4}},
+		{interval: "P1M2W", expected: datediff.Diff{Months: 1, Days: 14}},
+		{interval: "PT0S", expected: datediff.Diff{}},
+		{interval: "P1Y2MT0S", expected: datediff.Diff{Years: 1, Months: 2}},
+		{interval: "P3DT0H0M0.000S", expected: datediff.Diff{Days: 3}},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParsePostgresInterval(tC.interval)
+		if err != nil {
+			t.Errorf("ParsePostgresInterval(%s) failed: %v", tC.interval, err)
+		} else if !got.Equal(tC.expected) {
+			t.Errorf("ParsePostgresInterval(%s) = %#v, want %#v", tC.interval, got, tC.expected)
+		}
+	}
+
+	{
+		interval := "2 years 4 days"
+		expected := "2 years 0 months 4 days"
+		got, err := datediff.ParsePostgresInterval(interval)
+		if err != nil {
+			t.Errorf("ParsePostgresInterval(%s) failed: %v", interval, err)
+		} else if got.StringWithZeros() != expected {
+			t.Errorf("ParsePostgresInterval(%s) StringWithZeros() = %s, want %s", interval, got.StringWithZeros(), expected)
+		}
+		if got.PostgresIntervalString() != interval {
+			t.Errorf("ParsePostgresInterval(%s) PostgresIntervalString() = %s, want %s", interval, got.PostgresIntervalString(), interval)
+		}
+	}
+}
+
+func TestParsePostgresIntervalFails(t *testing.T) {
+	testCases := []struct {
+		interval string
+		expected string
+	}{
+		{interval: "", expected: `invalid interval ""`},
+		{interval: "2 years 3", expected: `invalid interval "2 years 3"`},
+		{interval: "years 3", expected: `invalid interval "years 3"`},
+		{interval: "2 hours", expected: `invalid interval "2 hours": unknown unit "hours"`},
+		{interval: "1 day 04:05:06", expected: `interval "1 day 04:05:06" has time part, it is not supported`},
+		{interval: "P2H", expected: `invalid ISO 8601 duration "P2H": unexpected designator H`},
+		{interval: "P1DT4H", expected: `interval "P1DT4H" has time part, it is not supported`},
+		{interval: "PT0.5S", expected: `interval "PT0.5S" has time part, it is not supported`},
+		{interval: "P1DT", expected: `invalid ISO 8601 duration "P1DT"`},
+		{interval: "PT0S0M", expected: `invalid ISO 8601 duration "PT0S0M": unexpected designator M`},
+		{interval: "PTxS", expected: `invalid ISO 8601 duration "PTxS"`},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.ParsePostgresInterval(tC.interval)
+		if err == nil {
+			t.Errorf("ParsePostgresInterval(%s) = %#v, want to fail due to %s", tC.interval, got, tC.expected)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParsePostgresInterval(%s) failed: %v, want to fail due to %s", tC.interval, err, tC.expected)
+		}
+	}
+}
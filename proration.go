@@ -0,0 +1,56 @@
+package datediff
+
+import "time"
+
+// Charge is the prorated charge of the calendar month of the term.
+type Charge struct {
+	Period
+	Amount int64 // amount in minor units of the currency, i.e cents
+}
+
+// Prorate splits the monthly amount across calendar months of the term, as
+// leases and subscriptions are charged. Full months are charged the monthly
+// amount, and partial months are charged for their days by the actual number
+// of days in the month, i.e the term from January 15 to March 10 is charged
+// for 17 of 31 days of January, the full month of February, and 9 of 31 days
+// of March. Amounts are in minor units of the currency and rounded half away
+// from zero, time of the day is ignored, so partial days are not charged.
+// Options that adjust the dates are applied, i.e WithInclusiveEnd charges
+// the last day of the term.
+//
+// Prorate returns error when start date is after end date.
+func Prorate(start, end time.Time, monthly int64, opts ...Option) ([]Charge, error) {
+	if start.After(end) {
+		return nil, ErrStartAfterEnd
+	}
+	o := newOptions(opts)
+	start, end = o.dates(start, end)
+
+	var charges []Charge
+	EachMonth(start, end)(func(p Period) bool {
+		amount := monthly
+		if p.Diff.Months == 0 {
+			amount = prorated(monthly, p.Diff.Days, daysIn(p.Start.Year(), p.Start.Month()))
+		}
+		charges = append(charges, Charge{Period: p, Amount: amount})
+		return true
+	})
+	return charges, nil
+}
+
+// prorated returns the part of the amount for days of the total days rounded
+// half away from zero.
+func prorated(amount int64, days, total int) int64 {
+	n := 2 * amount * int64(days)
+	if n < 0 {
+		n -= int64(total)
+	} else {
+		n += int64(total)
+	}
+	return n / (2 * int64(total))
+}
+
+// daysIn returns the number of days in the month of the Gregorian calendar.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
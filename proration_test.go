@@ -0,0 +1,80 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestProrate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		monthly  int64
+		opts     []datediff.Option
+		expected []int64
+	}{
+		{
+			desc:     "partial months",
+			start:    date(2021, time.January, 15),
+			end:      date(2021, time.March, 10),
+			monthly:  3100,
+			expected: []int64{1700, 3100, 900},
+		},
+		{
+			desc:     "leap year February",
+			start:    date(2024, time.February, 15),
+			end:      date(2024, time.March, 1),
+			monthly:  2900,
+			expected: []int64{1500},
+		},
+		{
+			desc:     "rounding",
+			start:    date(2021, time.April, 1),
+			end:      date(2021, time.April, 2),
+			monthly:  100005,
+			expected: []int64{3334}, // 3333.5
+		},
+		{
+			desc:     "inclusive end",
+			start:    date(2021, time.June, 1),
+			end:      date(2021, time.June, 30),
+			monthly:  1000,
+			opts:     []datediff.Option{datediff.WithInclusiveEnd()},
+			expected: []int64{1000},
+		},
+		{
+			desc:    "empty term",
+			start:   date(2021, time.June, 1),
+			end:     date(2021, time.June, 1),
+			monthly: 1000,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			charges, err := datediff.Prorate(tC.start, tC.end, tC.monthly, tC.opts...)
+			if err != nil {
+				t.Fatalf("Prorate() failed: %v", err)
+			}
+			var got []int64
+			for _, c := range charges {
+				got = append(got, c.Amount)
+			}
+			if len(got) != len(tC.expected) {
+				t.Fatalf("Prorate() = %v, want %v", got, tC.expected)
+			}
+			for i := range got {
+				if got[i] != tC.expected[i] {
+					t.Errorf("Prorate() = %v, want %v", got, tC.expected)
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := datediff.Prorate(date(2021, time.June, 2), date(2021, time.June, 1), 1000); err != datediff.ErrStartAfterEnd {
+		t.Errorf("Prorate() error = %v, want %v", err, datediff.ErrStartAfterEnd)
+	}
+}
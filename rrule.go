@@ -0,0 +1,472 @@
+package datediff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRRuleYear is the last year occurrences of recurrence rules are searched
+// in, it stops the search for rules that never match, i.e February 30.
+const maxRRuleYear = 9999
+
+// rruleFrequency is the FREQ part of the recurrence rule.
+type rruleFrequency uint8
+
+const (
+	rruleDaily rruleFrequency = iota + 1
+	rruleWeekly
+	rruleMonthly
+	rruleYearly
+)
+
+var rruleFrequencies = map[string]rruleFrequency{
+	"DAILY":   rruleDaily,
+	"WEEKLY":  rruleWeekly,
+	"MONTHLY": rruleMonthly,
+	"YEARLY":  rruleYearly,
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// rruleDay is the BYDAY value of the recurrence rule, i.e -1FR is the last
+// Friday of the month.
+type rruleDay struct {
+	n       int // occurrence of the weekday in the month or the year, 0 is every
+	weekday time.Weekday
+}
+
+// RRule is the recurrence rule of RFC 5545, i.e "FREQ=WEEKLY;BYDAY=TU". These
+// rule parts are supported:
+//
+//	FREQ - DAILY, WEEKLY, MONTHLY or YEARLY
+//	INTERVAL - the number of periods of the frequency between occurrences
+//	COUNT - the number of occurrences
+//	UNTIL - the date of the last occurrence, inclusive
+//	BYDAY - days of the week, i.e MO,TU, or their occurrences in the month
+//	or the year for MONTHLY and YEARLY rules, i.e 2TU or -1FR
+//	BYMONTHDAY - days of the month, negative days count from the month end
+//	BYMONTH - months of the year
+//	WKST - the first day of the week, Monday is the default
+//
+// Rules of hours, minutes and seconds, BYSETPOS, BYYEARDAY and BYWEEKNO are
+// not supported. Occurrences keep the time of the day of the start date of
+// the rule, and invalid dates are skipped, i.e monthly occurrences of the
+// 31st day happen only in months that have it.
+type RRule struct {
+	freq       rruleFrequency
+	interval   int
+	count      int
+	until      time.Time
+	floating   bool // UNTIL is in the location of the start date
+	byDay      []rruleDay
+	byMonthDay []int
+	byMonth    []time.Month
+	wkst       time.Weekday
+}
+
+// ParseRRule parses the recurrence rule, i.e "FREQ=MONTHLY;BYDAY=-1FR". The
+// rule may have the "RRULE:" prefix.
+//
+// ParseRRule returns error when the rule has unknown or unsupported parts, or
+// values of its parts are invalid.
+func ParseRRule(s string) (RRule, error) {
+	r := RRule{interval: 1, wkst: time.Monday}
+	rule := strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if rule == "" {
+		return RRule{}, fmt.Errorf("invalid rrule %q", s)
+	}
+	for _, part := range strings.Split(rule, ";") {
+		i := strings.IndexByte(part, '=')
+		if i <= 0 {
+			return RRule{}, fmt.Errorf("invalid rrule part %q", part)
+		}
+		if err := r.parsePart(part[:i], part[i+1:]); err != nil {
+			return RRule{}, fmt.Errorf("rrule part %q: %w", part, err)
+		}
+	}
+
+	switch {
+	case r.freq == 0:
+		return RRule{}, fmt.Errorf("rrule %q has no frequency", s)
+	case r.count > 0 && !r.until.IsZero():
+		return RRule{}, fmt.Errorf("rrule %q has both COUNT and UNTIL", s)
+	case r.freq == rruleWeekly && len(r.byMonthDay) > 0:
+		return RRule{}, fmt.Errorf("rrule %q has BYMONTHDAY with WEEKLY frequency", s)
+	}
+	if r.freq == rruleDaily || r.freq == rruleWeekly {
+		for _, d := range r.byDay {
+			if d.n != 0 {
+				return RRule{}, fmt.Errorf("rrule %q has BYDAY occurrences with %s frequency", s, r.freqName())
+			}
+		}
+	}
+	return r, nil
+}
+
+func (r *RRule) parsePart(name, value string) error {
+	switch name {
+	case "FREQ":
+		freq, ok := rruleFrequencies[value]
+		if !ok {
+			return fmt.Errorf("unknown frequency %q", value)
+		}
+		r.freq = freq
+	case "INTERVAL":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid interval %q", value)
+		}
+		r.interval = n
+	case "COUNT":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid count %q", value)
+		}
+		r.count = n
+	case "UNTIL":
+		return r.parseUntil(value)
+	case "BYDAY":
+		for _, v := range strings.Split(value, ",") {
+			if len(v) < 2 {
+				return fmt.Errorf("invalid day %q", v)
+			}
+			weekday, ok := rruleWeekdays[v[len(v)-2:]]
+			if !ok {
+				return fmt.Errorf("invalid day %q", v)
+			}
+			var n int
+			if v = v[:len(v)-2]; v != "" {
+				var err error
+				if n, err = strconv.Atoi(v); err != nil || n == 0 || n < -53 || n > 53 {
+					return fmt.Errorf("invalid day occurrence %q", v)
+				}
+			}
+			r.byDay = append(r.byDay, rruleDay{n: n, weekday: weekday})
+		}
+	case "BYMONTHDAY":
+		for _, v := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(v)
+			if err != nil || n == 0 || n < -31 || n > 31 {
+				return fmt.Errorf("invalid day of the month %q", v)
+			}
+			r.byMonthDay = append(r.byMonthDay, n)
+		}
+	case "BYMONTH":
+		for _, v := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 || n > monthsInYear {
+				return fmt.Errorf("invalid month %q", v)
+			}
+			r.byMonth = append(r.byMonth, time.Month(n))
+		}
+	case "WKST":
+		weekday, ok := rruleWeekdays[value]
+		if !ok {
+			return fmt.Errorf("invalid day %q", value)
+		}
+		r.wkst = weekday
+	case "BYSECOND", "BYMINUTE", "BYHOUR", "BYYEARDAY", "BYWEEKNO", "BYSETPOS":
+		return fmt.Errorf("%s is not supported", name)
+	default:
+		return fmt.Errorf("unknown part %s", name)
+	}
+	return nil
+}
+
+func (r *RRule) parseUntil(value string) error {
+	layout := "20060102T150405"
+	switch {
+	case len(value) == len("20060102"):
+		layout = "20060102"
+		r.floating = true
+	case strings.HasSuffix(value, "Z"):
+		value = strings.TrimSuffix(value, "Z")
+	default:
+		r.floating = true
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return fmt.Errorf("invalid date %q", value)
+	}
+	if layout == "20060102" {
+		// the date includes the whole day
+		t = t.Add(Day - time.Nanosecond)
+	}
+	r.until = t
+	return nil
+}
+
+func (r RRule) freqName() string {
+	for name, freq := range rruleFrequencies {
+		if freq == r.freq {
+			return name
+		}
+	}
+	return ""
+}
+
+// Each returns the iterator over occurrences of the rule that starts on the
+// start date, within the range of dates. The end date is not included in the
+// range. The start date is the first occurrence when it matches the rule.
+//
+// The iterator has the signature of iter.Seq[time.Time], in Go 1.23 and later
+// it can be used with range-over-func.
+func (r RRule) Each(start, end time.Time) func(yield func(time.Time) bool) {
+	return func(yield func(time.Time) bool) {
+		r.each(start, func(t time.Time) bool {
+			return t.Before(end) && yield(t)
+		})
+	}
+}
+
+// CountOccurrences returns the number of occurrences of the recurrence rule
+// that starts on the start date, within the range of dates, i.e the number of
+// Tuesdays for "FREQ=WEEKLY;BYDAY=TU". See RRule.Each for details.
+//
+// CountOccurrences returns error in the following cases:
+//
+//	start date is after end date
+//	invalid recurrence rule
+func CountOccurrences(rrule string, start, end time.Time) (int, error) {
+	if start.After(end) {
+		return 0, ErrStartAfterEnd
+	}
+	r, err := ParseRRule(rrule)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	r.Each(start, end)(func(time.Time) bool {
+		n++
+		return true
+	})
+	return n, nil
+}
+
+// NextOccurrence returns the first occurrence of the recurrence rule that
+// starts on the start date, that is after the date. It returns zero time when
+// there are no more occurrences.
+//
+// NextOccurrence returns error when the recurrence rule is invalid.
+func NextOccurrence(rrule string, start, after time.Time) (time.Time, error) {
+	r, err := ParseRRule(rrule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var next time.Time
+	r.each(start, func(t time.Time) bool {
+		if t.After(after) {
+			next = t
+			return false
+		}
+		return true
+	})
+	return next, nil
+}
+
+// each yields occurrences of the rule in order until yield returns false, or
+// the rule has no more occurrences.
+func (r RRule) each(start time.Time, yield func(time.Time) bool) {
+	until := r.until
+	if r.floating {
+		until = time.Date(until.Year(), until.Month(), until.Day(),
+			until.Hour(), until.Minute(), until.Second(), until.Nanosecond(), start.Location())
+	}
+	hour, min, sec := start.Clock()
+	s := newCivilDate(start)
+
+	var n int
+	for k := 0; ; k++ {
+		days, year := r.period(s, k)
+		if year > maxRRuleYear {
+			return
+		}
+		for _, day := range days {
+			y, m, d := civilFromDays(day)
+			t := time.Date(y, m, d, hour, min, sec, start.Nanosecond(), start.Location())
+			if t.Before(start) {
+				continue
+			}
+			if !until.IsZero() && t.After(until) {
+				return
+			}
+			if n++; !yield(t) || n == r.count {
+				return
+			}
+		}
+	}
+}
+
+// period returns sorted day numbers of occurrences in the k-th period of the
+// rule frequency, and the year of the period.
+func (r RRule) period(s civilDate, k int) ([]int64, int) {
+	step := k * r.interval
+	switch r.freq {
+	case rruleDaily:
+		day := s.days + int64(step)
+		y, m, d := civilFromDays(day)
+		if !r.matchMonth(m) || !r.matchMonthDay(y, m, d) || !r.matchWeekday(day) {
+			return nil, y
+		}
+		return []int64{day}, y
+	case rruleWeekly:
+		weekStart := s.days - weekOffset(s.t, r.wkst) + int64(step)*daysInWeek
+		var days []int64
+		for i := int64(0); i < daysInWeek; i++ {
+			day := weekStart + i
+			if _, m, _ := civilFromDays(day); !r.matchMonth(m) {
+				continue
+			}
+			if len(r.byDay) == 0 && civilWeekday(day) != int64(s.t.Weekday()) || !r.matchWeekday(day) {
+				continue
+			}
+			days = append(days, day)
+		}
+		y, _, _ := civilFromDays(weekStart)
+		return days, y
+	case rruleMonthly:
+		month := time.Date(s.year, time.Month(s.month+step), 1, 0, 0, 0, 0, time.UTC)
+		if !r.matchMonth(month.Month()) {
+			return nil, month.Year()
+		}
+		return r.monthDays(month.Year(), month.Month(), s.day), month.Year()
+	}
+
+	year := s.year + step
+	var days []int64
+	switch {
+	case len(r.byMonth) > 0:
+		for _, m := range r.byMonth {
+			days = append(days, r.monthDays(year, m, s.day)...)
+		}
+	case len(r.byMonthDay) > 0:
+		for m := time.January; m <= time.December; m++ {
+			days = append(days, r.monthDays(year, m, s.day)...)
+		}
+	case len(r.byDay) > 0:
+		days = byDayDays(r.byDay, civilDays(year, time.January, 1), civilDays(year+1, time.January, 1))
+	default:
+		days = r.monthDays(year, time.Month(s.month), s.day)
+	}
+	return sortedDays(days), year
+}
+
+// monthDays returns sorted day numbers of occurrences in the month. The day
+// of the start date is used when the rule has neither BYDAY nor BYMONTHDAY.
+func (r RRule) monthDays(year int, month time.Month, startDay int) []int64 {
+	first := civilDays(year, month, 1)
+	n := daysIn(year, month)
+	var days []int64
+	switch {
+	case len(r.byMonthDay) > 0:
+		for _, d := range r.byMonthDay {
+			if d < 0 {
+				d += n + 1
+			}
+			if d >= 1 && d <= n && r.matchWeekday(first+int64(d-1)) {
+				days = append(days, first+int64(d-1))
+			}
+		}
+	case len(r.byDay) > 0:
+		days = byDayDays(r.byDay, first, first+int64(n))
+	case startDay <= n:
+		days = append(days, first+int64(startDay-1))
+	}
+	return sortedDays(days)
+}
+
+// byDayDays returns day numbers of BYDAY weekdays in the range of days, the
+// end day is not included.
+func byDayDays(byDay []rruleDay, from, to int64) []int64 {
+	var days []int64
+	for _, bd := range byDay {
+		// the first day of the range with the weekday
+		first := from + (int64(bd.weekday)-civilWeekday(from)+daysInWeek)%daysInWeek
+		switch {
+		case bd.n == 0:
+			for day := first; day < to; day += daysInWeek {
+				days = append(days, day)
+			}
+		case bd.n > 0:
+			if day := first + int64(bd.n-1)*daysInWeek; day < to {
+				days = append(days, day)
+			}
+		default:
+			last := first + (to-1-first)/daysInWeek*daysInWeek
+			if day := last + int64(bd.n+1)*daysInWeek; day >= from {
+				days = append(days, day)
+			}
+		}
+	}
+	return days
+}
+
+func (r RRule) matchMonth(m time.Month) bool {
+	if len(r.byMonth) == 0 {
+		return true
+	}
+	for _, bm := range r.byMonth {
+		if bm == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RRule) matchMonthDay(year int, month time.Month, day int) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	n := daysIn(year, month)
+	for _, d := range r.byMonthDay {
+		if d == day || d+n+1 == day {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekday reports whether the day matches weekdays of BYDAY, the
+// occurrences of weekdays are not checked.
+func (r RRule) matchWeekday(day int64) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	weekday := time.Weekday(civilWeekday(day))
+	for _, bd := range r.byDay {
+		if bd.weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// civilWeekday returns the day of the week of the day number, 0 is Sunday.
+func civilWeekday(day int64) int64 {
+	// March 1, year 0 is Wednesday
+	return ((day+3)%daysInWeek + daysInWeek) % daysInWeek
+}
+
+// sortedDays sorts day numbers and removes duplicates.
+func sortedDays(days []int64) []int64 {
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	n := 0
+	for i, day := range days {
+		if i == 0 || day != days[n-1] {
+			days[n] = day
+			n++
+		}
+	}
+	return days[:n]
+}
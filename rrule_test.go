@@ -0,0 +1,194 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestCountOccurrences(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rrule    string
+		start    time.Time
+		end      time.Time
+		expected int
+	}{
+		{
+			desc:     "Tuesdays",
+			rrule:    "FREQ=WEEKLY;BYDAY=TU",
+			start:    date(2024, time.January, 1),
+			end:      date(2024, time.February, 1),
+			expected: 5,
+		},
+		{
+			desc:     "monthly billing on the 31st",
+			rrule:    "RRULE:FREQ=MONTHLY",
+			start:    date(2024, time.January, 31),
+			end:      date(2025, time.January, 1),
+			expected: 7,
+		},
+		{
+			desc:     "end date is not included",
+			rrule:    "FREQ=MONTHLY",
+			start:    date(2024, time.January, 15),
+			end:      date(2024, time.March, 15),
+			expected: 2,
+		},
+		{
+			desc:     "every other week with count",
+			rrule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR;COUNT=5",
+			start:    date(2024, time.January, 3),
+			end:      date(2025, time.January, 1),
+			expected: 5,
+		},
+		{
+			desc:     "daily until the date",
+			rrule:    "FREQ=DAILY;UNTIL=20240110",
+			start:    date(2024, time.January, 1).Add(9 * time.Hour),
+			end:      date(2025, time.January, 1),
+			expected: 10,
+		},
+		{
+			desc:     "weekdays of January",
+			rrule:    "FREQ=DAILY;BYMONTH=1;BYDAY=MO,TU,WE,TH,FR",
+			start:    date(2024, time.January, 1),
+			end:      date(2025, time.January, 1),
+			expected: 23,
+		},
+		{
+			desc:     "last day of the month",
+			rrule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:    date(2024, time.January, 1),
+			end:      date(2025, time.January, 1),
+			expected: 12,
+		},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.CountOccurrences(tC.rrule, tC.start, tC.end)
+		if err != nil {
+			t.Errorf("CountOccurrences() %s failed: %v", tC.desc, err)
+		} else if got != tC.expected {
+			t.Errorf("CountOccurrences() %s = %d, want %d", tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rrule    string
+		start    time.Time
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			desc:     "last Friday of the month",
+			rrule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			start:    date(2024, time.January, 1),
+			after:    date(2024, time.January, 1),
+			expected: date(2024, time.January, 26),
+		},
+		{
+			desc:     "Thanksgiving",
+			rrule:    "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			start:    date(2020, time.January, 1),
+			after:    date(2024, time.January, 1),
+			expected: date(2024, time.November, 28),
+		},
+		{
+			desc:     "20th Monday of the year",
+			rrule:    "FREQ=YEARLY;BYDAY=20MO",
+			start:    date(1997, time.January, 1),
+			after:    date(1997, time.January, 1),
+			expected: date(1997, time.May, 19),
+		},
+		{
+			desc:     "leap day",
+			rrule:    "FREQ=YEARLY",
+			start:    date(2020, time.February, 29),
+			after:    date(2020, time.March, 1),
+			expected: date(2024, time.February, 29),
+		},
+		{
+			desc:     "skipped week",
+			rrule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR",
+			start:    date(2024, time.January, 3),
+			after:    date(2024, time.January, 5),
+			expected: date(2024, time.January, 15),
+		},
+		{
+			desc:  "no more occurrences",
+			rrule: "FREQ=DAILY;COUNT=3",
+			start: date(2024, time.January, 1),
+			after: date(2024, time.January, 3),
+		},
+		{
+			desc:  "impossible date",
+			rrule: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			start: date(2024, time.January, 1),
+			after: date(2024, time.January, 1),
+		},
+	}
+	for _, tC := range testCases {
+		got, err := datediff.NextOccurrence(tC.rrule, tC.start, tC.after)
+		if err != nil {
+			t.Errorf("NextOccurrence() %s failed: %v", tC.desc, err)
+		} else if !got.Equal(tC.expected) {
+			t.Errorf("NextOccurrence() %s = %s, want %s", tC.desc, got, tC.expected)
+		}
+	}
+}
+
+func TestParseRRuleFails(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rrule    string
+		expected string
+	}{
+		{
+			desc:     "empty rule",
+			rrule:    "",
+			expected: `invalid rrule ""`,
+		},
+		{
+			desc:     "unknown frequency",
+			rrule:    "FREQ=HOURLY",
+			expected: `rrule part "FREQ=HOURLY": unknown frequency "HOURLY"`,
+		},
+		{
+			desc:     "no frequency",
+			rrule:    "BYDAY=TU",
+			expected: `rrule "BYDAY=TU" has no frequency`,
+		},
+		{
+			desc:     "count and until",
+			rrule:    "FREQ=DAILY;COUNT=1;UNTIL=20240101",
+			expected: `rrule "FREQ=DAILY;COUNT=1;UNTIL=20240101" has both COUNT and UNTIL`,
+		},
+		{
+			desc:     "weekly occurrences of days",
+			rrule:    "FREQ=WEEKLY;BYDAY=1TU",
+			expected: `rrule "FREQ=WEEKLY;BYDAY=1TU" has BYDAY occurrences with WEEKLY frequency`,
+		},
+		{
+			desc:     "unsupported part",
+			rrule:    "FREQ=MONTHLY;BYSETPOS=-1",
+			expected: `rrule part "BYSETPOS=-1": BYSETPOS is not supported`,
+		},
+		{
+			desc:     "invalid month",
+			rrule:    "FREQ=YEARLY;BYMONTH=13",
+			expected: `rrule part "BYMONTH=13": invalid month "13"`,
+		},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.ParseRRule(tC.rrule)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("ParseRRule() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package datediff
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ShiftCycle is the repeating roster of on and off days, i.e 4 days on and
+// 4 days off. Cycles start on the anchor date and repeat before and after it.
+type ShiftCycle struct {
+	on     []bool // on days of the cycle
+	shifts int    // the number of on days in the cycle
+	anchor int64
+}
+
+// NewShiftCycle creates shift cycle that starts on the anchor date. Lengths
+// are numbers of days of alternating on and off blocks, starting with on
+// days, i.e NewShiftCycle(anchor, 4, 4) is 4-on/4-off, and
+// NewShiftCycle(anchor, 2, 2, 3, 2, 2, 3) is the Panama schedule. The time of
+// the anchor date is ignored.
+//
+// NewShiftCycle returns error in the following cases:
+//
+//	no lengths are provided
+//	any length is not positive
+func NewShiftCycle(anchor time.Time, lengths ...int) (ShiftCycle, error) {
+	if len(lengths) == 0 {
+		return ShiftCycle{}, errors.New("shift cycle has no days")
+	}
+	c := ShiftCycle{anchor: newCivilDate(anchor).days}
+	for i, n := range lengths {
+		if n <= 0 {
+			return ShiftCycle{}, fmt.Errorf("invalid shift cycle length %d", n)
+		}
+		for j := 0; j < n; j++ {
+			c.on = append(c.on, i%2 == 0)
+		}
+		if i%2 == 0 {
+			c.shifts += n
+		}
+	}
+	return c, nil
+}
+
+// Len returns the number of days in the cycle.
+func (c ShiftCycle) Len() int {
+	return len(c.on)
+}
+
+// IsOn reports whether the date is the on day of the cycle.
+func (c ShiftCycle) IsOn(t time.Time) bool {
+	return c.isOn(newCivilDate(t).days)
+}
+
+// Cycles returns the number of complete cycles between dates and the
+// remainder in days, that are days before the first complete cycle and after
+// the last one, i.e "6 cycles and 2 days". Dates are converted to days as
+// AccrualDays does.
+//
+// Cycles returns error when start date is after end date.
+func (c ShiftCycle) Cycles(start, end time.Time) (int, Diff, error) {
+	if start.After(end) {
+		return 0, Diff{}, ErrStartAfterEnd
+	}
+	from := newCivilDate(start).days
+	to := newCivilDate(end.In(start.Location())).days
+	n := int64(len(c.on))
+	cycles, remainder := countPeriods(from, to, int((from-c.anchor)/n), func(k int) int64 {
+		return c.anchor + int64(k)*n
+	})
+	return cycles, Diff{Days: int(remainder), mode: ModeDays}, nil
+}
+
+// Shifts returns the number of on days between dates. The end date is not
+// included. Dates are converted to days as AccrualDays does.
+//
+// Shifts returns error when start date is after end date.
+func (c ShiftCycle) Shifts(start, end time.Time) (int, error) {
+	if start.After(end) {
+		return 0, ErrStartAfterEnd
+	}
+	from := newCivilDate(start).days
+	to := newCivilDate(end.In(start.Location())).days
+	n := int64(len(c.on))
+	shifts := (to - from) / n * int64(c.shifts)
+	for day := from + (to-from)/n*n; day < to; day++ {
+		if c.isOn(day) {
+			shifts++
+		}
+	}
+	return int(shifts), nil
+}
+
+func (c ShiftCycle) isOn(day int64) bool {
+	n := int64(len(c.on))
+	return c.on[((day-c.anchor)%n+n)%n]
+}
@@ -0,0 +1,81 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestShiftCycle(t *testing.T) {
+	anchor := date(2024, time.January, 1)
+	c, err := datediff.NewShiftCycle(anchor, 4, 4)
+	if err != nil {
+		t.Fatalf("NewShiftCycle() failed: %v", err)
+	}
+	if got := c.Len(); got != 8 {
+		t.Errorf("Len() = %d, want 8", got)
+	}
+
+	testCases := []struct {
+		desc      string
+		start     time.Time
+		end       time.Time
+		cycles    int
+		remainder int
+		shifts    int
+	}{
+		{desc: "aligned cycles", start: anchor, end: date(2024, time.February, 18), cycles: 6, shifts: 24},
+		{desc: "remainder", start: date(2024, time.January, 7), end: date(2024, time.February, 20), cycles: 5, remainder: 4, shifts: 22},
+		{desc: "before anchor", start: date(2023, time.December, 24), end: date(2024, time.January, 3), cycles: 1, remainder: 2, shifts: 6},
+		{desc: "no complete cycles", start: date(2024, time.January, 3), end: date(2024, time.January, 6), remainder: 3, shifts: 2},
+	}
+	for _, tC := range testCases {
+		cycles, remainder, err := c.Cycles(tC.start, tC.end)
+		if err != nil {
+			t.Errorf("Cycles() %s failed: %v", tC.desc, err)
+		} else if cycles != tC.cycles || !remainder.Equal(datediff.Diff{Days: tC.remainder}) {
+			t.Errorf("Cycles() %s = %d, %v, want %d, %d days", tC.desc, cycles, remainder, tC.cycles, tC.remainder)
+		}
+		shifts, err := c.Shifts(tC.start, tC.end)
+		if err != nil {
+			t.Errorf("Shifts() %s failed: %v", tC.desc, err)
+		} else if shifts != tC.shifts {
+			t.Errorf("Shifts() %s = %d, want %d", tC.desc, shifts, tC.shifts)
+		}
+	}
+
+	for _, tC := range []struct {
+		date     time.Time
+		expected bool
+	}{
+		{date: anchor, expected: true},
+		{date: date(2024, time.January, 4), expected: true},
+		{date: date(2024, time.January, 5), expected: false},
+		{date: date(2023, time.December, 31), expected: false},
+		{date: date(2023, time.December, 27), expected: true},
+	} {
+		if got := c.IsOn(tC.date); got != tC.expected {
+			t.Errorf("IsOn(%s) = %t, want %t", tC.date.Format("2006-01-02"), got, tC.expected)
+		}
+	}
+}
+
+func TestNewShiftCycleFails(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		lengths  []int
+		expected string
+	}{
+		{desc: "no days", expected: "shift cycle has no days"},
+		{desc: "invalid length", lengths: []int{4, 0}, expected: "invalid shift cycle length 0"},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.NewShiftCycle(time.Now(), tC.lengths...)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("NewShiftCycle() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
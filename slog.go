@@ -0,0 +1,16 @@
+//go:build go1.21
+
+package datediff
+
+import "log/slog"
+
+// LogValue implements the slog.LogValuer interface. Dates difference is
+// logged as a group of years, months, weeks and days attributes.
+func (d Diff) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("years", d.Years),
+		slog.Int("months", d.Months),
+		slog.Int("weeks", d.Weeks),
+		slog.Int("days", d.Days),
+	)
+}
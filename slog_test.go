@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package datediff_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestLogValue(t *testing.T) {
+	start := time.Date(2000, time.April, 17, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2003, time.May, 18, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("uptime", "diff", mustNewDiff(t, start, end, "%Y %M %D"))
+
+	expected := "level=INFO msg=uptime diff.years=3 diff.months=1 diff.weeks=0 diff.days=1\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("Info() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,49 @@
+package datediff
+
+import (
+	"fmt"
+	"time"
+)
+
+// SplitRange splits the range of dates into n consecutive periods of equal
+// number of days, i.e for installment plans or report bucketing. When the
+// number of days is not divisible by n, the remaining days are distributed
+// one by one to the first periods, so that they are at most one day longer
+// than the last ones. The time of the end date that does not make a full day
+// belongs to the last period. Dates differences of periods are calculated in
+// days.
+//
+// SplitRange returns error in the following cases:
+//
+//	start date is after end date
+//	n is not positive
+//	the range has fewer days than n
+func SplitRange(start, end time.Time, n int) ([]Period, error) {
+	if start.After(end) {
+		return nil, ErrStartAfterEnd
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid number of periods %d", n)
+	}
+	days := newDiff(start, end, ModeDays).Days
+	if days < n {
+		return nil, fmt.Errorf("range of %d days cannot be split into %d periods", days, n)
+	}
+
+	periods := make([]Period, n)
+	size, remainder := days/n, days%n
+	from, offset := start, 0
+	for i := range periods {
+		offset += size
+		if i < remainder {
+			offset++
+		}
+		to := start.AddDate(0, 0, offset)
+		if i == n-1 {
+			to = end
+		}
+		periods[i] = Period{Start: from, End: to, Diff: newDiff(from, to, ModeDays)}
+		from = to
+	}
+	return periods, nil
+}
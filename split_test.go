@@ -0,0 +1,121 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestSplitRange(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		start    string
+		end      string
+		n        int
+		expected []string // start and end dates of periods
+	}{
+		{
+			desc:     "equal periods",
+			start:    "2020-01-01",
+			end:      "2020-01-31",
+			n:        3,
+			expected: []string{"2020-01-01", "2020-01-11", "2020-01-11", "2020-01-21", "2020-01-21", "2020-01-31"},
+		},
+		{
+			desc:     "remaining days go to the first periods",
+			start:    "2020-01-01",
+			end:      "2020-01-12",
+			n:        4,
+			expected: []string{"2020-01-01", "2020-01-04", "2020-01-04", "2020-01-07", "2020-01-07", "2020-01-10", "2020-01-10", "2020-01-12"},
+		},
+		{
+			desc:     "single period",
+			start:    "2020-01-01",
+			end:      "2021-01-01",
+			n:        1,
+			expected: []string{"2020-01-01", "2021-01-01"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			start, _ := time.Parse(dateFmt, tC.start)
+			end, _ := time.Parse(dateFmt, tC.end)
+			periods, err := datediff.SplitRange(start, end, tC.n)
+			if err != nil {
+				t.Fatalf("SplitRange() failed: %v", err)
+			}
+			var got []string
+			for _, p := range periods {
+				got = append(got, p.Start.Format(dateFmt), p.End.Format(dateFmt))
+				if want := datediff.MustNewDiff(p.Start, p.End, "%D"); !p.Diff.Equal(want) {
+					t.Errorf("SplitRange() period diff = %v, want %v", p.Diff, want)
+				}
+			}
+			if len(got) != len(tC.expected) {
+				t.Fatalf("SplitRange() = %v, want %v", got, tC.expected)
+			}
+			for i := range got {
+				if got[i] != tC.expected[i] {
+					t.Errorf("SplitRange() = %v, want %v", got, tC.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSplitRangeTimeOfDay(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.January, 3, 12, 0, 0, 0, time.UTC)
+	periods, err := datediff.SplitRange(start, end, 2)
+	if err != nil {
+		t.Fatalf("SplitRange() failed: %v", err)
+	}
+	if got := periods[len(periods)-1].End; !got.Equal(end) {
+		t.Errorf("SplitRange() last period end = %s, want %s", got, end)
+	}
+}
+
+func TestSplitRangeFails(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		end      time.Time
+		n        int
+		expected string
+	}{
+		{
+			desc:     "start date is after end date",
+			start:    end,
+			end:      start,
+			n:        1,
+			expected: "start date is after end date",
+		},
+		{
+			desc:     "number of periods is not positive",
+			start:    start,
+			end:      end,
+			n:        0,
+			expected: "invalid number of periods 0",
+		},
+		{
+			desc:     "range is too short",
+			start:    start,
+			end:      end,
+			n:        3,
+			expected: "range of 2 days cannot be split into 3 periods",
+		},
+	}
+	for _, tC := range testCases {
+		_, err := datediff.SplitRange(tC.start, tC.end, tC.n)
+		if err == nil {
+			t.Errorf("want to fail due to %s", tC.desc)
+		} else if err.Error() != tC.expected {
+			t.Errorf("SplitRange() error = %v, want %s", err, tC.expected)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package datediff
+
+import "time"
+
+// TenureFormatter formats tenure in HR reports with short English units
+// names, i.e "5 yrs 3 mos". Tenure of less than a month is "0 mos".
+var TenureFormatter = Formatter{Locale: tenureLocale(), ShowZero: true}
+
+// Tenure returns the length of service of the employee hired on the date as
+// of the date, i.e "5 years 3 months". Tenure is calculated in years and
+// months, both dates are converted and truncated as AgeAt does. Use
+// TenureFormatter for reports.
+func Tenure(hire, asOf time.Time, opts ...Option) (Diff, error) {
+	start, end := daysOf(hire, asOf)
+	return NewDiffWithMode(start, end, ModeYears|ModeMonths, opts...)
+}
+
+// ServiceMilestones returns the iterator over upcoming service anniversaries
+// of the employee hired on the date, that are after the date and are every n
+// years of service, i.e 5, 10, 15 years when n is 5. It yields years of
+// service and the date of the anniversary. Anniversaries are calculated as
+// NextAnniversary does. The iterator yields nothing when n is not positive.
+//
+// The iterator has the signature of iter.Seq2[int, time.Time], in Go 1.23 and
+// later it can be used with range-over-func.
+func ServiceMilestones(hire, after time.Time, n int, opts ...Option) func(yield func(int, time.Time) bool) {
+	return func(yield func(int, time.Time) bool) {
+		if n <= 0 {
+			return
+		}
+		o := newOptions(opts)
+		_, years := NextAnniversary(hire, after, opts...)
+		for years = (years + n - 1) / n * n; ; years += n {
+			if !yield(years, o.anniversary(hire, years)) {
+				return
+			}
+		}
+	}
+}
+
+// tenureLocale returns English locale with short units names.
+func tenureLocale() *Locale {
+	l := *english
+	l.Units = map[string]map[PluralCategory]string{
+		"year":  {PluralOne: "{0} yr", PluralOther: "{0} yrs"},
+		"month": {PluralOne: "{0} mo", PluralOther: "{0} mos"},
+		"week":  {PluralOne: "{0} wk", PluralOther: "{0} wks"},
+		"day":   {PluralOne: "{0} day", PluralOther: "{0} days"},
+	}
+	return &l
+}
+
+// startOfDayIn returns the start of the day of the date in the location.
+func startOfDayIn(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,108 @@
+package datediff_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestTenure(t *testing.T) {
+	hire := date(2019, time.January, 31)
+
+	testCases := []struct {
+		asOf     time.Time
+		expected string
+	}{
+		{asOf: date(2019, time.February, 15), expected: "0 mos"},
+		{asOf: date(2019, time.March, 3).Add(18 * time.Hour), expected: "1 mo"},
+		{asOf: date(2024, time.May, 1), expected: "5 yrs 3 mos"},
+		{asOf: date(2020, time.January, 31), expected: "1 yr"},
+	}
+	for _, tC := range testCases {
+		d, err := datediff.Tenure(hire, tC.asOf)
+		if err != nil {
+			t.Errorf("Tenure() failed: %v", err)
+		} else if got := datediff.TenureFormatter.String(d); got != tC.expected {
+			t.Errorf("Tenure(%s) = %q, want %q", tC.asOf, got, tC.expected)
+		}
+	}
+
+	// the time of the day of the hire date does not affect anniversaries
+	hired := time.Date(2019, time.January, 15, 9, 30, 0, 0, time.UTC)
+	for _, tC := range []struct {
+		asOf     time.Time
+		expected string
+	}{
+		{asOf: date(2019, time.January, 15), expected: "0 mos"},
+		{asOf: date(2019, time.February, 15), expected: "1 mo"},
+		{asOf: time.Date(2024, time.January, 15, 8, 0, 0, 0, time.UTC), expected: "5 yrs"},
+	} {
+		d, err := datediff.Tenure(hired, tC.asOf)
+		if err != nil {
+			t.Errorf("Tenure(%s) failed: %v", tC.asOf, err)
+		} else if got := datediff.TenureFormatter.String(d); got != tC.expected {
+			t.Errorf("Tenure(%s) of hire at %s = %q, want %q", tC.asOf, hired, got, tC.expected)
+		}
+	}
+}
+
+func TestServiceMilestones(t *testing.T) {
+	hire := date(2016, time.February, 29)
+
+	testCases := []struct {
+		desc     string
+		after    time.Time
+		n        int
+		opts     []datediff.Option
+		expected []string
+	}{
+		{
+			desc:     "every 5 years",
+			after:    date(2024, time.June, 1),
+			n:        5,
+			expected: []string{"10 2026-03-01", "15 2031-03-01", "20 2036-02-29"},
+		},
+		{
+			desc:     "on the anniversary",
+			after:    date(2021, time.March, 1),
+			n:        5,
+			expected: []string{"10 2026-03-01", "15 2031-03-01", "20 2036-02-29"},
+		},
+		{
+			desc:     "before the anniversary",
+			after:    date(2021, time.February, 28),
+			n:        5,
+			expected: []string{"5 2021-03-01", "10 2026-03-01", "15 2031-03-01"},
+		},
+		{
+			desc:     "leap day policy",
+			after:    date(2021, time.February, 27),
+			n:        5,
+			opts:     []datediff.Option{datediff.WithLeapDayPolicy(datediff.LeapDayFeb28)},
+			expected: []string{"5 2021-02-28", "10 2026-02-28", "15 2031-02-28"},
+		},
+		{
+			desc:  "not positive step",
+			after: date(2021, time.February, 27),
+		},
+	}
+	for _, tC := range testCases {
+		var got []string
+		datediff.ServiceMilestones(hire, tC.after, tC.n, tC.opts...)(func(years int, t time.Time) bool {
+			got = append(got, fmt.Sprintf("%d %s", years, t.Format("2006-01-02")))
+			return len(got) < 3
+		})
+		if len(got) != len(tC.expected) {
+			t.Errorf("ServiceMilestones() %s = %q, want %q", tC.desc, got, tC.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tC.expected[i] {
+				t.Errorf("ServiceMilestones() %s = %q, want %q", tC.desc, got, tC.expected)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package datediff
+
+import "time"
+
+// BuddhistEraOffset is the difference of years of the Buddhist era and the
+// Common era, i.e 2024 is 2567 of the Buddhist era.
+const BuddhistEraOffset = 543
+
+// thaiNewYearChange is the year the Thai new year moved from April 1 to
+// January 1, the year 2483 of the Buddhist era lasted from April 1 to
+// December 31, 1940.
+const thaiNewYearChange = 1941
+
+// ThaiDate is the date of the Thai solar calendar, i.e 2567-01-15 is
+// January 15, 2024.
+type ThaiDate struct {
+	Year  int // year of the Buddhist era
+	Month time.Month
+	Day   int
+}
+
+// ThaiDateOf returns the Thai solar calendar date of the date in its
+// location. Years before 1941 start on April 1, so January to March belong
+// to the previous year of the Buddhist era, i.e January 1, 1940 is
+// 2482-01-01.
+func ThaiDateOf(t time.Time) ThaiDate {
+	year, month, day := t.Date()
+	year += BuddhistEraOffset
+	if t.Year() < thaiNewYearChange && month < time.April {
+		year--
+	}
+	return ThaiDate{Year: year, Month: month, Day: day}
+}
+
+// Time returns the date at midnight in UTC.
+func (d ThaiDate) Time() time.Time {
+	year := d.Year - BuddhistEraOffset
+	if year < thaiNewYearChange && d.Month < time.April {
+		year++
+	}
+	return time.Date(year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// ThaiCalendar is the Thai solar calendar used in Thai official documents.
+// It has months and days of the Gregorian calendar and years of the
+// Buddhist era, so dates difference is the same as of GregorianCalendar and
+// calculated as fast. Dates of the calendar are converted with ThaiDateOf.
+type ThaiCalendar struct {
+	GregorianCalendar
+}
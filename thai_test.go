@@ -0,0 +1,46 @@
+package datediff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antklim/datediff"
+)
+
+func TestThaiDateOf(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		expected datediff.ThaiDate
+	}{
+		{date: date(2024, time.January, 15), expected: datediff.ThaiDate{Year: 2567, Month: time.January, Day: 15}},
+		{date: date(1941, time.January, 1), expected: datediff.ThaiDate{Year: 2484, Month: time.January, Day: 1}},
+		{date: date(1940, time.December, 31), expected: datediff.ThaiDate{Year: 2483, Month: time.December, Day: 31}},
+		{date: date(1940, time.April, 1), expected: datediff.ThaiDate{Year: 2483, Month: time.April, Day: 1}},
+		{date: date(1940, time.March, 31), expected: datediff.ThaiDate{Year: 2482, Month: time.March, Day: 31}},
+		{date: date(1940, time.January, 1), expected: datediff.ThaiDate{Year: 2482, Month: time.January, Day: 1}},
+	}
+	for _, tC := range testCases {
+		got := datediff.ThaiDateOf(tC.date)
+		if got != tC.expected {
+			t.Errorf("ThaiDateOf(%s) = %+v, want %+v", tC.date.Format("2006-01-02"), got, tC.expected)
+		}
+		if !got.Time().Equal(tC.date) {
+			t.Errorf("ThaiDate.Time() = %s, want %s", got.Time(), tC.date)
+		}
+	}
+}
+
+func TestThaiCalendarDiff(t *testing.T) {
+	start, end := date(2020, time.February, 29), date(2024, time.March, 15)
+	want, err := datediff.NewDiff(start, end, "%Y %M %W %D")
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	got, err := datediff.NewDiff(start, end, "%Y %M %W %D", datediff.WithCalendar(datediff.ThaiCalendar{}))
+	if err != nil {
+		t.Fatalf("NewDiff() failed: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("NewDiff() = %v, want %v", got, want)
+	}
+}